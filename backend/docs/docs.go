@@ -15,32 +15,57 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/categories": {
+        "/admin/answers": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère la liste de toutes les catégories (admin uniquement)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Liste paginée de toutes les réponses, des plus récentes aux plus anciennes, filtrable par statut (admin uniquement)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Questions"
+                ],
+                "summary": "Liste des réponses à modérer",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PENDING",
+                            "APPROVED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "Statut",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Numéro de page (défaut: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (défaut: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Liste toutes les catégories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.CategoryResponse"
-                            }
+                            "$ref": "#/definitions/dtos.PaginatedAnswersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Statut invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
                     "401": {
@@ -62,14 +87,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/answers/{answerID}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crée une nouvelle catégorie (admin uniquement)",
+                "description": "Supprime définitivement une réponse. Le motif, obligatoire, est enregistré avec l'administrateur et le texte supprimé dans le journal de modération (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,29 +104,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Questions"
                 ],
-                "summary": "Créer une catégorie",
+                "summary": "Supprimer une réponse (admin)",
                 "parameters": [
                     {
-                        "description": "Informations de la catégorie",
+                        "type": "string",
+                        "description": "ID de la réponse",
+                        "name": "answerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motif de la suppression",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryRequest"
+                            "$ref": "#/definitions/dtos.ModerateQuestionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CategoryResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Motif manquant",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -116,8 +147,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Catégorie avec ce nom existe déjà",
+                    "404": {
+                        "description": "Réponse non trouvée",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -131,29 +162,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/categories/{id}": {
-            "get": {
+        "/admin/answers/{answerID}/approve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère les détails d'une catégorie par son ID (admin uniquement)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Publie une réponse en attente ou précédemment refusée ; elle est visible dès que sa question est publiée (admin uniquement)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Questions"
                 ],
-                "summary": "Détails d'une catégorie",
+                "summary": "Approuver une réponse",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la catégorie",
-                        "name": "id",
+                        "description": "ID de la réponse",
+                        "name": "answerID",
                         "in": "path",
                         "required": true
                     }
@@ -162,7 +190,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryResponse"
+                            "$ref": "#/definitions/dtos.AnswerResponse"
                         }
                     },
                     "401": {
@@ -178,7 +206,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Réponse non trouvée",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -190,14 +218,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/answers/{answerID}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Met à jour une catégorie existante (admin uniquement)",
+                "description": "Retire une réponse de la fiche produit ; son auteur la voit avec le motif du refus (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -205,24 +235,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Questions"
                 ],
-                "summary": "Mettre à jour une catégorie",
+                "summary": "Refuser une réponse",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la catégorie",
-                        "name": "id",
+                        "description": "ID de la réponse",
+                        "name": "answerID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Nouvelles informations de la catégorie",
+                        "description": "Motif du refus",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryRequest"
+                            "$ref": "#/definitions/dtos.ModerateQuestionRequest"
                         }
                     }
                 ],
@@ -230,11 +260,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryResponse"
+                            "$ref": "#/definitions/dtos.AnswerResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Motif manquant",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -252,13 +282,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                        "description": "Réponse non trouvée",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -270,14 +294,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/categories": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Supprime une catégorie (admin uniquement)",
+                "description": "Récupère la liste de toutes les catégories (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,19 +313,16 @@ const docTemplate = `{
                 "tags": [
                     "Categories"
                 ],
-                "summary": "Supprimer une catégorie",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID de la catégorie",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Liste toutes les catégories",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.CategoryResponse"
+                            }
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -313,12 +336,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -327,13 +344,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Met à jour uniquement le nom d'une catégorie (admin uniquement). Exemple: changer uniquement le nom sans recréer la catégorie.",
+                "description": "Crée une nouvelle catégorie (admin uniquement). taxRate définit un taux de TVA propre aux produits de la catégorie (sinon VAT_DEFAULT_RATE).\nparentID rattache la catégorie à une catégorie parente ; sans slug, il est dérivé du nom (suffixé de -2, -3... s'il est déjà pris).",
                 "consumes": [
                     "application/json"
                 ],
@@ -343,28 +360,21 @@ const docTemplate = `{
                 "tags": [
                     "Categories"
                 ],
-                "summary": "Mettre à jour partiellement une catégorie",
+                "summary": "Créer une catégorie",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID de la catégorie",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Champs à mettre à jour (nom optionnel)",
+                        "description": "Informations de la catégorie",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.PatchCategoryRequest"
+                            "$ref": "#/definitions/dtos.CategoryRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/dtos.CategoryResponse"
                         }
@@ -387,14 +397,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/docs.ErrorResponse"
-                        }
-                    },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Catégorie avec ce nom ou ce slug existe déjà",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -408,14 +412,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/orders": {
+        "/admin/categories/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère toutes les commandes de tous les utilisateurs (admin uniquement)",
+                "description": "Récupère les détails d'une catégorie par son ID (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -423,17 +427,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Categories"
+                ],
+                "summary": "Détails d'une catégorie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la catégorie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Liste toutes les commandes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.OrderResponse"
-                            }
+                            "$ref": "#/definitions/dtos.CategoryResponse"
                         }
                     },
                     "401": {
@@ -448,6 +458,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -455,16 +471,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/orders/{id}/status": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Met à jour le statut d'une commande (PENDING, SHIPPED, DELIVERED, CANCELLED) - admin uniquement",
+                "description": "Met à jour une catégorie existante (admin uniquement). Sans taxRate, la catégorie revient au taux de TVA par défaut ; sans parentID, elle devient une catégorie racine ; sans slug, le slug existant est conservé.\nUne catégorie ne peut pas être déplacée dans l'une de ses sous-catégories.",
                 "consumes": [
                     "application/json"
                 ],
@@ -472,24 +486,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Categories"
                 ],
-                "summary": "Mettre à jour le statut d'une commande",
+                "summary": "Mettre à jour une catégorie",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de la commande",
+                        "description": "ID de la catégorie",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Nouveau statut",
+                        "description": "Nouvelles informations de la catégorie",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateOrderStatusRequest"
+                            "$ref": "#/definitions/dtos.CategoryRequest"
                         }
                     }
                 ],
@@ -497,11 +511,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderResponse"
+                            "$ref": "#/definitions/dtos.CategoryResponse"
                         }
                     },
                     "400": {
-                        "description": "Statut invalide",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -524,6 +538,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -531,16 +551,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/products": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crée un nouveau produit (admin uniquement)",
+                "description": "Supprime une catégorie (admin uniquement). Une catégorie contenant des sous-catégories ne peut pas être supprimée.\nSi la catégorie contient des produits, la suppression est refusée (409, avec leur nombre) sauf avec ?reassignTo={categoryID}, qui les déplace dans une autre catégorie, ou ?detach=true, qui les laisse sans catégorie.\nLes produits et la catégorie sont modifiés dans une même transaction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -548,29 +566,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Créer un produit",
+                "summary": "Supprimer une catégorie",
                 "parameters": [
                     {
-                        "description": "Informations du produit",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ProductRequest"
-                        }
+                        "type": "string",
+                        "description": "ID de la catégorie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de la catégorie recevant les produits",
+                        "name": "reassignTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Laisser les produits sans catégorie",
+                        "name": "detach",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ProductResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Options incompatibles ou catégorie de destination invalide",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -587,12 +612,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Produit avec ce nom existe déjà",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "La catégorie contient des produits ou des sous-catégories",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CategoryInUseResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -600,16 +631,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/products/{id}": {
-            "put": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Met à jour un produit existant (admin uniquement)",
+                "description": "Met à jour un ou plusieurs champs d'une catégorie (admin uniquement). Exemple: changer uniquement le nom sans recréer la catégorie.\nparentID \"\" fait remonter la catégorie à la racine ; description \"\" efface la description.",
                 "consumes": [
                     "application/json"
                 ],
@@ -617,24 +646,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Mettre à jour un produit",
+                "summary": "Mettre à jour partiellement une catégorie",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID du produit",
+                        "description": "ID de la catégorie",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Nouvelles informations du produit",
+                        "description": "Champs à mettre à jour (tous optionnels)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductRequest"
+                            "$ref": "#/definitions/dtos.PatchCategoryRequest"
                         }
                     }
                 ],
@@ -642,7 +671,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductResponse"
+                            "$ref": "#/definitions/dtos.CategoryResponse"
                         }
                     },
                     "400": {
@@ -682,14 +711,60 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/admin/coupons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère tous les codes promo, les plus récents en premier (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Liste les codes promo",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.CouponResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Supprime un produit (admin uniquement)",
+                "description": "Crée un code promo : pourcentage (PERCENTAGE), montant fixe (FIXED) ou livraison offerte (FREE_SHIPPING).\nLa remise peut être limitée à des produits ou catégories, soumise à un montant minimum, à une période de validité et à des limites d'utilisation globales ou par client (admin uniquement).",
                 "consumes": [
                     "application/json"
                 ],
@@ -697,21 +772,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Coupons"
                 ],
-                "summary": "Supprimer un produit",
+                "summary": "Créer un code promo",
+                "parameters": [
+                    {
+                        "description": "Paramètres du code promo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CouponResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Code déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/coupons/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère un code promo par son ID (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Détails d'un code promo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID du produit",
+                        "description": "ID du code promo",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CouponResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -739,13 +883,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Met à jour uniquement les champs fournis d'un produit (admin uniquement). Exemple: changer uniquement l'image sans modifier les autres champs.",
+                "description": "Remplace les paramètres d'un code promo (admin uniquement). Les commandes déjà passées conservent leur remise.",
                 "consumes": [
                     "application/json"
                 ],
@@ -753,24 +897,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Coupons"
                 ],
-                "summary": "Mettre à jour partiellement un produit",
+                "summary": "Mettre à jour un code promo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID du produit",
+                        "description": "ID du code promo",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Champs à mettre à jour (tous optionnels)",
+                        "description": "Nouveaux paramètres du code promo",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.PatchProductRequest"
+                            "$ref": "#/definitions/dtos.CouponRequest"
                         }
                     }
                 ],
@@ -778,7 +922,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductResponse"
+                            "$ref": "#/definitions/dtos.CouponResponse"
                         }
                     },
                     "400": {
@@ -806,7 +950,7 @@ const docTemplate = `{
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Code déjà utilisé",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -818,30 +962,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/user/{id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Supprime un utilisateur par son ID. Réservé aux administrateurs.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Supprime un code promo (admin uniquement). Les commandes qui l'ont utilisé conservent le code et la remise ; pour suspendre un code, préférez active=false.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Coupons"
                 ],
-                "summary": "Supprimer un utilisateur (Admin uniquement)",
+                "summary": "Supprimer un code promo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de l'utilisateur",
+                        "description": "ID du code promo",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -858,7 +997,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -872,32 +1017,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users": {
+        "/admin/coupons/{id}/stats": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère la liste de tous les utilisateurs. Réservé aux administrateurs.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Nombre d'utilisations, clients distincts, remises accordées et chiffre d'affaires généré. Les commandes annulées ne consomment pas d'utilisation (admin uniquement).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Coupons"
+                ],
+                "summary": "Statistiques d'un code promo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du code promo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Liste tous les utilisateurs (Admin uniquement)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.UserResponse"
-                            }
+                            "$ref": "#/definitions/dtos.CouponStatsResponse"
                         }
                     },
                     "401": {
@@ -907,7 +1055,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -921,9 +1075,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authentifie un utilisateur et retourne un token JWT",
+        "/admin/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère toutes les commandes de tous les utilisateurs (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -931,35 +1090,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "Connexion utilisateur",
-                "parameters": [
-                    {
-                        "description": "Identifiants de connexion",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.LoginRequest"
-                        }
-                    }
+                    "Orders"
                 ],
+                "summary": "Liste toutes les commandes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.LoginResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.OrderResponse"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Email ou mot de passe incorrect",
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -973,29 +1124,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/me": {
+        "/admin/orders/stream": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère les informations de l'utilisateur connecté via le token JWT",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Flux Server-Sent Events des nouvelles commandes (\"order.created\") et des changements de statut (\"order.status_changed\") de tous les clients (admin uniquement).\nHeartbeat, reprise via Last-Event-ID et événement \"resync\" comme pour /orders/stream.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Orders"
+                ],
+                "summary": "Flux temps réel des commandes (SSE, admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identifiant du dernier événement reçu",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Informations utilisateur actuel",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Flux d'événements",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserResponse"
+                            "$ref": "#/definitions/dtos.OrderStreamEvent"
                         }
                     },
                     "401": {
@@ -1004,8 +1160,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1013,9 +1169,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Crée un nouveau compte utilisateur et retourne un token JWT",
+        "/admin/orders/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Met à jour le statut d'une commande (PENDING, SHIPPED, DELIVERED, CANCELLED) - admin uniquement",
                 "consumes": [
                     "application/json"
                 ],
@@ -1023,35 +1184,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Orders"
                 ],
-                "summary": "Inscription d'un nouvel utilisateur",
+                "summary": "Mettre à jour le statut d'une commande",
                 "parameters": [
                     {
-                        "description": "Informations d'inscription",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.UserRequest"
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouveau statut",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateOrderStatusRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.LoginResponse"
+                            "$ref": "#/definitions/dtos.OrderResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Statut invalide",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Email déjà utilisé",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1065,14 +1245,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
-            "get": {
+        "/admin/payments/{id}/refund": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère toutes les commandes de l'utilisateur connecté",
+                "description": "Rembourse tout ou partie d'un paiement capturé auprès du prestataire. Sans montant, le solde restant est remboursé (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1080,17 +1260,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Payments"
+                ],
+                "summary": "Rembourser un paiement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du paiement",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Montant à rembourser",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RefundPaymentRequest"
+                        }
+                    }
                 ],
-                "summary": "Liste les commandes de l'utilisateur",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.OrderResponse"
-                            }
+                            "$ref": "#/definitions/dtos.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Montant invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1099,21 +1299,47 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Paiement non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Paiement non capturé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Erreur du prestataire de paiement",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/admin/payments/{id}/simulate": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crée une nouvelle commande avec les produits sélectionnés. Le stock est automatiquement déduit.",
+                "description": "Retourne un webhook signé du faux prestataire pour un paiement donné, à rejouer tel quel sur l'URL indiquée avec l'en-tête de signature.\nDisponible uniquement lorsque le faux prestataire est activé (PAYMENT_FAKE_ENABLED=true, en développement) (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1121,29 +1347,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Payments"
                 ],
-                "summary": "Créer une commande",
+                "summary": "Simuler un webhook de paiement",
                 "parameters": [
                     {
-                        "description": "Items de la commande",
+                        "type": "string",
+                        "description": "ID du paiement",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Événement à simuler",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateOrderRequest"
+                            "$ref": "#/definitions/dtos.SimulatePaymentWebhookRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderResponse"
+                            "$ref": "#/definitions/dtos.SignedWebhookResponse"
                         }
                     },
                     "400": {
-                        "description": "Stock insuffisant ou produit non trouvé",
+                        "description": "Type d'événement invalide",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1154,6 +1387,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Paiement non trouvé ou faux prestataire désactivé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1163,14 +1408,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
-            "get": {
+        "/admin/products": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère les détails d'une commande. L'utilisateur peut voir ses propres commandes, l'admin peut voir toutes les commandes.",
+                "description": "Crée un nouveau produit avec une variante par défaut \"Standard\" portant le prix et le stock (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1178,23 +1423,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Products"
                 ],
-                "summary": "Détails d'une commande",
+                "summary": "Créer un produit",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID de la commande",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Informations du produit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderResponse"
+                            "$ref": "#/definitions/dtos.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1203,8 +1456,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Produit avec ce nom existe déjà",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1218,16 +1477,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
-            "get": {
+        "/admin/products/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère la liste de tous les produits disponibles avec leurs catégories (authentification requise). Supporte la pagination via ?page=1\u0026limit=10",
+                "description": "Crée ou met à jour des produits en masse (admin uniquement). Les produits sont rapprochés par SKU puis par nom, les catégories par nom (créées si besoin).\nLe corps peut être un CSV (Content-Type: text/csv, en-têtes: name,sku,description,price,stock,imageURL,category et optionnellement ingredients,skinTypes,concerns (valeurs séparées par |),volumeML,paoMonths), un tableau JSON (application/json) ou un formulaire multipart avec un champ \"file\".\nLes attributs skincare sont validés comme à la création ; pour un produit existant, un attribut absent de la ligne n'est pas modifié.\nToutes les écritures sont faites dans une seule transaction : si une ligne est en erreur, rien n'est enregistré. Utilisez ?dryRun=true pour obtenir le rapport sans rien modifier.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -1235,29 +1496,43 @@ const docTemplate = `{
                 "tags": [
                     "Products"
                 ],
-                "summary": "Liste tous les produits",
+                "summary": "Importer des produits (CSV/JSON)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Numéro de page (défaut: 1)",
-                        "name": "page",
+                        "type": "boolean",
+                        "description": "Simulation sans écriture (défaut: false)",
+                        "name": "dryRun",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Nombre d'éléments par page (défaut: 10, max: 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Produits à importer (JSON)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ImportProductRow"
+                            }
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Fichier CSV ou JSON (multipart)",
+                        "name": "file",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Si page et limit ne sont pas fournis",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.ProductResponse"
-                            }
+                            "$ref": "#/definitions/dtos.ImportProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Fichier invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1266,6 +1541,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Au moins une ligne est en erreur - rien n'a été importé",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportProductsResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1275,44 +1562,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
+        "/admin/products/ratings/recompute": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère les détails d'un produit par son ID (authentification requise)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Recalcule la note moyenne, le nombre d'avis et l'histogramme de chaque produit à partir de ses avis approuvés, et retourne le nombre de produits corrigés.\nCes agrégats sont tenus à jour à chaque écriture d'avis : le recalcul sert après une intervention directe en base (admin uniquement).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Products"
                 ],
-                "summary": "Détails d'un produit",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID du produit",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Recalculer les notes des produits",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/docs.ErrorResponse"
+                            "$ref": "#/definitions/dtos.RecomputeRatingsResponse"
                         }
                     },
                     "401": {
@@ -1321,8 +1590,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1336,14 +1605,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{productID}/reviews": {
-            "get": {
+        "/admin/products/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère tous les avis d'un produit avec statistiques (moyenne, total)",
+                "description": "Met à jour un produit existant (admin uniquement). Le prix et le stock sont appliqués à la variante par défaut ; le stock du produit est la somme des variantes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1351,23 +1620,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Products"
                 ],
-                "summary": "Liste des avis d'un produit",
+                "summary": "Mettre à jour un produit",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "ID du produit",
-                        "name": "productID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Nouvelles informations du produit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductReviewsResponse"
+                            "$ref": "#/definitions/dtos.ProductResponse"
                         }
                     },
                     "400": {
@@ -1382,27 +1660,39 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permet à un utilisateur authentifié de laisser un avis (note et commentaire) sur un produit",
+                "description": "Supprime un produit (admin uniquement)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1410,35 +1700,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Products"
                 ],
-                "summary": "Créer un avis pour un produit",
+                "summary": "Supprimer un produit",
                 "parameters": [
                     {
-                        "description": "Données de l'avis",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateReviewRequest"
-                        }
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ReviewResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1456,16 +1741,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/{productID}/reviews/me": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère l'avis de l'utilisateur connecté pour un produit spécifique",
+                "description": "Met à jour uniquement les champs fournis d'un produit (admin uniquement). Exemple: changer uniquement l'image sans modifier les autres champs.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1473,23 +1756,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Products"
                 ],
-                "summary": "Avis de l'utilisateur pour un produit",
+                "summary": "Mettre à jour partiellement un produit",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "ID du produit",
-                        "name": "productID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Champs à mettre à jour (tous optionnels)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PatchProductRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ReviewResponse"
+                            "$ref": "#/definitions/dtos.ProductResponse"
                         }
                     },
                     "400": {
@@ -1504,8 +1796,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Aucun avis trouvé"
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1516,51 +1823,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/reviews/{reviewID}": {
-            "put": {
+        "/admin/products/{id}/images": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permet à un utilisateur de modifier son propre avis",
+                "description": "Upload une image (JPEG ou PNG, 5 Mo et 40 mégapixels maximum) via un formulaire multipart avec un champ \"file\" (admin uniquement).\nUne miniature (200px) et une version moyenne (800px) sont générées automatiquement. L'image est ajoutée en dernière position.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Products"
                 ],
-                "summary": "Mettre à jour un avis",
+                "summary": "Ajouter une image à un produit",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de l'avis",
-                        "name": "reviewID",
+                        "description": "ID du produit",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Données de mise à jour",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.UpdateReviewRequest"
-                        }
+                        "type": "file",
+                        "description": "Image à uploader (JPEG ou PNG)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ReviewResponse"
+                            "$ref": "#/definitions/dtos.ProductImageResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Fichier manquant ou image invalide",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1572,7 +1877,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1583,6 +1888,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Image trop volumineuse (poids ou dimensions)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Type d'image non supporté",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1590,14 +1907,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/products/{id}/images/order": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permet à un utilisateur de supprimer son propre avis",
+                "description": "Définit l'ordre d'affichage des images (admin uniquement). La liste doit contenir tous les IDs d'images du produit ; la première devient l'image principale.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1605,23 +1924,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Products"
                 ],
-                "summary": "Supprimer un avis",
+                "summary": "Réordonner les images d'un produit",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de l'avis",
-                        "name": "reviewID",
+                        "description": "ID du produit",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Nouvel ordre des images",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReorderProductImagesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/docs.SuccessMessage"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProductImageResponse"
+                            }
                         }
                     },
                     "400": {
@@ -1637,7 +1968,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1657,39 +1988,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}": {
-            "get": {
+        "/admin/products/{id}/images/{imageID}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Récupère les informations d'un utilisateur par son ID. L'utilisateur peut voir son propre profil, l'admin peut voir n'importe quel profil.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Supprime une image et ses miniatures (admin uniquement). Les images suivantes remontent d'une position.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Récupérer un utilisateur",
+                "summary": "Supprimer une image d'un produit",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de l'utilisateur",
+                        "description": "ID du produit",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de l'image",
+                        "name": "imageID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.UserResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -1698,7 +2030,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Accès refusé - Admin requis",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1716,14 +2048,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/products/{id}/variants": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Met à jour les informations d'un utilisateur. L'utilisateur peut modifier son propre profil, l'admin peut modifier n'importe quel profil.",
+                "description": "Ajoute un format (ex: 30ml, 50ml) avec son propre prix, stock, SKU et code-barres (admin uniquement). Avec isDefault=true, la variante remplace la variante par défaut actuelle.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1731,32 +2065,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Mettre à jour un utilisateur",
+                "summary": "Ajouter une variante à un produit",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID de l'utilisateur",
+                        "description": "ID du produit",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Nouvelles informations",
+                        "description": "Informations de la variante",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserRequest"
+                            "$ref": "#/definitions/dtos.ProductVariantRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserResponse"
+                            "$ref": "#/definitions/dtos.ProductVariantResponse"
                         }
                     },
                     "400": {
@@ -1772,7 +2106,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "SKU ou code-barres déjà utilisé",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1786,9 +2132,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "post": {
-                "description": "Crée un nouvel utilisateur (sans authentification requise)",
+        "/admin/products/{id}/variants/{variantID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Met à jour une variante d'un produit (admin uniquement). Un SKU ou code-barres vide est supprimé. Pour changer la variante par défaut, passez isDefault=true sur la nouvelle.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1796,25 +2147,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Créer un utilisateur",
+                "summary": "Mettre à jour une variante",
                 "parameters": [
                     {
-                        "description": "Informations utilisateur",
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de la variante",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouvelles informations de la variante",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserRequest"
+                            "$ref": "#/definitions/dtos.ProductVariantRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserResponse"
+                            "$ref": "#/definitions/dtos.ProductVariantResponse"
                         }
                     },
                     "400": {
@@ -1823,8 +2188,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "409": {
-                        "description": "Email déjà utilisé",
+                        "description": "SKU ou code-barres déjà utilisé",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -1836,241 +2219,7299 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime une variante d'un produit (admin uniquement). Le produit doit conserver au moins une variante ; si la variante par défaut est supprimée, la plus ancienne restante devient la variante par défaut.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Supprimer une variante",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de la variante",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Dernière variante du produit",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/questions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste paginée de toutes les questions avec toutes leurs réponses, des plus récentes aux plus anciennes, filtrable par statut et par produit (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Liste des questions à modérer",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PENDING",
+                            "APPROVED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "Statut",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "productID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Numéro de page (défaut: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (défaut: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedQuestionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Statut invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/questions/{questionID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime définitivement une question et ses réponses. Le motif, obligatoire, est enregistré avec l'administrateur et le texte supprimé dans le journal de modération (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Supprimer une question (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motif de la suppression",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ModerateQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Motif manquant",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/questions/{questionID}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publie une question en attente ou précédemment refusée : elle devient visible sur la fiche produit (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Approuver une question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuestionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/questions/{questionID}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire une question de la fiche produit ; son auteur la voit avec le motif du refus (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Refuser une question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motif du refus",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ModerateQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuestionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Motif manquant",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/most-wished": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Classe les produits selon le nombre d'utilisateurs qui les ont ajoutés à leurs favoris (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorites"
+                ],
+                "summary": "Produits les plus souhaités",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre de produits (défaut: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.MostWishedProductResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste toutes les demandes de retour, des plus récentes aux plus anciennes, filtrables par statut (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Liste des retours",
+                "parameters": [
+                    {
+                        "enum": [
+                            "REQUESTED",
+                            "APPROVED",
+                            "REJECTED",
+                            "RECEIVED"
+                        ],
+                        "type": "string",
+                        "description": "Statut",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ReturnResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Statut invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère une demande de retour avec ses articles et le montant remboursable (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Détails d'un retour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du retour",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Retour non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accepte une demande de retour en attente ; le client peut renvoyer les articles (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Approuver un retour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du retour",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note pour le client",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Retour non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Retour déjà traité",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enregistre la réception des articles d'un retour approuvé : remise en stock des variantes et remboursement (total par défaut, ou partiel via refundAmount) sur le paiement de la commande.\nLe remboursement est reporté dans le total remboursé de la commande (refundedAmount) et déduit du chiffre d'affaires des statistiques (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Réceptionner un retour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du retour",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Montant remboursé",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReceiveReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Montant invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Retour non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Retour non approuvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Erreur du prestataire de paiement",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/returns/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refuse une demande de retour en attente ; les articles redeviennent retournables dans le délai de retour (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Refuser un retour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du retour",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motif du refus",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Retour non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Retour déjà traité",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste paginée de tous les avis, des plus récents aux plus anciens, filtrable par statut et par produit (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Liste des avis à modérer",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PENDING",
+                            "APPROVED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "Statut",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "productID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Numéro de page (défaut: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (défaut: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Statut invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime définitivement un avis. Le motif, obligatoire, est enregistré avec l'administrateur et le commentaire supprimé dans le journal de modération ; pour masquer un avis en conservant une trace visible par son auteur, préférez le refus (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Supprimer un avis (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motif de la suppression",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ModerateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Motif manquant",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewID}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publie un avis en attente ou précédemment refusé : il devient visible sur la fiche produit (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Approuver un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewID}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire un avis de la fiche produit ; son auteur le voit toujours avec le motif du refus, et l'avis repasse en modération s'il le modifie (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Refuser un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motif du refus",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ModerateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Motif manquant",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{reviewID}/reply": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remplace le texte de la réponse publiée sous un avis ; l'auteur de l'avis n'est pas prévenu à nouveau (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Modifier la réponse à un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Texte de la réponse",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewReplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Réponse vide ou trop longue",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis ou réponse non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publie la réponse du service client sous un avis publié (une seule réponse par avis). L'auteur de l'avis est prévenu par e-mail (événement \"review.replied\") (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Répondre à un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Texte de la réponse",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewReplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Réponse vide ou trop longue",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Une réponse existe déjà, ou avis non publié",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire la réponse publiée sous un avis (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Supprimer la réponse à un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Réponse non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipping-methods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste tous les modes de livraison, y compris les inactifs (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Liste tous les modes de livraison",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ShippingMethodResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crée un mode de livraison : forfait (FLAT) ou prix de base + prix par kg entamé (WEIGHT), avec un seuil de gratuité optionnel (admin uniquement)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Créer un mode de livraison",
+                "parameters": [
+                    {
+                        "description": "Mode de livraison",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingMethodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nom déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/shipping-methods/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remplace les paramètres d'un mode de livraison (admin uniquement). Les commandes passées conservent leurs frais de port.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Mettre à jour un mode de livraison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du mode de livraison",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouveaux paramètres",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShippingMethodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nom déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime un mode de livraison (admin uniquement). Les commandes conservent son nom et leurs frais de port ; pour le retirer temporairement, préférez active=false.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Supprimer un mode de livraison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du mode de livraison",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/user/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime un utilisateur par son ID. Réservé aux administrateurs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Supprimer un utilisateur (Admin uniquement)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère la liste de tous les utilisateurs. Réservé aux administrateurs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Liste tous les utilisateurs (Admin uniquement)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.UserResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les destinations de webhooks sortants, actives ou non (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Liste des webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.WebhookEndpointResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enregistre une URL appelée en POST pour chaque événement des types choisis (order.created, order.status_changed, order.refunded, product.stock_changed, review.created, review.replied, user.registered, ou \"*\" pour tous).\nLe corps est {\"id\", \"type\", \"createdAt\", \"data\"} ; il est signé avec la clé retournée (en-tête Webhook-Signature \"t=\u003ctimestamp\u003e,v1=\u003chex HMAC-SHA256(secret, \"\u003ctimestamp\u003e.\u003ccorps\u003e\")\u003e\"). La clé n'est plus affichée ensuite : en cas de perte, générez-en une nouvelle (rotate-secret).\nToute réponse hors 2xx est retentée avec un délai exponentiel (WEBHOOK_MAX_ATTEMPTS tentatives) ; le webhook est désactivé après WEBHOOK_DISABLE_AFTER_FAILURES échecs d'affilée (admin uniquement).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Créer un webhook",
+                "parameters": [
+                    {
+                        "description": "Destination",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookEndpointSecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "URL invalide ou type d'événement inconnu",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retourne une destination de webhooks, sans sa clé de signature (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Détails d'un webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookEndpointResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remplace l'URL, la description et les types d'événements d'un webhook. Réactiver (active=true) un webhook désactivé remet à zéro son compteur d'échecs et reprend les livraisons en attente (admin uniquement).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Mettre à jour un webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Destination",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "URL invalide ou type d'événement inconnu",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime un webhook et son journal de livraisons (admin uniquement). Pour suspendre les envois, préférez active=false.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Supprimer un webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les livraisons d'un webhook, des plus récentes aux plus anciennes, avec le résultat de la dernière tentative (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Journal des livraisons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "SUCCEEDED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Filtrer par statut",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre de livraisons (défaut: 50, max: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Statut invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries/{deliveryID}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Programme un nouvel envoi immédiat d'une livraison, avec le même corps et le même identifiant d'événement (admin uniquement). La livraison d'origine reste dans le journal.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Relivrer un webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID de la livraison",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookDeliveryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Webhook désactivé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/rotate-secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Génère une nouvelle clé de signature et la retourne ; l'ancienne est aussitôt invalide, y compris pour les livraisons en attente (admin uniquement)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Changer la clé d'un webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookEndpointSecretResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès refusé - Admin requis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/answers/{answerID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime une réponse de l'utilisateur connecté",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Supprimer ma réponse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la réponse",
+                        "name": "answerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Réponse d'un autre utilisateur",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Réponse non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/answers/{answerID}/upvote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Indique qu'une réponse publiée a été utile. Un seul vote par utilisateur et par réponse ; impossible de voter pour sa propre réponse",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Voter pour une réponse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la réponse",
+                        "name": "answerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AnswerResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Vote pour sa propre réponse",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Réponse non trouvée ou non publiée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Vote déjà enregistré",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire le vote de l'utilisateur connecté pour une réponse publiée",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Retirer mon vote pour une réponse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la réponse",
+                        "name": "answerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AnswerResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Réponse ou vote non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authentifie un utilisateur et retourne un token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Connexion utilisateur",
+                "parameters": [
+                    {
+                        "description": "Identifiants de connexion",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Email ou mot de passe incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère les informations de l'utilisateur connecté via le token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Informations utilisateur actuel",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Crée un nouveau compte utilisateur et retourne un token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Inscription d'un nouvel utilisateur",
+                "parameters": [
+                    {
+                        "description": "Informations d'inscription",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Retourne les catégories racines et leurs sous-catégories, triées par ordre d'affichage puis par nom.\nproductCount inclut les produits des sous-catégories ; le slug s'utilise comme filtre ?category= de GET /products.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Arborescence des catégories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.CategoryTreeResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/favorites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les produits favoris de l'utilisateur connecté, les plus récemment ajoutés en premier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorites"
+                ],
+                "summary": "Mes favoris",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.FavoriteResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ajoute un produit aux favoris de l'utilisateur connecté. L'opération est idempotente : si le produit est déjà en favori, le favori existant est retourné avec un code 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorites"
+                ],
+                "summary": "Ajouter un favori",
+                "parameters": [
+                    {
+                        "description": "Produit à ajouter",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddFavoriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Déjà en favori",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FavoriteResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FavoriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/favorites/{productID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire un produit des favoris de l'utilisateur connecté",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorites"
+                ],
+                "summary": "Retirer un favori",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "productID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère toutes les commandes de l'utilisateur connecté",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Liste les commandes de l'utilisateur",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.OrderResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crée une nouvelle commande avec les produits sélectionnés. Chaque ligne peut préciser une variante (variantID), sinon la variante par défaut du produit est commandée. Le stock de la variante est automatiquement déduit. L'adresse (addressID, carnet de l'utilisateur) est copiée dans la commande et les frais de port du mode de livraison (shippingMethodID) sont ajoutés au total. Avec un en-tête Idempotency-Key, un renvoi de la même requête retourne la commande d'origine sans en créer une nouvelle. Un code promo (couponCode) peut être appliqué : la remise est détaillée par ligne et sur la commande. Les prix étant TTC, chaque ligne et la commande sont décomposées en HT / TVA / TTC selon le taux de la catégorie du produit (ou VAT_DEFAULT_RATE).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Créer une commande",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clé d'idempotence choisie par le client (ex: UUID)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Langue des e-mails de suivi (fr, en ; défaut: fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Items de la commande",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Stock insuffisant, produit ou variante non trouvé, code promo invalide ou non applicable, adresse ou mode de livraison manquant",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Requête avec la même Idempotency-Key en cours de traitement",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key déjà utilisée pour une requête différente",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Flux Server-Sent Events des changements de statut des commandes de l'utilisateur connecté (événement \"order.status_changed\").\nUn commentaire \": heartbeat\" est envoyé toutes les SSE_HEARTBEAT_SECONDS secondes (15 par défaut). Après une coupure, renvoyer l'id du dernier événement reçu dans l'en-tête Last-Event-ID :\nles événements manqués encore en mémoire sont rejoués, sinon un événement \"resync\" invite à recharger les commandes. Le flux est fermé à l'expiration du token.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Flux temps réel de mes commandes (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identifiant du dernier événement reçu",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Flux d'événements",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderStreamEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère les détails d'une commande. L'utilisateur peut voir ses propres commandes, l'admin peut voir toutes les commandes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Détails d'une commande",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/invoice.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Génère la facture PDF d'une commande payée ou expédiée : mentions du vendeur (INVOICE_SELLER_*), client, lignes, récapitulatif TVA et totaux.\nLe numéro de facture est séquentiel et sans trou, attribué au paiement ou à l'expédition. Seul le propriétaire de la commande ou un admin peut la télécharger.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Facture PDF d'une commande",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Commande ni payée ni expédiée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crée une intention de paiement auprès du prestataire choisi (prestataire par défaut si omis) et retourne le client secret à transmettre au front.\nTant que le paiement est en attente, un nouvel appel retourne la même intention. La commande est marquée payée à réception du webhook de capture.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payer une commande",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clé d'idempotence choisie par le client (ex: UUID)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Prestataire de paiement",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PayOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Prestataire inconnu",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Commande annulée ou déjà payée, ou requête avec la même Idempotency-Key en cours",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key déjà utilisée pour une requête différente",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Erreur du prestataire de paiement",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les tentatives de paiement d'une commande et leur statut (propriétaire de la commande ou admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Paiements d'une commande",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.PaymentResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/returns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les demandes de retour d'une commande et leur statut (propriétaire de la commande ou admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Retours d'une commande",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ReturnResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Demande le retour de tout ou partie des articles d'une commande livrée, avec un motif. La demande doit être faite dans les RETURN_WINDOW_DAYS jours suivant la livraison (14 par défaut) ;\nun article ne peut pas être retourné au-delà de la quantité commandée (les retours refusés ne comptent pas).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Returns"
+                ],
+                "summary": "Demander un retour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Articles à retourner",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Motif manquant, article inconnu ou quantité invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Commande non livrée ou délai de retour dépassé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère la liste de tous les produits disponibles avec leurs catégories (authentification requise). Supporte la pagination via ?page=1\u0026limit=10\nFiltres : ?category=visage (slug, sous-catégories incluses), ?skinType=sensitive, ?concern=acne, ?excludeIngredient=parfum (répétable ou séparé par des virgules) pour écarter les produits contenant un allergène. Les produits sans liste d'ingrédients ne sont pas exclus.\nChaque produit porte sa note moyenne, son nombre d'avis publiés et leur répartition par note ; ?sort=rating trie par note moyenne décroissante.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Liste tous les produits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Numéro de page (défaut: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (défaut: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug de catégorie (produits des sous-catégories inclus)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dry",
+                            "oily",
+                            "combination",
+                            "sensitive"
+                        ],
+                        "type": "string",
+                        "description": "Type de peau adapté",
+                        "name": "skinType",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "acne",
+                            "aging",
+                            "hyperpigmentation",
+                            "dehydration",
+                            "redness",
+                            "pores",
+                            "dullness"
+                        ],
+                        "type": "string",
+                        "description": "Préoccupation ciblée",
+                        "name": "concern",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ingrédient(s) INCI à exclure, séparés par des virgules",
+                        "name": "excludeIngredient",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rating"
+                        ],
+                        "type": "string",
+                        "description": "Tri : rating (meilleures notes d'abord)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Si page et limit ne sont pas fournis",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Filtre invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/recommended": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Classe les produits selon le profil de peau de l'utilisateur connecté, la note moyenne des avis et les achats croisés des autres clients.\nLes produits achetés récemment (RECOMMENDATION_RECENT_DAYS, 90 jours par défaut), en rupture de stock ou contenant un ingrédient à éviter sont exclus.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Produits recommandés",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre de produits (défaut: 10, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.RecommendedProductResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère les détails d'un produit par son ID (authentification requise), avec le nombre de questions ayant une réponse publiée (answeredQuestionCount)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Détails d'un produit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/questions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page des questions publiées d'un produit avec leurs réponses publiées : réponses officielles (administrateur) d'abord, puis les plus votées. upvoted indique les questions et réponses pour lesquelles l'utilisateur connecté a voté.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Questions d'un produit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Uniquement les questions avec (true) ou sans (false) réponse",
+                        "name": "answered",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "recent",
+                            "popular"
+                        ],
+                        "type": "string",
+                        "description": "Tri (défaut: recent)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Numéro de page (défaut: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (défaut: 10, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaginatedQuestionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Tri invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enregistre la question de l'utilisateur connecté. Elle est publiée après validation par un administrateur (statut PENDING), sauf si QUESTION_AUTO_APPROVE=true. Une question contenant un terme de REVIEW_BANNED_WORDS est refusée.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Poser une question sur un produit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuestionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Question vide, trop longue ou termes interdits",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{productID}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère une page des avis publiés (approuvés) d'un produit avec les statistiques du produit (moyenne, total, histogramme). Avec ?verifiedOnly=true, seuls les achats vérifiés (auteur ayant reçu le produit) sont retenus, statistiques comprises.\nLes filtres ?rating=4,5 (notes exactes) et ?minRating=4 réduisent la liste sans changer les statistiques ; total indique le nombre d'avis correspondants. votedHelpful indique les avis pour lesquels l'utilisateur connecté a voté.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Liste des avis d'un produit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "productID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Uniquement les achats vérifiés (défaut: false)",
+                        "name": "verifiedOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Note(s) exacte(s), séparées par des virgules (ex: 4,5)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Note minimale (1 à 5)",
+                        "name": "minRating",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "recent",
+                            "helpful",
+                            "rating_high",
+                            "rating_low"
+                        ],
+                        "type": "string",
+                        "description": "Tri (défaut: recent)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Numéro de page (défaut: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (défaut: 10, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Tri ou filtre de note invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permet à un utilisateur authentifié de laisser un avis (note et commentaire) sur un produit.\nL'avis est publié après validation par un administrateur (statut PENDING), sauf si sa note atteint REVIEW_AUTO_APPROVE_MIN_RATING. Un commentaire contenant un terme de REVIEW_BANNED_WORDS est refusé.\nL'avis est marqué verifiedPurchase si l'utilisateur a une commande livrée contenant le produit ; avec REVIEW_REQUIRE_PURCHASE=true, les autres avis sont refusés (403).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Créer un avis pour un produit",
+                "parameters": [
+                    {
+                        "description": "Données de l'avis",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Note invalide ou termes interdits",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Produit non reçu (REVIEW_REQUIRE_PURCHASE)",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{productID}/reviews/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère l'avis de l'utilisateur connecté pour un produit spécifique, avec son statut de modération et le motif d'un éventuel refus",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Avis de l'utilisateur pour un produit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID du produit",
+                        "name": "productID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Aucun avis trouvé"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime une question de l'utilisateur connecté, avec ses réponses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Supprimer ma question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Question d'un autre utilisateur",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}/answers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Un administrateur publie directement une réponse officielle. Un client doit avoir reçu le produit (commande livrée) et ne peut pas répondre à sa propre question ; sa réponse est publiée après modération, sauf si QUESTION_AUTO_APPROVE=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Répondre à une question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Réponse",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateAnswerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AnswerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Réponse vide, trop longue ou termes interdits",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Produit non reçu ou question de l'utilisateur",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question non trouvée ou non publiée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}/upvote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Indique que l'utilisateur connecté se pose aussi la question. Un seul vote par utilisateur et par question ; impossible de voter pour sa propre question",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Voter pour une question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuestionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Vote pour sa propre question",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question non trouvée ou non publiée",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Vote déjà enregistré",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire le vote de l'utilisateur connecté pour une question publiée",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Retirer mon vote pour une question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de la question",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuestionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question ou vote non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{reviewID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permet à un utilisateur de modifier son propre avis ; l'avis modifié repasse en modération (sauf approbation automatique)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Mettre à jour un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permet à un utilisateur de supprimer son propre avis",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Supprimer un avis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/docs.SuccessMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{reviewID}/helpful": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Indique qu'un avis publié a été utile. Un seul vote par utilisateur et par avis ; impossible de voter pour son propre avis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Voter « avis utile »",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Vote pour son propre avis",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis non trouvé ou non publié",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Vote déjà enregistré",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire le vote « utile » de l'utilisateur connecté sur un avis publié",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Retirer mon vote « avis utile »",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'avis",
+                        "name": "reviewID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Avis ou vote non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping-methods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les modes de livraison actifs, du moins cher au plus cher. Les frais de port sont calculés à la commande selon la règle du mode (forfait ou au poids) et le seuil de gratuité.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Modes de livraison",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ShippingMethodResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère les informations d'un utilisateur par son ID. L'utilisateur peut voir son propre profil, l'admin peut voir n'importe quel profil.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Récupérer un utilisateur",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Met à jour les informations d'un utilisateur. L'utilisateur peut modifier son propre profil, l'admin peut modifier n'importe quel profil.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mettre à jour un utilisateur",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouvelles informations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Crée un nouvel utilisateur (sans authentification requise)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Créer un utilisateur",
+                "parameters": [
+                    {
+                        "description": "Informations utilisateur",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/addresses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liste les adresses de livraison de l'utilisateur connecté, l'adresse par défaut en premier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mes adresses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.AddressResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ajoute une adresse de livraison. La première adresse devient l'adresse par défaut ; avec isDefault=true, elle remplace l'adresse par défaut actuelle.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Ajouter une adresse",
+                "parameters": [
+                    {
+                        "description": "Adresse",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/addresses/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Met à jour une adresse du carnet. Les commandes passées conservent l'adresse d'origine. Pour changer l'adresse par défaut, passez isDefault=true sur la nouvelle.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mettre à jour une adresse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'adresse",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouvelle adresse",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Supprime une adresse du carnet. Si c'était l'adresse par défaut, la plus ancienne des adresses restantes la remplace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Supprimer une adresse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID de l'adresse",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Récupère le profil de peau de l'utilisateur connecté (type de peau, préoccupations, ingrédients à éviter). Retourne un profil vide s'il n'a jamais été renseigné.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mon profil de peau",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SkinProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crée ou remplace le profil de peau de l'utilisateur connecté. Les ingrédients à éviter sont exclus des recommandations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Enregistrer mon profil de peau",
+                "parameters": [
+                    {
+                        "description": "Profil de peau",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SkinProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SkinProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Type de peau ou préoccupation invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payments/{provider}": {
+            "post": {
+                "description": "Point d'entrée des notifications du prestataire (paiement autorisé, capturé, échoué, remboursé). La signature est vérifiée avant tout traitement ;\nun événement déjà reçu est acquitté sans être rejoué. Un paiement capturé pour un montant différent de celui du paiement est refusé.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Webhook de paiement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom du prestataire (ex: fake)",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Webhook invalide ou montant incohérent",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Signature invalide",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestataire ou paiement inconnu",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
         "docs.ErrorResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "error": {
+                    "type": "string",
+                    "example": "Message d'erreur"
+                }
+            }
+        },
+        "docs.SuccessMessage": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Opération réussie"
+                }
+            }
+        },
+        "dtos.AddFavoriteRequest": {
+            "description": "Produit à ajouter aux favoris de l'utilisateur connecté",
+            "type": "object",
+            "required": [
+                "productID"
+            ],
+            "properties": {
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.AddressRequest": {
+            "description": "Adresse de livraison du carnet d'adresses",
+            "type": "object",
+            "required": [
+                "city",
+                "fullName",
+                "line1",
+                "postalCode"
+            ],
+            "properties": {
+                "city": {
+                    "description": "Ville",
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "country": {
+                    "description": "Code pays ISO à 2 lettres (défaut: FR)",
+                    "type": "string",
+                    "example": "FR"
+                },
+                "fullName": {
+                    "description": "Destinataire",
+                    "type": "string",
+                    "example": "Marie Dupont"
+                },
+                "isDefault": {
+                    "description": "Devenir l'adresse par défaut",
+                    "type": "boolean",
+                    "example": false
+                },
+                "label": {
+                    "description": "Nom de l'adresse (optionnel)",
+                    "type": "string",
+                    "example": "Maison"
+                },
+                "line1": {
+                    "description": "Adresse",
+                    "type": "string",
+                    "example": "12 rue des Lilas"
+                },
+                "line2": {
+                    "description": "Complément d'adresse (optionnel)",
+                    "type": "string",
+                    "example": "Bâtiment B"
+                },
+                "phone": {
+                    "description": "Téléphone (optionnel)",
+                    "type": "string",
+                    "example": "+33612345678"
+                },
+                "postalCode": {
+                    "description": "Code postal",
+                    "type": "string",
+                    "example": "75011"
+                }
+            }
+        },
+        "dtos.AddressResponse": {
+            "description": "Adresse du carnet d'adresses de l'utilisateur",
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "Ville",
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "country": {
+                    "description": "Code pays",
+                    "type": "string",
+                    "example": "FR"
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "fullName": {
+                    "description": "Destinataire",
+                    "type": "string",
+                    "example": "Marie Dupont"
+                },
+                "id": {
+                    "description": "UUID de l'adresse",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "isDefault": {
+                    "description": "Adresse par défaut",
+                    "type": "boolean",
+                    "example": true
+                },
+                "label": {
+                    "description": "Nom de l'adresse",
+                    "type": "string",
+                    "example": "Maison"
+                },
+                "line1": {
+                    "description": "Adresse",
+                    "type": "string",
+                    "example": "12 rue des Lilas"
+                },
+                "line2": {
+                    "description": "Complément d'adresse",
+                    "type": "string",
+                    "example": "Bâtiment B"
+                },
+                "phone": {
+                    "description": "Téléphone",
+                    "type": "string",
+                    "example": "+33612345678"
+                },
+                "postalCode": {
+                    "description": "Code postal",
+                    "type": "string",
+                    "example": "75011"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "dtos.AnswerResponse": {
+            "description": "Réponse à une question",
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Texte de la réponse",
+                    "type": "string",
+                    "example": "Oui, la formule ne contient ni rétinol ni huiles essentielles."
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "UUID de la réponse",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "moderatedAt": {
+                    "description": "Date de la dernière décision de modération",
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "moderationReason": {
+                    "description": "Motif du refus (si refusée)",
+                    "type": "string",
+                    "example": "Hors sujet"
+                },
+                "official": {
+                    "description": "Réponse de la marque (administrateur)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "questionID": {
+                    "description": "ID de la question",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "status": {
+                    "description": "Statut de modération",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "APPROVED",
+                        "REJECTED"
+                    ],
+                    "example": "APPROVED"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "upvoteCount": {
+                    "description": "Nombre de votes",
+                    "type": "integer",
+                    "example": 2
+                },
+                "upvoted": {
+                    "description": "L'utilisateur connecté a voté",
+                    "type": "boolean",
+                    "example": false
+                },
+                "userEmail": {
+                    "description": "Email masqué de l'auteur",
+                    "type": "string",
+                    "example": "u***@example.com"
+                },
+                "userID": {
+                    "description": "ID de l'auteur",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "verifiedPurchase": {
+                    "description": "L'auteur a reçu le produit",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dtos.CategoryInUseResponse": {
+            "description": "Erreur retournée lorsque la catégorie à supprimer contient encore des produits",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Message d'erreur",
+                    "type": "string",
+                    "example": "La catégorie contient des produits : précisez reassignTo ou detach=true"
+                },
+                "productCount": {
+                    "description": "Nombre de produits de la catégorie",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dtos.CategoryRequest": {
+            "description": "Informations catégorie pour création/modification",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description affichée aux clients",
+                    "type": "string",
+                    "example": "Soins pour le visage"
+                },
+                "name": {
+                    "description": "Nom de la catégorie",
+                    "type": "string",
+                    "example": "Visage"
+                },
+                "parentID": {
+                    "description": "Catégorie parente (absent = catégorie racine)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "slug": {
+                    "description": "Identifiant d'URL (absent = dérivé du nom à la création, inchangé à la mise à jour)",
+                    "type": "string",
+                    "example": "visage"
+                },
+                "sortOrder": {
+                    "description": "Ordre d'affichage parmi les catégories de même parent",
+                    "type": "integer",
+                    "example": 0
+                },
+                "taxRate": {
+                    "description": "Taux de TVA en % (absent = taux par défaut)",
+                    "type": "number",
+                    "example": 5.5
+                }
+            }
+        },
+        "dtos.CategoryResponse": {
+            "description": "Informations catégorie",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "description": {
+                    "description": "Description",
+                    "type": "string",
+                    "example": "Soins pour le visage"
+                },
+                "id": {
+                    "description": "UUID de la catégorie",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "description": "Nom de la catégorie",
+                    "type": "string",
+                    "example": "Visage"
+                },
+                "parentID": {
+                    "description": "Catégorie parente (absent = catégorie racine)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "slug": {
+                    "description": "Identifiant d'URL",
+                    "type": "string",
+                    "example": "visage"
+                },
+                "sortOrder": {
+                    "description": "Ordre d'affichage parmi les catégories de même parent",
+                    "type": "integer",
+                    "example": 0
+                },
+                "taxRate": {
+                    "description": "Taux de TVA propre à la catégorie (absent = taux par défaut)",
+                    "type": "number",
+                    "example": 5.5
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "dtos.CategoryTreeResponse": {
+            "description": "Catégorie avec ses sous-catégories et son nombre de produits",
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "Sous-catégories, triées par ordre d'affichage puis par nom",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryTreeResponse"
+                    }
+                },
+                "description": {
+                    "description": "Description",
+                    "type": "string",
+                    "example": "Soins pour le visage"
+                },
+                "id": {
+                    "description": "UUID de la catégorie",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "description": "Nom de la catégorie",
+                    "type": "string",
+                    "example": "Visage"
+                },
+                "productCount": {
+                    "description": "Nombre de produits de la catégorie et de ses sous-catégories",
+                    "type": "integer",
+                    "example": 12
+                },
+                "slug": {
+                    "description": "Identifiant d'URL (filtre ?category= de GET /products)",
+                    "type": "string",
+                    "example": "visage"
+                },
+                "sortOrder": {
+                    "description": "Ordre d'affichage",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dtos.CouponRequest": {
+            "description": "Paramètres d'un code promo (remise en pourcentage, montant fixe ou livraison offerte)",
+            "type": "object",
+            "required": [
+                "code",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Code actif (défaut: true)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "categoryIDs": {
+                    "description": "Catégories éligibles (vide = toutes)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "description": "Code saisi par le client (insensible à la casse)",
+                    "type": "string",
+                    "example": "BIENVENUE10"
+                },
+                "description": {
+                    "description": "Description interne",
+                    "type": "string",
+                    "example": "10% sur la première commande"
+                },
+                "endsAt": {
+                    "description": "Fin de validité (optionnel)",
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "maxUses": {
+                    "description": "Nombre maximal d'utilisations (0 = illimité)",
+                    "type": "integer",
+                    "example": 100
+                },
+                "maxUsesPerUser": {
+                    "description": "Nombre maximal d'utilisations par client (0 = illimité)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "minOrderAmount": {
+                    "description": "Montant minimum de commande avant remise (0 = aucun)",
+                    "type": "number",
+                    "example": 50
+                },
+                "productIDs": {
+                    "description": "Produits éligibles (vide = tous)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startsAt": {
+                    "description": "Début de validité (optionnel)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "type": {
+                    "description": "Type de remise",
+                    "type": "string",
+                    "enum": [
+                        "PERCENTAGE",
+                        "FIXED",
+                        "FREE_SHIPPING"
+                    ],
+                    "example": "PERCENTAGE"
+                },
+                "value": {
+                    "description": "Pourcentage (0-100] ou montant fixe ; ignoré pour FREE_SHIPPING",
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "dtos.CouponResponse": {
+            "description": "Informations d'un code promo",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Code actif",
+                    "type": "boolean",
+                    "example": true
+                },
+                "categoryIDs": {
+                    "description": "Catégories éligibles (vide = toutes)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "description": "Code promo",
+                    "type": "string",
+                    "example": "BIENVENUE10"
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "description": {
+                    "description": "Description interne",
+                    "type": "string",
+                    "example": "10% sur la première commande"
+                },
+                "endsAt": {
+                    "description": "Fin de validité",
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "id": {
+                    "description": "UUID du coupon",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "maxUses": {
+                    "description": "Nombre maximal d'utilisations (0 = illimité)",
+                    "type": "integer",
+                    "example": 100
+                },
+                "maxUsesPerUser": {
+                    "description": "Nombre maximal d'utilisations par client (0 = illimité)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "minOrderAmount": {
+                    "description": "Montant minimum de commande (0 = aucun)",
+                    "type": "number",
+                    "example": 50
+                },
+                "productIDs": {
+                    "description": "Produits éligibles (vide = tous)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startsAt": {
+                    "description": "Début de validité",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "type": {
+                    "description": "Type de remise",
+                    "type": "string",
+                    "enum": [
+                        "PERCENTAGE",
+                        "FIXED",
+                        "FREE_SHIPPING"
+                    ],
+                    "example": "PERCENTAGE"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "value": {
+                    "description": "Pourcentage ou montant fixe",
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "dtos.CouponStatsResponse": {
+            "description": "Utilisation d'un code promo (les commandes annulées ne consomment pas d'utilisation)",
+            "type": "object",
+            "properties": {
+                "averageDiscount": {
+                    "description": "Remise moyenne par commande",
+                    "type": "number",
+                    "example": 7.44
+                },
+                "cancelledCount": {
+                    "description": "Commandes annulées ayant utilisé le code",
+                    "type": "integer",
+                    "example": 3
+                },
+                "code": {
+                    "description": "Code promo",
+                    "type": "string",
+                    "example": "BIENVENUE10"
+                },
+                "couponID": {
+                    "description": "UUID du coupon",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "remainingUses": {
+                    "description": "Utilisations restantes (absent si illimité)",
+                    "type": "integer",
+                    "example": 58
+                },
+                "totalDiscount": {
+                    "description": "Remises accordées (commandes non annulées)",
+                    "type": "number",
+                    "example": 312.5
+                },
+                "totalRevenue": {
+                    "description": "Chiffre d'affaires des commandes (après remise, remboursements déduits)",
+                    "type": "number",
+                    "example": 2840.9
+                },
+                "uniqueUsers": {
+                    "description": "Nombre de clients distincts",
+                    "type": "integer",
+                    "example": 40
+                },
+                "usageCount": {
+                    "description": "Commandes non annulées ayant utilisé le code",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dtos.CreateAnswerRequest": {
+            "description": "Réponse à une question, par un administrateur ou un client ayant reçu le produit",
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "description": "Texte de la réponse (2000 caractères max)",
+                    "type": "string",
+                    "example": "Oui, la formule ne contient ni rétinol ni huiles essentielles."
+                }
+            }
+        },
+        "dtos.CreateOrderRequest": {
+            "description": "Requête de création de commande",
+            "type": "object",
+            "required": [
+                "addressID",
+                "items",
+                "shippingMethodID"
+            ],
+            "properties": {
+                "addressID": {
+                    "description": "Adresse de livraison (carnet d'adresses de l'utilisateur)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "couponCode": {
+                    "description": "Code promo (optionnel)",
+                    "type": "string",
+                    "example": "BIENVENUE10"
+                },
+                "items": {
+                    "description": "Liste des items de la commande (minimum 1)",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderItemRequest"
+                    }
+                },
+                "shippingMethodID": {
+                    "description": "Mode de livraison",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.CreateQuestionRequest": {
+            "description": "Question d'un client sur un produit",
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "description": "Texte de la question (1000 caractères max)",
+                    "type": "string",
+                    "example": "Ce produit convient-il pendant la grossesse ?"
+                }
+            }
+        },
+        "dtos.CreateReturnRequest": {
+            "description": "Articles à retourner et motif du retour",
+            "type": "object",
+            "required": [
+                "items",
+                "reason"
+            ],
+            "properties": {
+                "items": {
+                    "description": "Articles retournés (minimum 1)",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ReturnItemRequest"
+                    }
+                },
+                "reason": {
+                    "description": "Motif du retour",
+                    "type": "string",
+                    "example": "Produit ne convenant pas à ma peau"
+                }
+            }
+        },
+        "dtos.CreateReviewRequest": {
+            "description": "Avis utilisateur sur un produit",
+            "type": "object",
+            "required": [
+                "productID",
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "Commentaire (optionnel)",
+                    "type": "string",
+                    "example": "Excellent produit, très satisfait !"
+                },
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "rating": {
+                    "description": "Note de 1 à 5 (obligatoire)",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 5
+                }
+            }
+        },
+        "dtos.FavoriteResponse": {
+            "description": "Produit favori de l'utilisateur avec la date d'ajout",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Date d'ajout aux favoris",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "product": {
+                    "description": "Détails du produit",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ProductResponse"
+                        }
+                    ]
+                },
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.ImportProductRow": {
+            "description": "Produit à créer ou mettre à jour lors d'un import en masse",
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Nom de la catégorie (créée si elle n'existe pas)",
+                    "type": "string",
+                    "example": "Visage"
+                },
+                "concerns": {
+                    "description": "Préoccupations ciblées (CSV : séparées par |)",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "acne",
+                            "aging",
+                            "hyperpigmentation",
+                            "dehydration",
+                            "redness",
+                            "pores",
+                            "dullness"
+                        ]
+                    },
+                    "example": [
+                        "aging"
+                    ]
+                },
+                "description": {
+                    "description": "Description du produit",
+                    "type": "string",
+                    "example": "Crème hydratante pour peau sensible"
+                },
+                "imageURL": {
+                    "description": "URL de l'image du produit",
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "ingredients": {
+                    "description": "Liste INCI des ingrédients (CSV : séparés par |)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "AQUA",
+                        "GLYCERIN",
+                        "PARFUM"
+                    ]
+                },
+                "name": {
+                    "description": "Nom du produit (obligatoire)",
+                    "type": "string",
+                    "example": "Crème hydratante"
+                },
+                "paoMonths": {
+                    "description": "Période après ouverture en mois (0 = non renseignée)",
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "description": "Prix en euros (doit être \u003e 0)",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "skinTypes": {
+                    "description": "Types de peau adaptés (CSV : séparés par |)",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "dry",
+                            "oily",
+                            "combination",
+                            "sensitive"
+                        ]
+                    },
+                    "example": [
+                        "dry",
+                        "sensitive"
+                    ]
+                },
+                "sku": {
+                    "description": "Référence interne (optionnelle)",
+                    "type": "string",
+                    "example": "CREME-HYD-50"
+                },
+                "stock": {
+                    "description": "Quantité en stock (doit être \u003e= 0)",
+                    "type": "integer",
+                    "example": 50
+                },
+                "volumeML": {
+                    "description": "Contenance en ml (0 = non renseignée)",
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "dtos.ImportProductsResponse": {
+            "description": "Rapport détaillé d'un import de produits",
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Les modifications ont-elles été enregistrées ?",
+                    "type": "boolean",
+                    "example": true
+                },
+                "categoriesCreated": {
+                    "description": "Catégories créées pendant l'import",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created": {
+                    "description": "Nombre de produits créés",
+                    "type": "integer",
+                    "example": 3
+                },
+                "dryRun": {
+                    "description": "Simulation uniquement (aucune écriture)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "description": "Nombre de lignes en erreur",
+                    "type": "integer",
+                    "example": 0
+                },
+                "rows": {
+                    "description": "Résultat ligne par ligne",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ImportRowResult"
+                    }
+                },
+                "skipped": {
+                    "description": "Nombre de lignes ignorées",
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated": {
+                    "description": "Nombre de produits mis à jour",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dtos.ImportRowResult": {
+            "description": "Résultat du traitement d'une ligne d'import",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Nom du produit",
+                    "type": "string",
+                    "example": "Crème hydratante"
+                },
+                "productID": {
+                    "description": "ID du produit mis à jour ou créé",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "reason": {
+                    "description": "Raison (pour skipped et error)",
+                    "type": "string",
+                    "example": "le prix doit être supérieur à 0"
+                },
+                "row": {
+                    "description": "Numéro de ligne (CSV) ou position (JSON, commence à 1)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "description": "Référence interne",
+                    "type": "string",
+                    "example": "CREME-HYD-50"
+                },
+                "status": {
+                    "description": "Résultat de la ligne",
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "skipped",
+                        "error"
+                    ],
+                    "example": "created"
+                }
+            }
+        },
+        "dtos.LoginRequest": {
+            "description": "Identifiants de connexion",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email de l'utilisateur",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "description": "Mot de passe",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "dtos.LoginResponse": {
+            "description": "Réponse de connexion avec token JWT",
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "Token JWT",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "description": "Informations de l'utilisateur",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.UserResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "dtos.ModerateQuestionRequest": {
+            "description": "Motif de la décision de modération",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Motif (obligatoire pour un refus ou une suppression)",
+                    "type": "string",
+                    "example": "Hors sujet"
+                }
+            }
+        },
+        "dtos.ModerateReviewRequest": {
+            "description": "Motif de la décision de modération",
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Motif (obligatoire pour un refus ou une suppression)",
+                    "type": "string",
+                    "example": "Propos injurieux"
+                }
+            }
+        },
+        "dtos.MostWishedProductResponse": {
+            "description": "Produit et nombre d'utilisateurs l'ayant ajouté à leurs favoris",
+            "type": "object",
+            "properties": {
+                "favoriteCount": {
+                    "description": "Nombre d'utilisateurs l'ayant en favori",
+                    "type": "integer",
+                    "example": 42
+                },
+                "name": {
+                    "description": "Nom du produit",
+                    "type": "string",
+                    "example": "Crème hydratante"
+                },
+                "price": {
+                    "description": "Prix actuel",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "stock": {
+                    "description": "Stock actuel (toutes variantes)",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dtos.OrderItemRequest": {
+            "description": "Item de commande avec produit et quantité",
+            "type": "object",
+            "required": [
+                "productID",
+                "quantity"
+            ],
+            "properties": {
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "quantity": {
+                    "description": "Quantité commandée (doit être \u003e 0)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "variantID": {
+                    "description": "ID de la variante (optionnel, variante par défaut sinon)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.OrderItemResponse": {
+            "description": "Item de commande avec détails du produit",
+            "type": "object",
+            "properties": {
+                "discountAmount": {
+                    "description": "Part de la remise imputée à cette ligne",
+                    "type": "number",
+                    "example": 2.99
+                },
+                "grossAmount": {
+                    "description": "Montant TTC de la ligne (remise déduite)",
+                    "type": "number",
+                    "example": 56.99
+                },
+                "id": {
+                    "description": "UUID de l'item",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "netAmount": {
+                    "description": "Montant HT de la ligne (remise déduite)",
+                    "type": "number",
+                    "example": 47.49
+                },
+                "price": {
+                    "description": "Prix unitaire au moment de la commande",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "product": {
+                    "description": "Détails du produit",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ProductResponse"
+                        }
+                    ]
+                },
+                "quantity": {
+                    "description": "Quantité commandée",
+                    "type": "integer",
+                    "example": 2
+                },
+                "taxAmount": {
+                    "description": "Montant de TVA de la ligne",
+                    "type": "number",
+                    "example": 9.5
+                },
+                "taxRate": {
+                    "description": "Taux de TVA appliqué (%)",
+                    "type": "number",
+                    "example": 20
+                },
+                "variantID": {
+                    "description": "ID de la variante commandée",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "variantLabel": {
+                    "description": "Libellé de la variante au moment de la commande",
+                    "type": "string",
+                    "example": "50ml"
+                }
+            }
+        },
+        "dtos.OrderResponse": {
+            "description": "Informations complètes d'une commande",
+            "type": "object",
+            "properties": {
+                "couponCode": {
+                    "description": "Code promo appliqué",
+                    "type": "string",
+                    "example": "BIENVENUE10"
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deliveredAt": {
+                    "description": "Date de livraison (point de départ du délai de retour)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "discountAmount": {
+                    "description": "Remise accordée par le code promo",
+                    "type": "number",
+                    "example": 5.99
+                },
+                "freeShipping": {
+                    "description": "Livraison offerte par le code promo",
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "description": "UUID de la commande",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "invoiceNumber": {
+                    "description": "Numéro de facture (attribué au paiement ou à l'expédition)",
+                    "type": "string",
+                    "example": "FA-000042"
+                },
+                "netAmount": {
+                    "description": "Total HT",
+                    "type": "number",
+                    "example": 44.99
+                },
+                "orderDate": {
+                    "description": "Date de la commande",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "orderItems": {
+                    "description": "Liste des items de la commande",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderItemResponse"
+                    }
+                },
+                "paidAt": {
+                    "description": "Date du paiement (absent si non payée)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "refundedAmount": {
+                    "description": "Total remboursé (retours et remboursements)",
+                    "type": "number",
+                    "example": 0
+                },
+                "shippingAddress": {
+                    "description": "Adresse de livraison copiée à la commande",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ShippingAddressResponse"
+                        }
+                    ]
+                },
+                "shippingAmount": {
+                    "description": "Frais de port TTC (inclus dans totalAmount)",
+                    "type": "number",
+                    "example": 4.9
+                },
+                "shippingMethod": {
+                    "description": "Mode de livraison au moment de la commande",
+                    "type": "string",
+                    "example": "Colissimo"
+                },
+                "status": {
+                    "description": "Statut de la commande",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SHIPPED",
+                        "DELIVERED",
+                        "CANCELLED"
+                    ],
+                    "example": "PENDING"
+                },
+                "subtotalAmount": {
+                    "description": "Montant des articles avant remise",
+                    "type": "number",
+                    "example": 59.98
+                },
+                "taxAmount": {
+                    "description": "Total TVA",
+                    "type": "number",
+                    "example": 9
+                },
+                "taxBreakdown": {
+                    "description": "Détail HT / TVA / TTC par taux",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TaxLineResponse"
+                    }
+                },
+                "totalAmount": {
+                    "description": "Montant total de la commande (après remise)",
+                    "type": "number",
+                    "example": 53.99
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "userID": {
+                    "description": "ID de l'utilisateur",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.OrderStreamEvent": {
+            "description": "Événement SSE \"order.created\" ou \"order.status_changed\" ; l'id SSE est à renvoyer dans Last-Event-ID pour reprendre le flux",
+            "type": "object",
+            "properties": {
+                "occurredAt": {
+                    "description": "Date du changement",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "orderID": {
+                    "description": "UUID de la commande",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "previousStatus": {
+                    "description": "Statut précédent (order.status_changed)",
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "status": {
+                    "description": "Statut courant",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SHIPPED",
+                        "DELIVERED",
+                        "CANCELLED"
+                    ],
+                    "example": "SHIPPED"
+                },
+                "totalAmount": {
+                    "description": "Montant total TTC (order.created)",
+                    "type": "number",
+                    "example": 59.98
+                },
+                "userID": {
+                    "description": "UUID du client",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.PaginatedAnswersResponse": {
+            "description": "Liste paginée de réponses (file de modération)",
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "description": "Liste des réponses",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AnswerResponse"
+                    }
+                },
+                "hasNext": {
+                    "description": "Y a-t-il une page suivante ?",
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "description": "Y a-t-il une page précédente ?",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Nombre d'éléments par page",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Page actuelle",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Nombre total de réponses",
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "description": "Nombre total de pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PaginatedProductsResponse": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "description": "Y a-t-il une page suivante ?",
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "description": "Y a-t-il une page précédente ?",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Nombre d'éléments par page",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Page actuelle",
+                    "type": "integer"
+                },
+                "products": {
+                    "description": "Liste des produits",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductResponse"
+                    }
+                },
+                "total": {
+                    "description": "Nombre total de produits",
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "description": "Nombre total de pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PaginatedQuestionsResponse": {
+            "description": "Liste paginée de questions avec leurs réponses",
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "description": "Y a-t-il une page suivante ?",
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "description": "Y a-t-il une page précédente ?",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Nombre d'éléments par page",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Page actuelle",
+                    "type": "integer"
+                },
+                "questions": {
+                    "description": "Liste des questions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.QuestionResponse"
+                    }
+                },
+                "total": {
+                    "description": "Nombre total de questions",
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "description": "Nombre total de pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PaginatedReviewsResponse": {
+            "description": "Liste paginée d'avis (file de modération)",
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "description": "Y a-t-il une page suivante ?",
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "description": "Y a-t-il une page précédente ?",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Nombre d'éléments par page",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Page actuelle",
+                    "type": "integer"
+                },
+                "reviews": {
+                    "description": "Liste des avis",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ReviewResponse"
+                    }
+                },
+                "total": {
+                    "description": "Nombre total d'avis",
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "description": "Nombre total de pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PatchCategoryRequest": {
+            "description": "Permet de mettre à jour un ou plusieurs champs d'une catégorie",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description (optionnel, \"\" pour l'effacer)",
+                    "type": "string",
+                    "example": "Soins pour le visage"
+                },
+                "name": {
+                    "description": "Nom de la catégorie (optionnel)",
+                    "type": "string",
+                    "example": "Visage"
+                },
+                "parentID": {
+                    "description": "Catégorie parente (optionnel, \"\" pour remonter à la racine)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "slug": {
+                    "description": "Identifiant d'URL (optionnel)",
+                    "type": "string",
+                    "example": "visage"
+                },
+                "sortOrder": {
+                    "description": "Ordre d'affichage (optionnel)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "taxRate": {
+                    "description": "Taux de TVA en % (optionnel)",
+                    "type": "number",
+                    "example": 5.5
+                }
+            }
+        },
+        "dtos.PatchProductRequest": {
+            "description": "Permet de mettre à jour uniquement certains champs d'un produit (tous les champs sont optionnels)",
+            "type": "object",
+            "properties": {
+                "categoryID": {
+                    "description": "ID de la catégorie (optionnel, peut être null pour supprimer la catégorie)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "concerns": {
+                    "description": "Préoccupations (optionnel, remplace la liste existante)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "acne"
+                    ]
+                },
+                "description": {
+                    "description": "Description du produit (optionnel)",
+                    "type": "string",
+                    "example": "Crème hydratante pour peau sensible"
+                },
+                "imageURL": {
+                    "description": "URL de l'image du produit (optionnel)",
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "ingredients": {
+                    "description": "Liste INCI (optionnel, remplace la liste existante)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "AQUA",
+                        "GLYCERIN"
+                    ]
+                },
+                "name": {
+                    "description": "Nom du produit (optionnel)",
+                    "type": "string",
+                    "example": "Crème hydratante"
+                },
+                "paoMonths": {
+                    "description": "PAO en mois (optionnel, 0 pour effacer)",
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "description": "Prix de la variante par défaut (optionnel, doit être \u003e 0 si fourni)",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "skinTypes": {
+                    "description": "Types de peau (optionnel, remplace la liste existante)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "sensitive"
+                    ]
+                },
+                "sku": {
+                    "description": "Référence interne (optionnel)",
+                    "type": "string",
+                    "example": "CREME-HYD-50"
+                },
+                "stock": {
+                    "description": "Stock de la variante par défaut (optionnel, doit être \u003e= 0 si fourni)",
+                    "type": "integer",
+                    "example": 50
+                },
+                "volumeML": {
+                    "description": "Contenance en ml (optionnel, 0 pour effacer)",
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "dtos.PayOrderRequest": {
+            "description": "Choix du prestataire de paiement",
+            "type": "object",
+            "properties": {
+                "provider": {
+                    "description": "Prestataire (défaut: PAYMENT_DEFAULT_PROVIDER)",
+                    "type": "string",
+                    "example": "fake"
+                }
+            }
+        },
+        "dtos.PaymentResponse": {
+            "description": "Paiement d'une commande et son statut",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Montant TTC",
+                    "type": "number",
+                    "example": 53.99
+                },
+                "amountRefunded": {
+                    "description": "Montant remboursé",
+                    "type": "number",
+                    "example": 0
+                },
+                "clientSecret": {
+                    "description": "Secret pour finaliser le paiement côté client (à l'initiation uniquement)",
+                    "type": "string",
+                    "example": "fake_pi_3f2a9c1d7e6b5a4f3e2d1c0b_secret_9a8b7c6d5e4f3a2b"
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "currency": {
+                    "description": "Devise",
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "failureReason": {
+                    "description": "Motif d'échec",
+                    "type": "string",
+                    "example": "carte refusée"
+                },
+                "id": {
+                    "description": "UUID du paiement",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "orderID": {
+                    "description": "ID de la commande",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "provider": {
+                    "description": "Prestataire",
+                    "type": "string",
+                    "example": "fake"
+                },
+                "providerPaymentID": {
+                    "description": "Identifiant chez le prestataire",
+                    "type": "string",
+                    "example": "fake_pi_3f2a9c1d7e6b5a4f3e2d1c0b"
+                },
+                "status": {
+                    "description": "Statut du paiement",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "AUTHORIZED",
+                        "SUCCEEDED",
+                        "FAILED",
+                        "PARTIALLY_REFUNDED",
+                        "REFUNDED"
+                    ],
+                    "example": "PENDING"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "dtos.ProductImageResponse": {
+            "description": "Image uploadée d'un produit avec ses miniatures",
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "description": "Type MIME",
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "createdAt": {
+                    "description": "Date d'upload",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "height": {
+                    "description": "Hauteur de l'original (px)",
+                    "type": "integer",
+                    "example": 1200
+                },
+                "id": {
+                    "description": "UUID de l'image",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "mediumURL": {
+                    "description": "URL de la version moyenne (800px)",
+                    "type": "string",
+                    "example": "/media/products/550e8400/3f2a_medium.jpg"
+                },
+                "position": {
+                    "description": "Ordre d'affichage (0 = image principale)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "size": {
+                    "description": "Taille de l'original (octets)",
+                    "type": "integer",
+                    "example": 245760
+                },
+                "thumbnailURL": {
+                    "description": "URL de la miniature (200px)",
+                    "type": "string",
+                    "example": "/media/products/550e8400/3f2a_thumb.jpg"
+                },
+                "url": {
+                    "description": "URL de l'image originale",
+                    "type": "string",
+                    "example": "/media/products/550e8400/3f2a.jpg"
+                },
+                "width": {
+                    "description": "Largeur de l'original (px)",
+                    "type": "integer",
+                    "example": 1600
+                }
+            }
+        },
+        "dtos.ProductRequest": {
+            "description": "Informations produit pour création/modification",
+            "type": "object",
+            "required": [
+                "name",
+                "price"
+            ],
+            "properties": {
+                "categoryID": {
+                    "description": "ID de la catégorie (optionnel)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "concerns": {
+                    "description": "Préoccupations ciblées",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "acne",
+                            "aging",
+                            "hyperpigmentation",
+                            "dehydration",
+                            "redness",
+                            "pores",
+                            "dullness"
+                        ]
+                    },
+                    "example": [
+                        "aging"
+                    ]
+                },
+                "description": {
+                    "description": "Description du produit",
+                    "type": "string",
+                    "example": "Crème hydratante pour peau sensible"
+                },
+                "imageURL": {
+                    "description": "URL de l'image du produit",
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "ingredients": {
+                    "description": "Liste INCI des ingrédients",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "AQUA",
+                        "GLYCERIN",
+                        "PARFUM"
+                    ]
+                },
+                "name": {
+                    "description": "Nom du produit",
+                    "type": "string",
+                    "example": "Crème hydratante"
+                },
+                "paoMonths": {
+                    "description": "Période après ouverture en mois (0 = non renseignée)",
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "description": "Prix en euros de la variante par défaut (doit être \u003e 0)",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "skinTypes": {
+                    "description": "Types de peau adaptés",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "dry",
+                            "oily",
+                            "combination",
+                            "sensitive"
+                        ]
+                    },
+                    "example": [
+                        "dry",
+                        "sensitive"
+                    ]
+                },
+                "sku": {
+                    "description": "Référence interne (optionnelle, unique)",
+                    "type": "string",
+                    "example": "CREME-HYD-50"
+                },
+                "stock": {
+                    "description": "Quantité en stock de la variante par défaut",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50
+                },
+                "volumeML": {
+                    "description": "Contenance en ml (0 = non renseignée)",
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "dtos.ProductResponse": {
+            "description": "Informations produit avec catégorie",
+            "type": "object",
+            "properties": {
+                "answeredQuestionCount": {
+                    "description": "Nombre de questions publiées ayant au moins une réponse publiée (détail du produit uniquement)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "category": {
+                    "description": "Informations de la catégorie (si disponible)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.CategoryResponse"
+                        }
+                    ]
+                },
+                "categoryID": {
+                    "description": "ID de la catégorie",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "concerns": {
+                    "description": "Préoccupations ciblées",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aging"
+                    ]
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "description": {
+                    "description": "Description",
+                    "type": "string",
+                    "example": "Crème hydratante pour peau sensible"
+                },
+                "id": {
+                    "description": "UUID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "imageURL": {
+                    "description": "URL de l'image",
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "images": {
+                    "description": "Images uploadées, dans l'ordre d'affichage",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductImageResponse"
+                    }
+                },
+                "ingredients": {
+                    "description": "Liste INCI des ingrédients",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "AQUA",
+                        "GLYCERIN",
+                        "PARFUM"
+                    ]
+                },
+                "isFavorite": {
+                    "description": "Le produit est dans les favoris de l'utilisateur connecté",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "description": "Nom du produit",
+                    "type": "string",
+                    "example": "Crème hydratante"
+                },
+                "paoMonths": {
+                    "description": "Période après ouverture en mois",
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "description": "Prix en euros (variante par défaut)",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "ratingAverage": {
+                    "description": "Note moyenne des avis publiés, arrondie à 1 décimale (0 sans avis)",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "ratingCount": {
+                    "description": "Nombre d'avis publiés",
+                    "type": "integer",
+                    "example": 12
+                },
+                "ratingHistogram": {
+                    "description": "Répartition des avis publiés par note",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.RatingHistogram"
+                        }
+                    ]
+                },
+                "skinTypes": {
+                    "description": "Types de peau adaptés",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "dry",
+                        "sensitive"
+                    ]
+                },
+                "sku": {
+                    "description": "Référence interne",
                     "type": "string",
-                    "example": "Message d'erreur"
+                    "example": "CREME-HYD-50"
+                },
+                "stock": {
+                    "description": "Quantité en stock (toutes variantes)",
+                    "type": "integer",
+                    "example": 50
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "variants": {
+                    "description": "Variantes (formats) du produit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductVariantResponse"
+                    }
+                },
+                "volumeML": {
+                    "description": "Contenance en ml",
+                    "type": "integer",
+                    "example": 50
                 }
             }
         },
-        "docs.SuccessMessage": {
+        "dtos.ProductReviewsResponse": {
+            "description": "Liste des avis avec statistiques (moyenne, total)",
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Opération réussie"
+                "averageRating": {
+                    "description": "Note moyenne (0-5)",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "hasNext": {
+                    "description": "Y a-t-il une page suivante ?",
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "description": "Y a-t-il une page précédente ?",
+                    "type": "boolean"
+                },
+                "histogram": {
+                    "description": "Répartition des avis par note",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.RatingHistogram"
+                        }
+                    ]
+                },
+                "limit": {
+                    "description": "Nombre d'éléments par page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "description": "Page actuelle",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reviews": {
+                    "description": "Page d'avis",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ReviewResponse"
+                    }
+                },
+                "total": {
+                    "description": "Nombre d'avis correspondant aux filtres de note",
+                    "type": "integer",
+                    "example": 7
+                },
+                "totalPages": {
+                    "description": "Nombre total de pages",
+                    "type": "integer",
+                    "example": 1
+                },
+                "totalReviews": {
+                    "description": "Nombre total d'avis",
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "dtos.CategoryRequest": {
-            "description": "Informations catégorie pour création/modification",
+        "dtos.ProductVariantRequest": {
+            "description": "Format d'un produit (contenance, conditionnement) avec son propre prix et stock",
             "type": "object",
             "required": [
-                "name"
+                "label",
+                "price"
             ],
             "properties": {
-                "name": {
-                    "description": "Nom de la catégorie",
+                "barcode": {
+                    "description": "Code-barres (optionnel, unique)",
                     "type": "string",
-                    "example": "Visage"
+                    "example": "3760123456789"
+                },
+                "isDefault": {
+                    "description": "Devenir la variante par défaut du produit",
+                    "type": "boolean",
+                    "example": false
+                },
+                "label": {
+                    "description": "Libellé de la variante",
+                    "type": "string",
+                    "example": "50ml"
+                },
+                "price": {
+                    "description": "Prix en euros (doit être \u003e 0)",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "sku": {
+                    "description": "Référence de la variante (optionnelle, unique)",
+                    "type": "string",
+                    "example": "CREME-HYD-50ML"
+                },
+                "stock": {
+                    "description": "Quantité en stock",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 20
+                },
+                "weightGrams": {
+                    "description": "Poids expédié en grammes (0 = non renseigné)",
+                    "type": "integer",
+                    "example": 120
                 }
             }
         },
-        "dtos.CategoryResponse": {
-            "description": "Informations catégorie",
+        "dtos.ProductVariantResponse": {
+            "description": "Variante d'un produit",
             "type": "object",
             "properties": {
+                "barcode": {
+                    "description": "Code-barres",
+                    "type": "string",
+                    "example": "3760123456789"
+                },
                 "createdAt": {
                     "description": "Date de création",
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
                 "id": {
-                    "description": "UUID de la catégorie",
+                    "description": "UUID de la variante",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "name": {
-                    "description": "Nom de la catégorie",
+                "isDefault": {
+                    "description": "Variante par défaut",
+                    "type": "boolean",
+                    "example": true
+                },
+                "label": {
+                    "description": "Libellé",
                     "type": "string",
-                    "example": "Visage"
+                    "example": "50ml"
+                },
+                "price": {
+                    "description": "Prix en euros",
+                    "type": "number",
+                    "example": 29.99
+                },
+                "sku": {
+                    "description": "Référence de la variante",
+                    "type": "string",
+                    "example": "CREME-HYD-50ML"
+                },
+                "stock": {
+                    "description": "Quantité en stock",
+                    "type": "integer",
+                    "example": 20
                 },
                 "updatedAt": {
                     "description": "Date de mise à jour",
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
+                },
+                "weightGrams": {
+                    "description": "Poids expédié en grammes",
+                    "type": "integer",
+                    "example": 120
                 }
             }
         },
-        "dtos.CreateOrderRequest": {
-            "description": "Requête de création de commande",
+        "dtos.QuestionResponse": {
+            "description": "Question d'un client avec ses réponses",
             "type": "object",
-            "required": [
-                "items"
-            ],
             "properties": {
-                "items": {
-                    "description": "Liste des items de la commande (minimum 1)",
+                "answerCount": {
+                    "description": "Nombre de réponses retournées",
+                    "type": "integer",
+                    "example": 1
+                },
+                "answers": {
+                    "description": "Réponses (publiées uniquement, sauf pour les administrateurs)",
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/dtos.OrderItemRequest"
+                        "$ref": "#/definitions/dtos.AnswerResponse"
                     }
-                }
-            }
-        },
-        "dtos.CreateReviewRequest": {
-            "description": "Avis utilisateur sur un produit",
-            "type": "object",
-            "required": [
-                "productID",
-                "rating"
-            ],
-            "properties": {
-                "comment": {
-                    "description": "Commentaire (optionnel)",
+                },
+                "body": {
+                    "description": "Texte de la question",
                     "type": "string",
-                    "example": "Excellent produit, très satisfait !"
+                    "example": "Ce produit convient-il pendant la grossesse ?"
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "UUID de la question",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "moderatedAt": {
+                    "description": "Date de la dernière décision de modération",
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "moderationReason": {
+                    "description": "Motif du refus (si refusée)",
+                    "type": "string",
+                    "example": "Hors sujet"
                 },
                 "productID": {
                     "description": "ID du produit",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "rating": {
-                    "description": "Note de 1 à 5 (obligatoire)",
+                "status": {
+                    "description": "Statut de modération",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "APPROVED",
+                        "REJECTED"
+                    ],
+                    "example": "APPROVED"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "upvoteCount": {
+                    "description": "Nombre de votes",
                     "type": "integer",
-                    "maximum": 5,
-                    "minimum": 1,
-                    "example": 5
+                    "example": 4
+                },
+                "upvoted": {
+                    "description": "L'utilisateur connecté a voté",
+                    "type": "boolean",
+                    "example": false
+                },
+                "userEmail": {
+                    "description": "Email masqué de l'auteur",
+                    "type": "string",
+                    "example": "u***@example.com"
+                },
+                "userID": {
+                    "description": "ID de l'auteur",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "dtos.LoginRequest": {
-            "description": "Identifiants de connexion",
+        "dtos.RatingHistogram": {
+            "description": "Nombre d'avis publiés pour chaque note de 1 à 5",
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "description": "Email de l'utilisateur",
-                    "type": "string",
-                    "example": "user@example.com"
+                "1": {
+                    "description": "Avis notés 1",
+                    "type": "integer",
+                    "example": 0
                 },
-                "password": {
-                    "description": "Mot de passe",
-                    "type": "string",
-                    "example": "password123"
+                "2": {
+                    "description": "Avis notés 2",
+                    "type": "integer",
+                    "example": 1
+                },
+                "3": {
+                    "description": "Avis notés 3",
+                    "type": "integer",
+                    "example": 1
+                },
+                "4": {
+                    "description": "Avis notés 4",
+                    "type": "integer",
+                    "example": 3
+                },
+                "5": {
+                    "description": "Avis notés 5",
+                    "type": "integer",
+                    "example": 7
                 }
             }
         },
-        "dtos.LoginResponse": {
-            "description": "Réponse de connexion avec token JWT",
+        "dtos.ReceiveReturnRequest": {
+            "description": "Montant remboursé à la réception (absent = montant payé pour les articles retournés)",
             "type": "object",
             "properties": {
-                "token": {
-                    "description": "Token JWT",
+                "note": {
+                    "description": "Note visible par le client",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "Un flacon ouvert, remboursement partiel"
                 },
-                "user": {
-                    "description": "Informations de l'utilisateur",
+                "refundAmount": {
+                    "description": "Montant à rembourser (0 = aucun remboursement)",
+                    "type": "number",
+                    "example": 24.99
+                }
+            }
+        },
+        "dtos.RecommendedProductResponse": {
+            "description": "Produit recommandé avec son score et les raisons de la recommandation",
+            "type": "object",
+            "properties": {
+                "product": {
+                    "description": "Produit recommandé",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/dtos.UserResponse"
+                            "$ref": "#/definitions/dtos.ProductResponse"
                         }
                     ]
+                },
+                "reasons": {
+                    "description": "Raisons de la recommandation",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "adapté à votre type de peau"
+                    ]
+                },
+                "score": {
+                    "description": "Score de pertinence (plus élevé = plus pertinent)",
+                    "type": "number",
+                    "example": 7.5
                 }
             }
         },
-        "dtos.OrderItemRequest": {
-            "description": "Item de commande avec produit et quantité",
+        "dtos.RecomputeRatingsResponse": {
+            "description": "Nombre de produits dont les agrégats de notes ont été corrigés",
+            "type": "object",
+            "properties": {
+                "corrected": {
+                    "description": "Produits dont les agrégats étaient faux (0 si tout était à jour)",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dtos.RefundPaymentRequest": {
+            "description": "Montant à rembourser (0 ou absent = solde restant)",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Montant à rembourser",
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "dtos.ReorderProductImagesRequest": {
+            "description": "Liste complète des IDs d'images dans le nouvel ordre",
+            "type": "object",
+            "required": [
+                "imageIDs"
+            ],
+            "properties": {
+                "imageIDs": {
+                    "description": "IDs des images, la première devient l'image principale",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.ReturnItemRequest": {
+            "description": "Ligne de commande et quantité retournée",
             "type": "object",
             "required": [
-                "productID",
+                "orderItemID",
                 "quantity"
             ],
             "properties": {
-                "productID": {
-                    "description": "ID du produit",
+                "orderItemID": {
+                    "description": "ID de la ligne de commande",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
                 "quantity": {
-                    "description": "Quantité commandée (doit être \u003e 0)",
+                    "description": "Quantité retournée",
                     "type": "integer",
-                    "example": 2
+                    "minimum": 1,
+                    "example": 1
                 }
             }
         },
-        "dtos.OrderItemResponse": {
-            "description": "Item de commande avec détails du produit",
+        "dtos.ReturnItemResponse": {
+            "description": "Article retourné et montant correspondant",
             "type": "object",
             "properties": {
+                "amount": {
+                    "description": "Montant TTC payé pour ces articles",
+                    "type": "number",
+                    "example": 24.99
+                },
                 "id": {
-                    "description": "UUID de l'item",
+                    "description": "UUID de la ligne de retour",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "price": {
-                    "description": "Prix unitaire au moment de la commande",
-                    "type": "number",
-                    "example": 29.99
+                "orderItemID": {
+                    "description": "ID de la ligne de commande",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "product": {
-                    "description": "Détails du produit",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dtos.ProductResponse"
-                        }
-                    ]
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "productName": {
+                    "description": "Nom du produit",
+                    "type": "string",
+                    "example": "Crème hydratante"
                 },
                 "quantity": {
-                    "description": "Quantité commandée",
+                    "description": "Quantité retournée",
                     "type": "integer",
-                    "example": 2
+                    "example": 1
+                },
+                "variantLabel": {
+                    "description": "Variante commandée",
+                    "type": "string",
+                    "example": "50 ml"
                 }
             }
         },
-        "dtos.OrderResponse": {
-            "description": "Informations complètes d'une commande",
+        "dtos.ReturnResponse": {
+            "description": "Demande de retour avec ses articles et son remboursement",
             "type": "object",
             "properties": {
+                "adminNote": {
+                    "description": "Note de l'administrateur",
+                    "type": "string",
+                    "example": "Retour accepté, étiquette envoyée par e-mail"
+                },
                 "createdAt": {
-                    "description": "Date de création",
+                    "description": "Date de la demande",
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
                 "id": {
-                    "description": "UUID de la commande",
+                    "description": "UUID du retour",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "orderDate": {
-                    "description": "Date de la commande",
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
-                },
-                "orderItems": {
-                    "description": "Liste des items de la commande",
+                "items": {
+                    "description": "Articles retournés",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.OrderItemResponse"
+                        "$ref": "#/definitions/dtos.ReturnItemResponse"
                     }
                 },
+                "orderID": {
+                    "description": "ID de la commande",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "reason": {
+                    "description": "Motif du retour",
+                    "type": "string",
+                    "example": "Produit ne convenant pas à ma peau"
+                },
+                "receivedAt": {
+                    "description": "Date de réception",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "refundAmount": {
+                    "description": "Montant remboursé à la réception",
+                    "type": "number",
+                    "example": 24.99
+                },
+                "refundableAmount": {
+                    "description": "Montant payé pour les articles retournés",
+                    "type": "number",
+                    "example": 24.99
+                },
                 "status": {
-                    "description": "Statut de la commande",
+                    "description": "Statut du retour",
                     "type": "string",
                     "enum": [
-                        "PENDING",
-                        "SHIPPED",
-                        "DELIVERED",
-                        "CANCELLED"
+                        "REQUESTED",
+                        "APPROVED",
+                        "REJECTED",
+                        "RECEIVED"
                     ],
-                    "example": "PENDING"
-                },
-                "totalAmount": {
-                    "description": "Montant total de la commande",
-                    "type": "number",
-                    "example": 59.98
+                    "example": "REQUESTED"
                 },
                 "updatedAt": {
                     "description": "Date de mise à jour",
@@ -2078,152 +9519,254 @@ const docTemplate = `{
                     "example": "2024-01-01T00:00:00Z"
                 },
                 "userID": {
-                    "description": "ID de l'utilisateur",
+                    "description": "ID du client",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 }
             }
         },
-        "dtos.PaginatedProductsResponse": {
+        "dtos.ReviewReplyRequest": {
+            "description": "Réponse publique de la marque à un avis",
             "type": "object",
+            "required": [
+                "body"
+            ],
             "properties": {
-                "hasNext": {
-                    "description": "Y a-t-il une page suivante ?",
-                    "type": "boolean"
+                "body": {
+                    "description": "Texte de la réponse (2000 caractères max)",
+                    "type": "string",
+                    "example": "Nous sommes désolés pour l'emballage, contactez-nous à service@porelo.fr"
+                }
+            }
+        },
+        "dtos.ReviewReplyResponse": {
+            "description": "Réponse publique de la marque, affichée sous l'avis",
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Texte de la réponse",
+                    "type": "string",
+                    "example": "Nous sommes désolés pour l'emballage, contactez-nous à service@porelo.fr"
                 },
-                "hasPrev": {
-                    "description": "Y a-t-il une page précédente ?",
-                    "type": "boolean"
+                "createdAt": {
+                    "description": "Date de la réponse",
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
                 },
-                "limit": {
-                    "description": "Nombre d'éléments par page",
-                    "type": "integer"
+                "id": {
+                    "description": "UUID de la réponse",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "page": {
-                    "description": "Page actuelle",
-                    "type": "integer"
+                "updatedAt": {
+                    "description": "Date de la dernière modification",
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                }
+            }
+        },
+        "dtos.ReviewResponse": {
+            "description": "Informations complètes d'un avis",
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Commentaire (optionnel)",
+                    "type": "string",
+                    "example": "Excellent produit !"
                 },
-                "products": {
-                    "description": "Liste des produits",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.ProductResponse"
-                    }
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "total": {
-                    "description": "Nombre total de produits",
-                    "type": "integer"
+                "helpfulCount": {
+                    "description": "Nombre de votes « utile »",
+                    "type": "integer",
+                    "example": 3
                 },
-                "totalPages": {
-                    "description": "Nombre total de pages",
-                    "type": "integer"
+                "id": {
+                    "description": "UUID de l'avis",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "moderatedAt": {
+                    "description": "Date de la dernière décision de modération",
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "moderationReason": {
+                    "description": "Motif du refus (si refusé)",
+                    "type": "string",
+                    "example": "Propos injurieux"
+                },
+                "productID": {
+                    "description": "ID du produit",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "rating": {
+                    "description": "Note de 1 à 5",
+                    "type": "integer",
+                    "example": 5
+                },
+                "reply": {
+                    "description": "Réponse publique de la marque (si elle existe)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ReviewReplyResponse"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Statut de modération",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "APPROVED",
+                        "REJECTED"
+                    ],
+                    "example": "APPROVED"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "userEmail": {
+                    "description": "Email de l'utilisateur (pour affichage)",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "userID": {
+                    "description": "ID de l'utilisateur",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "verifiedPurchase": {
+                    "description": "Achat vérifié : l'auteur a reçu le produit",
+                    "type": "boolean",
+                    "example": true
+                },
+                "votedHelpful": {
+                    "description": "L'utilisateur connecté a voté « utile »",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "dtos.PatchCategoryRequest": {
-            "description": "Permet de mettre à jour uniquement le nom d'une catégorie",
+        "dtos.ReviewReturnRequest": {
+            "description": "Note de l'administrateur (optionnelle)",
             "type": "object",
             "properties": {
-                "name": {
-                    "description": "Nom de la catégorie (optionnel)",
+                "note": {
+                    "description": "Note visible par le client",
                     "type": "string",
-                    "example": "Visage"
+                    "example": "Retour accepté, étiquette envoyée par e-mail"
                 }
             }
         },
-        "dtos.PatchProductRequest": {
-            "description": "Permet de mettre à jour uniquement certains champs d'un produit (tous les champs sont optionnels)",
+        "dtos.ShippingAddressResponse": {
+            "description": "Copie de l'adresse de livraison au moment de la commande",
             "type": "object",
             "properties": {
-                "categoryID": {
-                    "description": "ID de la catégorie (optionnel, peut être null pour supprimer la catégorie)",
+                "city": {
+                    "description": "Ville",
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Paris"
                 },
-                "description": {
-                    "description": "Description du produit (optionnel)",
+                "country": {
+                    "description": "Code pays",
                     "type": "string",
-                    "example": "Crème hydratante pour peau sensible"
+                    "example": "FR"
                 },
-                "imageURL": {
-                    "description": "URL de l'image du produit (optionnel)",
+                "fullName": {
+                    "description": "Destinataire",
                     "type": "string",
-                    "example": "https://example.com/image.jpg"
+                    "example": "Marie Dupont"
                 },
-                "name": {
-                    "description": "Nom du produit (optionnel)",
+                "line1": {
+                    "description": "Adresse",
                     "type": "string",
-                    "example": "Crème hydratante"
+                    "example": "12 rue des Lilas"
                 },
-                "price": {
-                    "description": "Prix en euros (optionnel, doit être \u003e 0 si fourni)",
-                    "type": "number",
-                    "example": 29.99
+                "line2": {
+                    "description": "Complément d'adresse",
+                    "type": "string",
+                    "example": "Bâtiment B"
                 },
-                "stock": {
-                    "description": "Quantité en stock (optionnel, doit être \u003e= 0 si fourni)",
-                    "type": "integer",
-                    "example": 50
+                "phone": {
+                    "description": "Téléphone",
+                    "type": "string",
+                    "example": "+33612345678"
+                },
+                "postalCode": {
+                    "description": "Code postal",
+                    "type": "string",
+                    "example": "75011"
                 }
             }
         },
-        "dtos.ProductRequest": {
-            "description": "Informations produit pour création/modification",
+        "dtos.ShippingMethodRequest": {
+            "description": "Mode de livraison et sa règle de prix (forfait ou au poids, gratuit au-delà d'un seuil)",
             "type": "object",
             "required": [
-                "name",
-                "price"
+                "name"
             ],
             "properties": {
-                "categoryID": {
-                    "description": "ID de la catégorie (optionnel)",
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "active": {
+                    "description": "Proposé aux clients (défaut: true)",
+                    "type": "boolean",
+                    "example": true
                 },
                 "description": {
-                    "description": "Description du produit",
+                    "description": "Description (optionnelle)",
                     "type": "string",
-                    "example": "Crème hydratante pour peau sensible"
+                    "example": "Livraison à domicile en 48h"
                 },
-                "imageURL": {
-                    "description": "URL de l'image du produit",
-                    "type": "string",
-                    "example": "https://example.com/image.jpg"
+                "estimatedDays": {
+                    "description": "Délai indicatif en jours (0 = non renseigné)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "freeAbove": {
+                    "description": "Livraison offerte à partir de ce montant d'articles (optionnel)",
+                    "type": "number",
+                    "example": 49
                 },
                 "name": {
-                    "description": "Nom du produit",
+                    "description": "Nom affiché",
                     "type": "string",
-                    "example": "Crème hydratante"
+                    "example": "Colissimo"
                 },
                 "price": {
-                    "description": "Prix en euros (doit être \u003e 0)",
+                    "description": "Prix fixe (FLAT) ou prix de base (WEIGHT), TTC",
                     "type": "number",
-                    "example": 29.99
+                    "example": 4.9
                 },
-                "stock": {
-                    "description": "Quantité en stock",
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 50
-                }
-            }
-        },
-        "dtos.ProductResponse": {
-            "description": "Informations produit avec catégorie",
-            "type": "object",
-            "properties": {
-                "category": {
-                    "description": "Informations de la catégorie (si disponible)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dtos.CategoryResponse"
-                        }
-                    ]
+                "pricePerKg": {
+                    "description": "Prix par kg entamé (WEIGHT)",
+                    "type": "number",
+                    "example": 1.5
                 },
-                "categoryID": {
-                    "description": "ID de la catégorie",
+                "type": {
+                    "description": "Règle de prix (défaut: FLAT)",
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "enum": [
+                        "FLAT",
+                        "WEIGHT"
+                    ],
+                    "example": "FLAT"
+                }
+            }
+        },
+        "dtos.ShippingMethodResponse": {
+            "description": "Mode de livraison",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Proposé aux clients",
+                    "type": "boolean",
+                    "example": true
                 },
                 "createdAt": {
                     "description": "Date de création",
@@ -2233,32 +9776,46 @@ const docTemplate = `{
                 "description": {
                     "description": "Description",
                     "type": "string",
-                    "example": "Crème hydratante pour peau sensible"
+                    "example": "Livraison à domicile en 48h"
+                },
+                "estimatedDays": {
+                    "description": "Délai indicatif en jours",
+                    "type": "integer",
+                    "example": 2
+                },
+                "freeAbove": {
+                    "description": "Seuil de gratuité",
+                    "type": "number",
+                    "example": 49
                 },
                 "id": {
-                    "description": "UUID du produit",
+                    "description": "UUID du mode de livraison",
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "imageURL": {
-                    "description": "URL de l'image",
-                    "type": "string",
-                    "example": "https://example.com/image.jpg"
-                },
                 "name": {
-                    "description": "Nom du produit",
+                    "description": "Nom affiché",
                     "type": "string",
-                    "example": "Crème hydratante"
+                    "example": "Colissimo"
                 },
                 "price": {
-                    "description": "Prix en euros",
+                    "description": "Prix fixe ou prix de base, TTC",
                     "type": "number",
-                    "example": 29.99
+                    "example": 4.9
                 },
-                "stock": {
-                    "description": "Quantité en stock",
-                    "type": "integer",
-                    "example": 50
+                "pricePerKg": {
+                    "description": "Prix par kg entamé",
+                    "type": "number",
+                    "example": 0
+                },
+                "type": {
+                    "description": "Règle de prix",
+                    "type": "string",
+                    "enum": [
+                        "FLAT",
+                        "WEIGHT"
+                    ],
+                    "example": "FLAT"
                 },
                 "updatedAt": {
                     "description": "Date de mise à jour",
@@ -2267,72 +9824,150 @@ const docTemplate = `{
                 }
             }
         },
-        "dtos.ProductReviewsResponse": {
-            "description": "Liste des avis avec statistiques (moyenne, total)",
+        "dtos.SignedWebhookResponse": {
+            "description": "Requête à envoyer telle quelle sur POST /webhooks/payments/fake",
             "type": "object",
             "properties": {
-                "averageRating": {
-                    "description": "Note moyenne (0-5)",
-                    "type": "number",
-                    "example": 4.5
+                "payload": {
+                    "description": "Corps JSON à envoyer (sans modification)",
+                    "type": "string"
                 },
-                "reviews": {
-                    "description": "Liste des avis",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.ReviewResponse"
-                    }
+                "signature": {
+                    "description": "Valeur de l'en-tête",
+                    "type": "string",
+                    "example": "t=1700000000,v1=5d41402abc4b2a76b9719d911017c592"
                 },
-                "totalReviews": {
-                    "description": "Nombre total d'avis",
-                    "type": "integer",
-                    "example": 10
+                "signatureHeader": {
+                    "description": "Nom de l'en-tête de signature",
+                    "type": "string",
+                    "example": "Fake-Signature"
+                },
+                "url": {
+                    "description": "Endpoint de réception",
+                    "type": "string",
+                    "example": "/webhooks/payments/fake"
                 }
             }
         },
-        "dtos.ReviewResponse": {
-            "description": "Informations complètes d'un avis",
+        "dtos.SimulatePaymentWebhookRequest": {
+            "description": "Événement à simuler pour un paiement du faux prestataire",
             "type": "object",
             "properties": {
-                "comment": {
-                    "description": "Commentaire (optionnel)",
+                "event": {
+                    "description": "Type d'événement",
                     "type": "string",
-                    "example": "Excellent produit !"
+                    "enum": [
+                        "payment.authorized",
+                        "payment.succeeded",
+                        "payment.failed",
+                        "payment.refunded"
+                    ],
+                    "example": "payment.succeeded"
                 },
-                "createdAt": {
-                    "description": "Date de création",
+                "reason": {
+                    "description": "Motif (payment.failed)",
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "carte refusée"
+                }
+            }
+        },
+        "dtos.SkinProfileRequest": {
+            "description": "Profil de peau utilisé pour personnaliser les recommandations",
+            "type": "object",
+            "properties": {
+                "avoidedIngredients": {
+                    "description": "Ingrédients INCI à éviter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PARFUM",
+                        "ALCOHOL DENAT."
+                    ]
                 },
-                "id": {
-                    "description": "UUID de l'avis",
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "concerns": {
+                    "description": "Préoccupations (acne, aging, ...)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "redness"
+                    ]
                 },
-                "productID": {
-                    "description": "ID du produit",
+                "skinType": {
+                    "description": "Type de peau (optionnel)",
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "enum": [
+                        "dry",
+                        "oily",
+                        "combination",
+                        "sensitive"
+                    ],
+                    "example": "sensitive"
+                }
+            }
+        },
+        "dtos.SkinProfileResponse": {
+            "description": "Profil de peau de l'utilisateur",
+            "type": "object",
+            "properties": {
+                "avoidedIngredients": {
+                    "description": "Ingrédients INCI à éviter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PARFUM"
+                    ]
                 },
-                "rating": {
-                    "description": "Note de 1 à 5",
-                    "type": "integer",
-                    "example": 5
+                "concerns": {
+                    "description": "Préoccupations",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "redness"
+                    ]
+                },
+                "skinType": {
+                    "description": "Type de peau",
+                    "type": "string",
+                    "example": "sensitive"
                 },
                 "updatedAt": {
-                    "description": "Date de mise à jour",
+                    "description": "Date de mise à jour (absente si le profil n'a jamais été renseigné)",
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "dtos.TaxLineResponse": {
+            "description": "Montants HT, TVA et TTC des lignes soumises à un même taux",
+            "type": "object",
+            "properties": {
+                "grossAmount": {
+                    "description": "Total TTC",
+                    "type": "number",
+                    "example": 53.99
                 },
-                "userEmail": {
-                    "description": "Email de l'utilisateur (pour affichage)",
-                    "type": "string",
-                    "example": "user@example.com"
+                "netAmount": {
+                    "description": "Total HT",
+                    "type": "number",
+                    "example": 44.99
                 },
-                "userID": {
-                    "description": "ID de l'utilisateur",
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "rate": {
+                    "description": "Taux de TVA (%)",
+                    "type": "number",
+                    "example": 20
+                },
+                "taxAmount": {
+                    "description": "Total TVA",
+                    "type": "number",
+                    "example": 9
                 }
             }
         },
@@ -2429,6 +10064,247 @@ const docTemplate = `{
                     "example": "2024-01-01T00:00:00Z"
                 }
             }
+        },
+        "dtos.WebhookDeliveryResponse": {
+            "description": "Livraison d'un événement à une destination, avec le résultat de la dernière tentative",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Nombre de tentatives",
+                    "type": "integer",
+                    "example": 1
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deliveredAt": {
+                    "description": "Date de réception confirmée",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "durationMs": {
+                    "description": "Durée de la dernière tentative",
+                    "type": "integer",
+                    "example": 120
+                },
+                "endpointID": {
+                    "description": "UUID de la destination",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "eventID": {
+                    "description": "UUID de l'événement (identique pour une relivraison)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "eventType": {
+                    "description": "Type de l'événement",
+                    "type": "string",
+                    "example": "order.created"
+                },
+                "id": {
+                    "description": "UUID de la livraison (en-tête Webhook-Delivery)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "lastAttemptAt": {
+                    "description": "Dernière tentative",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "lastError": {
+                    "description": "Erreur de la dernière tentative",
+                    "type": "string",
+                    "example": "réponse HTTP 500"
+                },
+                "nextAttemptAt": {
+                    "description": "Prochaine tentative (PENDING)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "redeliveryOf": {
+                    "description": "Livraison d'origine (relivraison manuelle)",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "responseBody": {
+                    "description": "Début de la dernière réponse",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "responseStatus": {
+                    "description": "Code HTTP de la dernière réponse",
+                    "type": "integer",
+                    "example": 200
+                },
+                "status": {
+                    "description": "Statut de la livraison",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SUCCEEDED",
+                        "FAILED"
+                    ],
+                    "example": "SUCCEEDED"
+                }
+            }
+        },
+        "dtos.WebhookEndpointRequest": {
+            "description": "Destination de webhooks : URL appelée en POST pour chaque événement des types choisis",
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Envois actifs (défaut: true) ; réactiver remet à zéro le compteur d'échecs",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "description": "Description (optionnelle)",
+                    "type": "string",
+                    "example": "Synchronisation ERP"
+                },
+                "events": {
+                    "description": "Types d'événements envoyés (\"*\" = tous)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.created",
+                        "order.status_changed"
+                    ]
+                },
+                "url": {
+                    "description": "URL http(s) du destinataire",
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/porelo"
+                }
+            }
+        },
+        "dtos.WebhookEndpointResponse": {
+            "description": "Destination de webhooks. Chaque envoi est signé : en-tête Webhook-Signature \"t=\u003ctimestamp\u003e,v1=\u003chex HMAC-SHA256(secret, \"\u003ctimestamp\u003e.\u003ccorps\u003e\")\u003e\"",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Envois actifs",
+                    "type": "boolean",
+                    "example": true
+                },
+                "consecutiveFailures": {
+                    "description": "Tentatives échouées d'affilée",
+                    "type": "integer",
+                    "example": 0
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "description": {
+                    "description": "Description",
+                    "type": "string",
+                    "example": "Synchronisation ERP"
+                },
+                "disabledAt": {
+                    "description": "Date de désactivation automatique",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "events": {
+                    "description": "Types d'événements envoyés",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.created",
+                        "order.status_changed"
+                    ]
+                },
+                "id": {
+                    "description": "UUID de la destination",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "url": {
+                    "description": "URL du destinataire",
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/porelo"
+                }
+            }
+        },
+        "dtos.WebhookEndpointSecretResponse": {
+            "description": "Destination de webhooks avec sa clé de signature, retournée uniquement à la création et à la rotation",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Envois actifs",
+                    "type": "boolean",
+                    "example": true
+                },
+                "consecutiveFailures": {
+                    "description": "Tentatives échouées d'affilée",
+                    "type": "integer",
+                    "example": 0
+                },
+                "createdAt": {
+                    "description": "Date de création",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "description": {
+                    "description": "Description",
+                    "type": "string",
+                    "example": "Synchronisation ERP"
+                },
+                "disabledAt": {
+                    "description": "Date de désactivation automatique",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "events": {
+                    "description": "Types d'événements envoyés",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.created",
+                        "order.status_changed"
+                    ]
+                },
+                "id": {
+                    "description": "UUID de la destination",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "secret": {
+                    "description": "Clé de signature HMAC-SHA256, à conserver : elle n'est plus affichée ensuite",
+                    "type": "string",
+                    "example": "whsec_3f1c..."
+                },
+                "updatedAt": {
+                    "description": "Date de mise à jour",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "url": {
+                    "description": "URL du destinataire",
+                    "type": "string",
+                    "example": "https://erp.example.com/hooks/porelo"
+                }
+            }
         }
     },
     "securityDefinitions": {
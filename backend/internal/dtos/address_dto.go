@@ -0,0 +1,34 @@
+package dtos
+
+import "time"
+
+// AddressRequest DTO pour la création/mise à jour d'une adresse
+// @Description Adresse de livraison du carnet d'adresses
+type AddressRequest struct {
+	Label      string `json:"label,omitempty" example:"Maison"`                    // Nom de l'adresse (optionnel)
+	FullName   string `json:"fullName" example:"Marie Dupont" binding:"required"`  // Destinataire
+	Line1      string `json:"line1" example:"12 rue des Lilas" binding:"required"` // Adresse
+	Line2      string `json:"line2,omitempty" example:"Bâtiment B"`                // Complément d'adresse (optionnel)
+	PostalCode string `json:"postalCode" example:"75011" binding:"required"`       // Code postal
+	City       string `json:"city" example:"Paris" binding:"required"`             // Ville
+	Country    string `json:"country,omitempty" example:"FR"`                      // Code pays ISO à 2 lettres (défaut: FR)
+	Phone      string `json:"phone,omitempty" example:"+33612345678"`              // Téléphone (optionnel)
+	IsDefault  bool   `json:"isDefault" example:"false"`                           // Devenir l'adresse par défaut
+}
+
+// AddressResponse DTO pour la réponse
+// @Description Adresse du carnet d'adresses de l'utilisateur
+type AddressResponse struct {
+	ID         string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"` // UUID de l'adresse
+	Label      string    `json:"label,omitempty" example:"Maison"`                  // Nom de l'adresse
+	FullName   string    `json:"fullName" example:"Marie Dupont"`                   // Destinataire
+	Line1      string    `json:"line1" example:"12 rue des Lilas"`                  // Adresse
+	Line2      string    `json:"line2,omitempty" example:"Bâtiment B"`              // Complément d'adresse
+	PostalCode string    `json:"postalCode" example:"75011"`                        // Code postal
+	City       string    `json:"city" example:"Paris"`                              // Ville
+	Country    string    `json:"country" example:"FR"`                              // Code pays
+	Phone      string    `json:"phone,omitempty" example:"+33612345678"`            // Téléphone
+	IsDefault  bool      `json:"isDefault" example:"true"`                          // Adresse par défaut
+	CreatedAt  time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`          // Date de création
+	UpdatedAt  time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`          // Date de mise à jour
+}
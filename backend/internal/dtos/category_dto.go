@@ -5,20 +5,54 @@ import "time"
 // CategoryRequest DTO pour la création/mise à jour d'une catégorie
 // @Description Informations catégorie pour création/modification
 type CategoryRequest struct {
-	Name string `json:"name" example:"Visage" binding:"required"` // Nom de la catégorie
+	Name        string   `json:"name" example:"Visage" binding:"required"`                          // Nom de la catégorie
+	Slug        string   `json:"slug,omitempty" example:"visage"`                                   // Identifiant d'URL (absent = dérivé du nom à la création, inchangé à la mise à jour)
+	Description *string  `json:"description,omitempty" example:"Soins pour le visage"`              // Description affichée aux clients
+	SortOrder   int      `json:"sortOrder" example:"0"`                                             // Ordre d'affichage parmi les catégories de même parent
+	ParentID    *string  `json:"parentID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // Catégorie parente (absent = catégorie racine)
+	TaxRate     *float64 `json:"taxRate,omitempty" example:"5.5"`                                   // Taux de TVA en % (absent = taux par défaut)
 }
 
 // PatchCategoryRequest DTO pour la mise à jour partielle d'une catégorie
-// @Description Permet de mettre à jour uniquement le nom d'une catégorie
+// @Description Permet de mettre à jour un ou plusieurs champs d'une catégorie
 type PatchCategoryRequest struct {
-	Name *string `json:"name,omitempty" example:"Visage"` // Nom de la catégorie (optionnel)
+	Name        *string  `json:"name,omitempty" example:"Visage"`                                   // Nom de la catégorie (optionnel)
+	Slug        *string  `json:"slug,omitempty" example:"visage"`                                   // Identifiant d'URL (optionnel)
+	Description *string  `json:"description,omitempty" example:"Soins pour le visage"`              // Description (optionnel, "" pour l'effacer)
+	SortOrder   *int     `json:"sortOrder,omitempty" example:"0"`                                   // Ordre d'affichage (optionnel)
+	ParentID    *string  `json:"parentID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // Catégorie parente (optionnel, "" pour remonter à la racine)
+	TaxRate     *float64 `json:"taxRate,omitempty" example:"5.5"`                                   // Taux de TVA en % (optionnel)
 }
 
 // CategoryResponse DTO pour la réponse
 // @Description Informations catégorie
 type CategoryResponse struct {
-	ID        string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"` // UUID de la catégorie
-	Name      string    `json:"name" example:"Visage"`                             // Nom de la catégorie
-	CreatedAt time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`          // Date de création
-	UpdatedAt time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`          // Date de mise à jour
+	ID          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                 // UUID de la catégorie
+	Name        string    `json:"name" example:"Visage"`                                             // Nom de la catégorie
+	Slug        string    `json:"slug" example:"visage"`                                             // Identifiant d'URL
+	Description *string   `json:"description,omitempty" example:"Soins pour le visage"`              // Description
+	SortOrder   int       `json:"sortOrder" example:"0"`                                             // Ordre d'affichage parmi les catégories de même parent
+	ParentID    *string   `json:"parentID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // Catégorie parente (absent = catégorie racine)
+	TaxRate     *float64  `json:"taxRate,omitempty" example:"5.5"`                                   // Taux de TVA propre à la catégorie (absent = taux par défaut)
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`                          // Date de création
+	UpdatedAt   time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                          // Date de mise à jour
+}
+
+// CategoryTreeResponse DTO d'un nœud de l'arborescence publique des catégories
+// @Description Catégorie avec ses sous-catégories et son nombre de produits
+type CategoryTreeResponse struct {
+	ID           string                 `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`    // UUID de la catégorie
+	Name         string                 `json:"name" example:"Visage"`                                // Nom de la catégorie
+	Slug         string                 `json:"slug" example:"visage"`                                // Identifiant d'URL (filtre ?category= de GET /products)
+	Description  *string                `json:"description,omitempty" example:"Soins pour le visage"` // Description
+	SortOrder    int                    `json:"sortOrder" example:"0"`                                // Ordre d'affichage
+	ProductCount int                    `json:"productCount" example:"12"`                            // Nombre de produits de la catégorie et de ses sous-catégories
+	Children     []CategoryTreeResponse `json:"children"`                                             // Sous-catégories, triées par ordre d'affichage puis par nom
+}
+
+// CategoryInUseResponse DTO de refus de suppression d'une catégorie contenant des produits
+// @Description Erreur retournée lorsque la catégorie à supprimer contient encore des produits
+type CategoryInUseResponse struct {
+	Error        string `json:"error" example:"La catégorie contient des produits : précisez reassignTo ou detach=true"` // Message d'erreur
+	ProductCount int    `json:"productCount" example:"12"`                                                               // Nombre de produits de la catégorie
 }
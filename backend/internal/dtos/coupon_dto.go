@@ -0,0 +1,54 @@
+package dtos
+
+import "time"
+
+// CouponRequest DTO pour la création/mise à jour d'un code promo
+// @Description Paramètres d'un code promo (remise en pourcentage, montant fixe ou livraison offerte)
+type CouponRequest struct {
+	Code           string     `json:"code" example:"BIENVENUE10" binding:"required"`                                       // Code saisi par le client (insensible à la casse)
+	Description    string     `json:"description,omitempty" example:"10% sur la première commande"`                        // Description interne
+	Type           string     `json:"type" example:"PERCENTAGE" enums:"PERCENTAGE,FIXED,FREE_SHIPPING" binding:"required"` // Type de remise
+	Value          float64    `json:"value" example:"10"`                                                                  // Pourcentage (0-100] ou montant fixe ; ignoré pour FREE_SHIPPING
+	MinOrderAmount float64    `json:"minOrderAmount,omitempty" example:"50"`                                               // Montant minimum de commande avant remise (0 = aucun)
+	StartsAt       *time.Time `json:"startsAt,omitempty" example:"2024-01-01T00:00:00Z"`                                   // Début de validité (optionnel)
+	EndsAt         *time.Time `json:"endsAt,omitempty" example:"2024-12-31T23:59:59Z"`                                     // Fin de validité (optionnel)
+	MaxUses        int        `json:"maxUses,omitempty" example:"100"`                                                     // Nombre maximal d'utilisations (0 = illimité)
+	MaxUsesPerUser int        `json:"maxUsesPerUser,omitempty" example:"1"`                                                // Nombre maximal d'utilisations par client (0 = illimité)
+	Active         *bool      `json:"active,omitempty" example:"true"`                                                     // Code actif (défaut: true)
+	ProductIDs     []string   `json:"productIDs,omitempty"`                                                                // Produits éligibles (vide = tous)
+	CategoryIDs    []string   `json:"categoryIDs,omitempty"`                                                               // Catégories éligibles (vide = toutes)
+}
+
+// CouponResponse DTO pour la réponse
+// @Description Informations d'un code promo
+type CouponResponse struct {
+	ID             string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                // UUID du coupon
+	Code           string     `json:"code" example:"BIENVENUE10"`                                       // Code promo
+	Description    string     `json:"description,omitempty" example:"10% sur la première commande"`     // Description interne
+	Type           string     `json:"type" example:"PERCENTAGE" enums:"PERCENTAGE,FIXED,FREE_SHIPPING"` // Type de remise
+	Value          float64    `json:"value" example:"10"`                                               // Pourcentage ou montant fixe
+	MinOrderAmount float64    `json:"minOrderAmount" example:"50"`                                      // Montant minimum de commande (0 = aucun)
+	StartsAt       *time.Time `json:"startsAt,omitempty" example:"2024-01-01T00:00:00Z"`                // Début de validité
+	EndsAt         *time.Time `json:"endsAt,omitempty" example:"2024-12-31T23:59:59Z"`                  // Fin de validité
+	MaxUses        int        `json:"maxUses" example:"100"`                                            // Nombre maximal d'utilisations (0 = illimité)
+	MaxUsesPerUser int        `json:"maxUsesPerUser" example:"1"`                                       // Nombre maximal d'utilisations par client (0 = illimité)
+	Active         bool       `json:"active" example:"true"`                                            // Code actif
+	ProductIDs     []string   `json:"productIDs"`                                                       // Produits éligibles (vide = tous)
+	CategoryIDs    []string   `json:"categoryIDs"`                                                      // Catégories éligibles (vide = toutes)
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`                         // Date de création
+	UpdatedAt      time.Time  `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                         // Date de mise à jour
+}
+
+// CouponStatsResponse DTO pour les statistiques d'utilisation d'un code promo
+// @Description Utilisation d'un code promo (les commandes annulées ne consomment pas d'utilisation)
+type CouponStatsResponse struct {
+	CouponID        string  `json:"couponID" example:"550e8400-e29b-41d4-a716-446655440000"` // UUID du coupon
+	Code            string  `json:"code" example:"BIENVENUE10"`                              // Code promo
+	UsageCount      int     `json:"usageCount" example:"42"`                                 // Commandes non annulées ayant utilisé le code
+	CancelledCount  int     `json:"cancelledCount" example:"3"`                              // Commandes annulées ayant utilisé le code
+	UniqueUsers     int     `json:"uniqueUsers" example:"40"`                                // Nombre de clients distincts
+	RemainingUses   *int    `json:"remainingUses,omitempty" example:"58"`                    // Utilisations restantes (absent si illimité)
+	TotalDiscount   float64 `json:"totalDiscount" example:"312.5"`                           // Remises accordées (commandes non annulées)
+	TotalRevenue    float64 `json:"totalRevenue" example:"2840.9"`                           // Chiffre d'affaires des commandes (après remise, remboursements déduits)
+	AverageDiscount float64 `json:"averageDiscount" example:"7.44"`                          // Remise moyenne par commande
+}
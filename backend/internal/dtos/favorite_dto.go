@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+// AddFavoriteRequest DTO pour ajouter un produit aux favoris
+// @Description Produit à ajouter aux favoris de l'utilisateur connecté
+type AddFavoriteRequest struct {
+	ProductID string `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"` // ID du produit
+}
+
+// FavoriteResponse DTO pour un favori
+// @Description Produit favori de l'utilisateur avec la date d'ajout
+type FavoriteResponse struct {
+	ProductID string          `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000"` // ID du produit
+	Product   ProductResponse `json:"product"`                                                  // Détails du produit
+	CreatedAt time.Time       `json:"createdAt" example:"2024-01-01T00:00:00Z"`                 // Date d'ajout aux favoris
+}
+
+// MostWishedProductResponse DTO pour une ligne du rapport "produits les plus souhaités"
+// @Description Produit et nombre d'utilisateurs l'ayant ajouté à leurs favoris
+type MostWishedProductResponse struct {
+	ProductID     string  `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000"` // ID du produit
+	Name          string  `json:"name" example:"Crème hydratante"`                          // Nom du produit
+	Price         float64 `json:"price" example:"29.99"`                                    // Prix actuel
+	Stock         int     `json:"stock" example:"12"`                                       // Stock actuel (toutes variantes)
+	FavoriteCount int     `json:"favoriteCount" example:"42"`                               // Nombre d'utilisateurs l'ayant en favori
+}
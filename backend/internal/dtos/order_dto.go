@@ -6,35 +6,81 @@ import "time"
 // @Description Item de commande avec produit et quantité
 type OrderItemRequest struct {
 	ProductID string `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"` // ID du produit
+	VariantID string `json:"variantID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`          // ID de la variante (optionnel, variante par défaut sinon)
 	Quantity  int    `json:"quantity" example:"2" binding:"required,gt=0"`                                // Quantité commandée (doit être > 0)
 }
 
 // CreateOrderRequest DTO pour créer une commande
 // @Description Requête de création de commande
 type CreateOrderRequest struct {
-	Items []OrderItemRequest `json:"items" binding:"required,min=1"` // Liste des items de la commande (minimum 1)
+	Items            []OrderItemRequest `json:"items" binding:"required,min=1"`                                                     // Liste des items de la commande (minimum 1)
+	CouponCode       string             `json:"couponCode,omitempty" example:"BIENVENUE10"`                                         // Code promo (optionnel)
+	AddressID        string             `json:"addressID" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"`        // Adresse de livraison (carnet d'adresses de l'utilisateur)
+	ShippingMethodID string             `json:"shippingMethodID" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"` // Mode de livraison
 }
 
 // OrderItemResponse DTO pour la réponse d'un item
 // @Description Item de commande avec détails du produit
 type OrderItemResponse struct {
-	ID       string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"` // UUID de l'item
-	Quantity int             `json:"quantity" example:"2"`                              // Quantité commandée
-	Price    float64         `json:"price" example:"29.99"`                             // Prix unitaire au moment de la commande
-	Product  ProductResponse `json:"product"`                                           // Détails du produit
+	ID             string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                  // UUID de l'item
+	Quantity       int             `json:"quantity" example:"2"`                                               // Quantité commandée
+	Price          float64         `json:"price" example:"29.99"`                                              // Prix unitaire au moment de la commande
+	VariantID      *string         `json:"variantID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la variante commandée
+	VariantLabel   string          `json:"variantLabel,omitempty" example:"50ml"`                              // Libellé de la variante au moment de la commande
+	DiscountAmount float64         `json:"discountAmount" example:"2.99"`                                      // Part de la remise imputée à cette ligne
+	TaxRate        float64         `json:"taxRate" example:"20"`                                               // Taux de TVA appliqué (%)
+	NetAmount      float64         `json:"netAmount" example:"47.49"`                                          // Montant HT de la ligne (remise déduite)
+	TaxAmount      float64         `json:"taxAmount" example:"9.50"`                                           // Montant de TVA de la ligne
+	GrossAmount    float64         `json:"grossAmount" example:"56.99"`                                        // Montant TTC de la ligne (remise déduite)
+	Product        ProductResponse `json:"product"`                                                            // Détails du produit
 }
 
 // OrderResponse DTO pour la réponse d'une commande
 // @Description Informations complètes d'une commande
 type OrderResponse struct {
-	ID          string              `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                    // UUID de la commande
-	OrderDate   time.Time           `json:"orderDate" example:"2024-01-01T00:00:00Z"`                             // Date de la commande
-	TotalAmount float64             `json:"totalAmount" example:"59.98"`                                          // Montant total de la commande
-	Status      string              `json:"status" example:"PENDING" enums:"PENDING,SHIPPED,DELIVERED,CANCELLED"` // Statut de la commande
-	UserID      string              `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`                // ID de l'utilisateur
-	OrderItems  []OrderItemResponse `json:"orderItems"`                                                           // Liste des items de la commande
-	CreatedAt   time.Time           `json:"createdAt" example:"2024-01-01T00:00:00Z"`                             // Date de création
-	UpdatedAt   time.Time           `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                             // Date de mise à jour
+	ID              string                   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                    // UUID de la commande
+	OrderDate       time.Time                `json:"orderDate" example:"2024-01-01T00:00:00Z"`                             // Date de la commande
+	SubtotalAmount  float64                  `json:"subtotalAmount" example:"59.98"`                                       // Montant des articles avant remise
+	DiscountAmount  float64                  `json:"discountAmount" example:"5.99"`                                        // Remise accordée par le code promo
+	TotalAmount     float64                  `json:"totalAmount" example:"53.99"`                                          // Montant total de la commande (après remise)
+	NetAmount       float64                  `json:"netAmount" example:"44.99"`                                            // Total HT
+	TaxAmount       float64                  `json:"taxAmount" example:"9.00"`                                             // Total TVA
+	TaxBreakdown    []TaxLineResponse        `json:"taxBreakdown"`                                                         // Détail HT / TVA / TTC par taux
+	CouponCode      string                   `json:"couponCode,omitempty" example:"BIENVENUE10"`                           // Code promo appliqué
+	FreeShipping    bool                     `json:"freeShipping" example:"false"`                                         // Livraison offerte par le code promo
+	ShippingMethod  string                   `json:"shippingMethod,omitempty" example:"Colissimo"`                         // Mode de livraison au moment de la commande
+	ShippingAmount  float64                  `json:"shippingAmount" example:"4.90"`                                        // Frais de port TTC (inclus dans totalAmount)
+	ShippingAddress *ShippingAddressResponse `json:"shippingAddress,omitempty"`                                            // Adresse de livraison copiée à la commande
+	PaidAt          *time.Time               `json:"paidAt,omitempty" example:"2024-01-01T00:00:00Z"`                      // Date du paiement (absent si non payée)
+	DeliveredAt     *time.Time               `json:"deliveredAt,omitempty" example:"2024-01-01T00:00:00Z"`                 // Date de livraison (point de départ du délai de retour)
+	RefundedAmount  float64                  `json:"refundedAmount" example:"0"`                                           // Total remboursé (retours et remboursements)
+	InvoiceNumber   string                   `json:"invoiceNumber,omitempty" example:"FA-000042"`                          // Numéro de facture (attribué au paiement ou à l'expédition)
+	Status          string                   `json:"status" example:"PENDING" enums:"PENDING,SHIPPED,DELIVERED,CANCELLED"` // Statut de la commande
+	UserID          string                   `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`                // ID de l'utilisateur
+	OrderItems      []OrderItemResponse      `json:"orderItems"`                                                           // Liste des items de la commande
+	CreatedAt       time.Time                `json:"createdAt" example:"2024-01-01T00:00:00Z"`                             // Date de création
+	UpdatedAt       time.Time                `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                             // Date de mise à jour
+}
+
+// ShippingAddressResponse DTO pour l'adresse de livraison d'une commande
+// @Description Copie de l'adresse de livraison au moment de la commande
+type ShippingAddressResponse struct {
+	FullName   string `json:"fullName" example:"Marie Dupont"`        // Destinataire
+	Line1      string `json:"line1" example:"12 rue des Lilas"`       // Adresse
+	Line2      string `json:"line2,omitempty" example:"Bâtiment B"`   // Complément d'adresse
+	PostalCode string `json:"postalCode" example:"75011"`             // Code postal
+	City       string `json:"city" example:"Paris"`                   // Ville
+	Country    string `json:"country" example:"FR"`                   // Code pays
+	Phone      string `json:"phone,omitempty" example:"+33612345678"` // Téléphone
+}
+
+// TaxLineResponse DTO pour le détail de la TVA d'une commande par taux
+// @Description Montants HT, TVA et TTC des lignes soumises à un même taux
+type TaxLineResponse struct {
+	Rate        float64 `json:"rate" example:"20"`           // Taux de TVA (%)
+	NetAmount   float64 `json:"netAmount" example:"44.99"`   // Total HT
+	TaxAmount   float64 `json:"taxAmount" example:"9.00"`    // Total TVA
+	GrossAmount float64 `json:"grossAmount" example:"53.99"` // Total TTC
 }
 
 // UpdateOrderStatusRequest DTO pour mettre à jour le statut d'une commande
@@ -42,3 +88,14 @@ type OrderResponse struct {
 type UpdateOrderStatusRequest struct {
 	Status string `json:"status" example:"SHIPPED" binding:"required,oneof=PENDING SHIPPED DELIVERED CANCELLED"` // Nouveau statut
 }
+
+// OrderStreamEvent DTO pour les événements du flux temps réel des commandes (SSE)
+// @Description Événement SSE "order.created" ou "order.status_changed" ; l'id SSE est à renvoyer dans Last-Event-ID pour reprendre le flux
+type OrderStreamEvent struct {
+	OrderID        string    `json:"orderID" example:"550e8400-e29b-41d4-a716-446655440000"`               // UUID de la commande
+	UserID         string    `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`                // UUID du client
+	Status         string    `json:"status" example:"SHIPPED" enums:"PENDING,SHIPPED,DELIVERED,CANCELLED"` // Statut courant
+	PreviousStatus string    `json:"previousStatus,omitempty" example:"PENDING"`                           // Statut précédent (order.status_changed)
+	TotalAmount    float64   `json:"totalAmount,omitempty" example:"59.98"`                                // Montant total TTC (order.created)
+	OccurredAt     time.Time `json:"occurredAt" example:"2024-01-01T00:00:00Z"`                            // Date du changement
+}
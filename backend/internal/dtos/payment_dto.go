@@ -0,0 +1,48 @@
+package dtos
+
+import "time"
+
+// PayOrderRequest DTO pour initier le paiement d'une commande
+// @Description Choix du prestataire de paiement
+type PayOrderRequest struct {
+	Provider string `json:"provider,omitempty" example:"fake"` // Prestataire (défaut: PAYMENT_DEFAULT_PROVIDER)
+}
+
+// PaymentResponse DTO pour un paiement
+// @Description Paiement d'une commande et son statut
+type PaymentResponse struct {
+	ID                string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                                                // UUID du paiement
+	OrderID           string    `json:"orderID" example:"550e8400-e29b-41d4-a716-446655440000"`                                           // ID de la commande
+	Provider          string    `json:"provider" example:"fake"`                                                                          // Prestataire
+	ProviderPaymentID string    `json:"providerPaymentID" example:"fake_pi_3f2a9c1d7e6b5a4f3e2d1c0b"`                                     // Identifiant chez le prestataire
+	ClientSecret      string    `json:"clientSecret,omitempty" example:"fake_pi_3f2a9c1d7e6b5a4f3e2d1c0b_secret_9a8b7c6d5e4f3a2b"`        // Secret pour finaliser le paiement côté client (à l'initiation uniquement)
+	Status            string    `json:"status" example:"PENDING" enums:"PENDING,AUTHORIZED,SUCCEEDED,FAILED,PARTIALLY_REFUNDED,REFUNDED"` // Statut du paiement
+	Amount            float64   `json:"amount" example:"53.99"`                                                                           // Montant TTC
+	AmountRefunded    float64   `json:"amountRefunded" example:"0"`                                                                       // Montant remboursé
+	Currency          string    `json:"currency" example:"EUR"`                                                                           // Devise
+	FailureReason     string    `json:"failureReason,omitempty" example:"carte refusée"`                                                  // Motif d'échec
+	CreatedAt         time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`                                                         // Date de création
+	UpdatedAt         time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                                                         // Date de mise à jour
+}
+
+// RefundPaymentRequest DTO pour rembourser un paiement
+// @Description Montant à rembourser (0 ou absent = solde restant)
+type RefundPaymentRequest struct {
+	Amount float64 `json:"amount,omitempty" example:"10"` // Montant à rembourser
+}
+
+// SimulatePaymentWebhookRequest DTO pour générer un webhook signé du faux prestataire
+// @Description Événement à simuler pour un paiement du faux prestataire
+type SimulatePaymentWebhookRequest struct {
+	Event  string `json:"event" example:"payment.succeeded" enums:"payment.authorized,payment.succeeded,payment.failed,payment.refunded"` // Type d'événement
+	Reason string `json:"reason,omitempty" example:"carte refusée"`                                                                       // Motif (payment.failed)
+}
+
+// SignedWebhookResponse DTO pour un webhook signé prêt à être envoyé
+// @Description Requête à envoyer telle quelle sur POST /webhooks/payments/fake
+type SignedWebhookResponse struct {
+	URL             string `json:"url" example:"/webhooks/payments/fake"`                                // Endpoint de réception
+	SignatureHeader string `json:"signatureHeader" example:"Fake-Signature"`                             // Nom de l'en-tête de signature
+	Signature       string `json:"signature" example:"t=1700000000,v1=5d41402abc4b2a76b9719d911017c592"` // Valeur de l'en-tête
+	Payload         string `json:"payload"`                                                              // Corps JSON à envoyer (sans modification)
+}
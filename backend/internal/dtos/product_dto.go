@@ -13,39 +13,127 @@ type PaginatedProductsResponse struct {
 	HasPrev    bool              `json:"hasPrev"`    // Y a-t-il une page précédente ?
 }
 
+// ProductFilters regroupe les filtres de recherche de GET /products
+type ProductFilters struct {
+	Category           string   // Slug de catégorie (sous-catégories incluses)
+	SkinType           string   // Type de peau (dry, oily, combination, sensitive)
+	Concern            string   // Préoccupation ciblée (acne, aging, ...)
+	ExcludeIngredients []string // Ingrédients INCI à exclure (allergènes)
+	Sort               string   // Tri (rating : meilleures notes d'abord)
+}
+
 // ProductRequest DTO pour la création/mise à jour d'un produit
 // @Description Informations produit pour création/modification
 type ProductRequest struct {
-	Name        string  `json:"name" example:"Crème hydratante" binding:"required"`        // Nom du produit
-	Description string  `json:"description" example:"Crème hydratante pour peau sensible"` // Description du produit
-	Price       float64 `json:"price" example:"29.99" binding:"required,gt=0"`             // Prix en euros (doit être > 0)
-	Stock       int     `json:"stock" example:"50" binding:"gte=0"`                        // Quantité en stock
-	ImageURL    string  `json:"imageURL" example:"https://example.com/image.jpg"`          // URL de l'image du produit
-	CategoryID  string  `json:"categoryID" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la catégorie (optionnel)
+	Name        string   `json:"name" example:"Crème hydratante" binding:"required"`                                               // Nom du produit
+	SKU         string   `json:"sku" example:"CREME-HYD-50"`                                                                       // Référence interne (optionnelle, unique)
+	Description string   `json:"description" example:"Crème hydratante pour peau sensible"`                                        // Description du produit
+	Price       float64  `json:"price" example:"29.99" binding:"required,gt=0"`                                                    // Prix en euros de la variante par défaut (doit être > 0)
+	Stock       int      `json:"stock" example:"50" binding:"gte=0"`                                                               // Quantité en stock de la variante par défaut
+	ImageURL    string   `json:"imageURL" example:"https://example.com/image.jpg"`                                                 // URL de l'image du produit
+	CategoryID  string   `json:"categoryID" example:"550e8400-e29b-41d4-a716-446655440000"`                                        // ID de la catégorie (optionnel)
+	Ingredients []string `json:"ingredients" example:"AQUA,GLYCERIN,PARFUM"`                                                       // Liste INCI des ingrédients
+	SkinTypes   []string `json:"skinTypes" example:"dry,sensitive" enums:"dry,oily,combination,sensitive"`                         // Types de peau adaptés
+	Concerns    []string `json:"concerns" example:"aging" enums:"acne,aging,hyperpigmentation,dehydration,redness,pores,dullness"` // Préoccupations ciblées
+	VolumeML    int      `json:"volumeML" example:"50"`                                                                            // Contenance en ml (0 = non renseignée)
+	PAOMonths   int      `json:"paoMonths" example:"12"`                                                                           // Période après ouverture en mois (0 = non renseignée)
 }
 
 // PatchProductRequest DTO pour la mise à jour partielle d'un produit
 // @Description Permet de mettre à jour uniquement certains champs d'un produit (tous les champs sont optionnels)
 type PatchProductRequest struct {
-	Name        *string  `json:"name,omitempty" example:"Crème hydratante"`                           // Nom du produit (optionnel)
-	Description *string  `json:"description,omitempty" example:"Crème hydratante pour peau sensible"` // Description du produit (optionnel)
-	Price       *float64 `json:"price,omitempty" example:"29.99"`                                     // Prix en euros (optionnel, doit être > 0 si fourni)
-	Stock       *int     `json:"stock,omitempty" example:"50"`                                        // Quantité en stock (optionnel, doit être >= 0 si fourni)
-	ImageURL    *string  `json:"imageURL,omitempty" example:"https://example.com/image.jpg"`          // URL de l'image du produit (optionnel)
-	CategoryID  *string  `json:"categoryID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la catégorie (optionnel, peut être null pour supprimer la catégorie)
+	Name        *string   `json:"name,omitempty" example:"Crème hydratante"`                           // Nom du produit (optionnel)
+	SKU         *string   `json:"sku,omitempty" example:"CREME-HYD-50"`                                // Référence interne (optionnel)
+	Description *string   `json:"description,omitempty" example:"Crème hydratante pour peau sensible"` // Description du produit (optionnel)
+	Price       *float64  `json:"price,omitempty" example:"29.99"`                                     // Prix de la variante par défaut (optionnel, doit être > 0 si fourni)
+	Stock       *int      `json:"stock,omitempty" example:"50"`                                        // Stock de la variante par défaut (optionnel, doit être >= 0 si fourni)
+	ImageURL    *string   `json:"imageURL,omitempty" example:"https://example.com/image.jpg"`          // URL de l'image du produit (optionnel)
+	CategoryID  *string   `json:"categoryID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la catégorie (optionnel, peut être null pour supprimer la catégorie)
+	Ingredients *[]string `json:"ingredients,omitempty" example:"AQUA,GLYCERIN"`                       // Liste INCI (optionnel, remplace la liste existante)
+	SkinTypes   *[]string `json:"skinTypes,omitempty" example:"sensitive"`                             // Types de peau (optionnel, remplace la liste existante)
+	Concerns    *[]string `json:"concerns,omitempty" example:"acne"`                                   // Préoccupations (optionnel, remplace la liste existante)
+	VolumeML    *int      `json:"volumeML,omitempty" example:"50"`                                     // Contenance en ml (optionnel, 0 pour effacer)
+	PAOMonths   *int      `json:"paoMonths,omitempty" example:"12"`                                    // PAO en mois (optionnel, 0 pour effacer)
 }
 
 // ProductResponse DTO pour la réponse
 // @Description Informations produit avec catégorie
 type ProductResponse struct {
-	ID          string            `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                   // UUID du produit
-	Name        string            `json:"name" example:"Crème hydratante"`                                     // Nom du produit
-	Description string            `json:"description" example:"Crème hydratante pour peau sensible"`           // Description
-	Price       float64           `json:"price" example:"29.99"`                                               // Prix en euros
-	Stock       int               `json:"stock" example:"50"`                                                  // Quantité en stock
-	ImageURL    string            `json:"imageURL" example:"https://example.com/image.jpg"`                    // URL de l'image
-	CategoryID  *string           `json:"categoryID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la catégorie
-	Category    *CategoryResponse `json:"category,omitempty"`                                                  // Informations de la catégorie (si disponible)
-	CreatedAt   time.Time         `json:"createdAt" example:"2024-01-01T00:00:00Z"`                            // Date de création
-	UpdatedAt   time.Time         `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                            // Date de mise à jour
+	ID                    string                   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                   // UUID du produit
+	Name                  string                   `json:"name" example:"Crème hydratante"`                                     // Nom du produit
+	SKU                   string                   `json:"sku,omitempty" example:"CREME-HYD-50"`                                // Référence interne
+	Description           string                   `json:"description" example:"Crème hydratante pour peau sensible"`           // Description
+	Price                 float64                  `json:"price" example:"29.99"`                                               // Prix en euros (variante par défaut)
+	Stock                 int                      `json:"stock" example:"50"`                                                  // Quantité en stock (toutes variantes)
+	ImageURL              string                   `json:"imageURL" example:"https://example.com/image.jpg"`                    // URL de l'image
+	Images                []ProductImageResponse   `json:"images"`                                                              // Images uploadées, dans l'ordre d'affichage
+	Variants              []ProductVariantResponse `json:"variants"`                                                            // Variantes (formats) du produit
+	Ingredients           []string                 `json:"ingredients" example:"AQUA,GLYCERIN,PARFUM"`                          // Liste INCI des ingrédients
+	SkinTypes             []string                 `json:"skinTypes" example:"dry,sensitive"`                                   // Types de peau adaptés
+	Concerns              []string                 `json:"concerns" example:"aging"`                                            // Préoccupations ciblées
+	VolumeML              *int                     `json:"volumeML,omitempty" example:"50"`                                     // Contenance en ml
+	PAOMonths             *int                     `json:"paoMonths,omitempty" example:"12"`                                    // Période après ouverture en mois
+	CategoryID            *string                  `json:"categoryID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la catégorie
+	Category              *CategoryResponse        `json:"category,omitempty"`                                                  // Informations de la catégorie (si disponible)
+	IsFavorite            bool                     `json:"isFavorite" example:"false"`                                          // Le produit est dans les favoris de l'utilisateur connecté
+	RatingAverage         float64                  `json:"ratingAverage" example:"4.5"`                                         // Note moyenne des avis publiés, arrondie à 1 décimale (0 sans avis)
+	RatingCount           int                      `json:"ratingCount" example:"12"`                                            // Nombre d'avis publiés
+	RatingHistogram       RatingHistogram          `json:"ratingHistogram"`                                                     // Répartition des avis publiés par note
+	AnsweredQuestionCount *int                     `json:"answeredQuestionCount,omitempty" example:"3"`                         // Nombre de questions publiées ayant au moins une réponse publiée (détail du produit uniquement)
+	CreatedAt             time.Time                `json:"createdAt" example:"2024-01-01T00:00:00Z"`                            // Date de création
+	UpdatedAt             time.Time                `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                            // Date de mise à jour
+}
+
+// ProductImageResponse DTO pour une image de produit
+// @Description Image uploadée d'un produit avec ses miniatures
+type ProductImageResponse struct {
+	ID           string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`              // UUID de l'image
+	URL          string    `json:"url" example:"/media/products/550e8400/3f2a.jpg"`                // URL de l'image originale
+	ThumbnailURL string    `json:"thumbnailURL" example:"/media/products/550e8400/3f2a_thumb.jpg"` // URL de la miniature (200px)
+	MediumURL    string    `json:"mediumURL" example:"/media/products/550e8400/3f2a_medium.jpg"`   // URL de la version moyenne (800px)
+	ContentType  string    `json:"contentType" example:"image/jpeg"`                               // Type MIME
+	Width        int       `json:"width" example:"1600"`                                           // Largeur de l'original (px)
+	Height       int       `json:"height" example:"1200"`                                          // Hauteur de l'original (px)
+	Size         int       `json:"size" example:"245760"`                                          // Taille de l'original (octets)
+	Position     int       `json:"position" example:"0"`                                           // Ordre d'affichage (0 = image principale)
+	CreatedAt    time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`                       // Date d'upload
+}
+
+// ReorderProductImagesRequest DTO pour réordonner les images d'un produit
+// @Description Liste complète des IDs d'images dans le nouvel ordre
+type ReorderProductImagesRequest struct {
+	ImageIDs []string `json:"imageIDs" binding:"required"` // IDs des images, la première devient l'image principale
+}
+
+// ProductVariantRequest DTO pour la création/mise à jour d'une variante
+// @Description Format d'un produit (contenance, conditionnement) avec son propre prix et stock
+type ProductVariantRequest struct {
+	Label       string  `json:"label" example:"50ml" binding:"required"`       // Libellé de la variante
+	SKU         string  `json:"sku" example:"CREME-HYD-50ML"`                  // Référence de la variante (optionnelle, unique)
+	Price       float64 `json:"price" example:"29.99" binding:"required,gt=0"` // Prix en euros (doit être > 0)
+	Stock       int     `json:"stock" example:"20" binding:"gte=0"`            // Quantité en stock
+	Barcode     string  `json:"barcode" example:"3760123456789"`               // Code-barres (optionnel, unique)
+	IsDefault   bool    `json:"isDefault" example:"false"`                     // Devenir la variante par défaut du produit
+	WeightGrams int     `json:"weightGrams" example:"120"`                     // Poids expédié en grammes (0 = non renseigné)
+}
+
+// ProductVariantResponse DTO pour la réponse d'une variante
+// @Description Variante d'un produit
+type ProductVariantResponse struct {
+	ID          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"` // UUID de la variante
+	SKU         string    `json:"sku,omitempty" example:"CREME-HYD-50ML"`            // Référence de la variante
+	Label       string    `json:"label" example:"50ml"`                              // Libellé
+	Price       float64   `json:"price" example:"29.99"`                             // Prix en euros
+	Stock       int       `json:"stock" example:"20"`                                // Quantité en stock
+	Barcode     string    `json:"barcode,omitempty" example:"3760123456789"`         // Code-barres
+	IsDefault   bool      `json:"isDefault" example:"true"`                          // Variante par défaut
+	WeightGrams int       `json:"weightGrams" example:"120"`                         // Poids expédié en grammes
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`          // Date de création
+	UpdatedAt   time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`          // Date de mise à jour
+}
+
+// RecomputeRatingsResponse DTO pour le résultat du recalcul des notes
+// @Description Nombre de produits dont les agrégats de notes ont été corrigés
+type RecomputeRatingsResponse struct {
+	Corrected int `json:"corrected" example:"0"` // Produits dont les agrégats étaient faux (0 si tout était à jour)
 }
@@ -0,0 +1,42 @@
+package dtos
+
+// ImportProductRow représente une ligne du fichier d'import (CSV ou JSON)
+// @Description Produit à créer ou mettre à jour lors d'un import en masse
+type ImportProductRow struct {
+	Name        string   `json:"name" example:"Crème hydratante"`                                                                  // Nom du produit (obligatoire)
+	SKU         string   `json:"sku" example:"CREME-HYD-50"`                                                                       // Référence interne (optionnelle)
+	Description string   `json:"description" example:"Crème hydratante pour peau sensible"`                                        // Description du produit
+	Price       float64  `json:"price" example:"29.99"`                                                                            // Prix en euros (doit être > 0)
+	Stock       int      `json:"stock" example:"50"`                                                                               // Quantité en stock (doit être >= 0)
+	ImageURL    string   `json:"imageURL" example:"https://example.com/image.jpg"`                                                 // URL de l'image du produit
+	Category    string   `json:"category" example:"Visage"`                                                                        // Nom de la catégorie (créée si elle n'existe pas)
+	Ingredients []string `json:"ingredients" example:"AQUA,GLYCERIN,PARFUM"`                                                       // Liste INCI des ingrédients (CSV : séparés par |)
+	SkinTypes   []string `json:"skinTypes" example:"dry,sensitive" enums:"dry,oily,combination,sensitive"`                         // Types de peau adaptés (CSV : séparés par |)
+	Concerns    []string `json:"concerns" example:"aging" enums:"acne,aging,hyperpigmentation,dehydration,redness,pores,dullness"` // Préoccupations ciblées (CSV : séparées par |)
+	VolumeML    int      `json:"volumeML" example:"50"`                                                                            // Contenance en ml (0 = non renseignée)
+	PAOMonths   int      `json:"paoMonths" example:"12"`                                                                           // Période après ouverture en mois (0 = non renseignée)
+}
+
+// ImportRowResult DTO pour le résultat d'une ligne d'import
+// @Description Résultat du traitement d'une ligne d'import
+type ImportRowResult struct {
+	Row       int    `json:"row" example:"2"`                                                    // Numéro de ligne (CSV) ou position (JSON, commence à 1)
+	Name      string `json:"name" example:"Crème hydratante"`                                    // Nom du produit
+	SKU       string `json:"sku,omitempty" example:"CREME-HYD-50"`                               // Référence interne
+	Status    string `json:"status" example:"created" enums:"created,updated,skipped,error"`     // Résultat de la ligne
+	Reason    string `json:"reason,omitempty" example:"le prix doit être supérieur à 0"`         // Raison (pour skipped et error)
+	ProductID string `json:"productID,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // ID du produit mis à jour ou créé
+}
+
+// ImportProductsResponse DTO pour le rapport d'import
+// @Description Rapport détaillé d'un import de produits
+type ImportProductsResponse struct {
+	DryRun            bool              `json:"dryRun" example:"false"` // Simulation uniquement (aucune écriture)
+	Applied           bool              `json:"applied" example:"true"` // Les modifications ont-elles été enregistrées ?
+	Created           int               `json:"created" example:"3"`    // Nombre de produits créés
+	Updated           int               `json:"updated" example:"2"`    // Nombre de produits mis à jour
+	Skipped           int               `json:"skipped" example:"1"`    // Nombre de lignes ignorées
+	Errors            int               `json:"errors" example:"0"`     // Nombre de lignes en erreur
+	CategoriesCreated []string          `json:"categoriesCreated"`      // Catégories créées pendant l'import
+	Rows              []ImportRowResult `json:"rows"`                   // Résultat ligne par ligne
+}
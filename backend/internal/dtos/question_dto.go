@@ -0,0 +1,91 @@
+package dtos
+
+import "time"
+
+// CreateQuestionRequest DTO pour poser une question sur un produit
+// @Description Question d'un client sur un produit
+type CreateQuestionRequest struct {
+	Body string `json:"body" example:"Ce produit convient-il pendant la grossesse ?" binding:"required"` // Texte de la question (1000 caractères max)
+}
+
+// CreateAnswerRequest DTO pour répondre à une question
+// @Description Réponse à une question, par un administrateur ou un client ayant reçu le produit
+type CreateAnswerRequest struct {
+	Body string `json:"body" example:"Oui, la formule ne contient ni rétinol ni huiles essentielles." binding:"required"` // Texte de la réponse (2000 caractères max)
+}
+
+// ModerateQuestionRequest DTO pour refuser ou supprimer une question ou une réponse
+// @Description Motif de la décision de modération
+type ModerateQuestionRequest struct {
+	Reason string `json:"reason" example:"Hors sujet" binding:"required"` // Motif (obligatoire pour un refus ou une suppression)
+}
+
+// QuestionResponse DTO pour une question et ses réponses
+// @Description Question d'un client avec ses réponses
+type QuestionResponse struct {
+	ID               string           `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`            // UUID de la question
+	Body             string           `json:"body" example:"Ce produit convient-il pendant la grossesse ?"` // Texte de la question
+	Status           string           `json:"status" example:"APPROVED" enums:"PENDING,APPROVED,REJECTED"`  // Statut de modération
+	ModerationReason *string          `json:"moderationReason,omitempty" example:"Hors sujet"`              // Motif du refus (si refusée)
+	ModeratedAt      *time.Time       `json:"moderatedAt,omitempty" example:"2024-01-02T00:00:00Z"`         // Date de la dernière décision de modération
+	UpvoteCount      int              `json:"upvoteCount" example:"4"`                                      // Nombre de votes
+	Upvoted          bool             `json:"upvoted" example:"false"`                                      // L'utilisateur connecté a voté
+	Answers          []AnswerResponse `json:"answers"`                                                      // Réponses (publiées uniquement, sauf pour les administrateurs)
+	AnswerCount      int              `json:"answerCount" example:"1"`                                      // Nombre de réponses retournées
+	UserID           string           `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`        // ID de l'auteur
+	UserEmail        string           `json:"userEmail" example:"u***@example.com"`                         // Email masqué de l'auteur
+	ProductID        string           `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000"`     // ID du produit
+	CreatedAt        time.Time        `json:"createdAt" example:"2024-01-01T00:00:00Z"`                     // Date de création
+	UpdatedAt        time.Time        `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                     // Date de mise à jour
+}
+
+// AnswerResponse DTO pour une réponse à une question
+// @Description Réponse à une question
+type AnswerResponse struct {
+	ID               string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                             // UUID de la réponse
+	Body             string     `json:"body" example:"Oui, la formule ne contient ni rétinol ni huiles essentielles."` // Texte de la réponse
+	Status           string     `json:"status" example:"APPROVED" enums:"PENDING,APPROVED,REJECTED"`                   // Statut de modération
+	ModerationReason *string    `json:"moderationReason,omitempty" example:"Hors sujet"`                               // Motif du refus (si refusée)
+	ModeratedAt      *time.Time `json:"moderatedAt,omitempty" example:"2024-01-02T00:00:00Z"`                          // Date de la dernière décision de modération
+	Official         bool       `json:"official" example:"true"`                                                       // Réponse de la marque (administrateur)
+	VerifiedPurchase bool       `json:"verifiedPurchase" example:"false"`                                              // L'auteur a reçu le produit
+	UpvoteCount      int        `json:"upvoteCount" example:"2"`                                                       // Nombre de votes
+	Upvoted          bool       `json:"upvoted" example:"false"`                                                       // L'utilisateur connecté a voté
+	UserID           string     `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`                         // ID de l'auteur
+	UserEmail        string     `json:"userEmail" example:"u***@example.com"`                                          // Email masqué de l'auteur
+	QuestionID       string     `json:"questionID" example:"550e8400-e29b-41d4-a716-446655440000"`                     // ID de la question
+	CreatedAt        time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`                                      // Date de création
+	UpdatedAt        time.Time  `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                                      // Date de mise à jour
+}
+
+// QuestionFilters regroupe les filtres, le tri et la pagination de GET /products/{productID}/questions
+type QuestionFilters struct {
+	Answered *bool  // Uniquement les questions avec (true) ou sans (false) réponse publiée ; toutes si nil
+	Sort     string // Tri : recent (défaut), popular
+	Page     int    // Numéro de page (défaut: 1)
+	Limit    int    // Nombre d'éléments par page (défaut: 10, max: 50)
+}
+
+// PaginatedQuestionsResponse DTO pour une liste paginée de questions
+// @Description Liste paginée de questions avec leurs réponses
+type PaginatedQuestionsResponse struct {
+	Questions  []QuestionResponse `json:"questions"`  // Liste des questions
+	Total      int                `json:"total"`      // Nombre total de questions
+	Page       int                `json:"page"`       // Page actuelle
+	Limit      int                `json:"limit"`      // Nombre d'éléments par page
+	TotalPages int                `json:"totalPages"` // Nombre total de pages
+	HasNext    bool               `json:"hasNext"`    // Y a-t-il une page suivante ?
+	HasPrev    bool               `json:"hasPrev"`    // Y a-t-il une page précédente ?
+}
+
+// PaginatedAnswersResponse DTO pour une liste paginée de réponses
+// @Description Liste paginée de réponses (file de modération)
+type PaginatedAnswersResponse struct {
+	Answers    []AnswerResponse `json:"answers"`    // Liste des réponses
+	Total      int              `json:"total"`      // Nombre total de réponses
+	Page       int              `json:"page"`       // Page actuelle
+	Limit      int              `json:"limit"`      // Nombre d'éléments par page
+	TotalPages int              `json:"totalPages"` // Nombre total de pages
+	HasNext    bool             `json:"hasNext"`    // Y a-t-il une page suivante ?
+	HasPrev    bool             `json:"hasPrev"`    // Y a-t-il une page précédente ?
+}
@@ -0,0 +1,59 @@
+package dtos
+
+import "time"
+
+// CreateReturnRequest DTO pour demander le retour d'articles d'une commande livrée
+// @Description Articles à retourner et motif du retour
+type CreateReturnRequest struct {
+	Reason string              `json:"reason" example:"Produit ne convenant pas à ma peau" binding:"required"` // Motif du retour
+	Items  []ReturnItemRequest `json:"items" binding:"required,min=1"`                                         // Articles retournés (minimum 1)
+}
+
+// ReturnItemRequest DTO pour un article à retourner
+// @Description Ligne de commande et quantité retournée
+type ReturnItemRequest struct {
+	OrderItemID string `json:"orderItemID" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"` // ID de la ligne de commande
+	Quantity    int    `json:"quantity" example:"1" binding:"required,min=1"`                                 // Quantité retournée
+}
+
+// ReviewReturnRequest DTO pour approuver ou refuser un retour
+// @Description Note de l'administrateur (optionnelle)
+type ReviewReturnRequest struct {
+	Note string `json:"note,omitempty" example:"Retour accepté, étiquette envoyée par e-mail"` // Note visible par le client
+}
+
+// ReceiveReturnRequest DTO pour réceptionner un retour
+// @Description Montant remboursé à la réception (absent = montant payé pour les articles retournés)
+type ReceiveReturnRequest struct {
+	RefundAmount *float64 `json:"refundAmount,omitempty" example:"24.99"`                           // Montant à rembourser (0 = aucun remboursement)
+	Note         string   `json:"note,omitempty" example:"Un flacon ouvert, remboursement partiel"` // Note visible par le client
+}
+
+// ReturnResponse DTO pour une demande de retour
+// @Description Demande de retour avec ses articles et son remboursement
+type ReturnResponse struct {
+	ID               string               `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                          // UUID du retour
+	OrderID          string               `json:"orderID" example:"550e8400-e29b-41d4-a716-446655440000"`                     // ID de la commande
+	UserID           string               `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`                      // ID du client
+	Status           string               `json:"status" example:"REQUESTED" enums:"REQUESTED,APPROVED,REJECTED,RECEIVED"`    // Statut du retour
+	Reason           string               `json:"reason" example:"Produit ne convenant pas à ma peau"`                        // Motif du retour
+	AdminNote        string               `json:"adminNote,omitempty" example:"Retour accepté, étiquette envoyée par e-mail"` // Note de l'administrateur
+	Items            []ReturnItemResponse `json:"items"`                                                                      // Articles retournés
+	RefundableAmount float64              `json:"refundableAmount" example:"24.99"`                                           // Montant payé pour les articles retournés
+	RefundAmount     float64              `json:"refundAmount" example:"24.99"`                                               // Montant remboursé à la réception
+	ReceivedAt       *time.Time           `json:"receivedAt,omitempty" example:"2024-01-01T00:00:00Z"`                        // Date de réception
+	CreatedAt        time.Time            `json:"createdAt" example:"2024-01-01T00:00:00Z"`                                   // Date de la demande
+	UpdatedAt        time.Time            `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                                   // Date de mise à jour
+}
+
+// ReturnItemResponse DTO pour un article retourné
+// @Description Article retourné et montant correspondant
+type ReturnItemResponse struct {
+	ID           string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`          // UUID de la ligne de retour
+	OrderItemID  string  `json:"orderItemID" example:"550e8400-e29b-41d4-a716-446655440000"` // ID de la ligne de commande
+	ProductID    string  `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000"`   // ID du produit
+	ProductName  string  `json:"productName" example:"Crème hydratante"`                     // Nom du produit
+	VariantLabel string  `json:"variantLabel,omitempty" example:"50 ml"`                     // Variante commandée
+	Quantity     int     `json:"quantity" example:"1"`                                       // Quantité retournée
+	Amount       float64 `json:"amount" example:"24.99"`                                     // Montant TTC payé pour ces articles
+}
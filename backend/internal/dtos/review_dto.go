@@ -20,20 +20,87 @@ type UpdateReviewRequest struct {
 // ReviewResponse DTO pour la réponse d'un avis
 // @Description Informations complètes d'un avis
 type ReviewResponse struct {
-	ID        string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`        // UUID de l'avis
-	Rating    int       `json:"rating" example:"5"`                                       // Note de 1 à 5
-	Comment   *string   `json:"comment,omitempty" example:"Excellent produit !"`          // Commentaire (optionnel)
-	UserID    string    `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`    // ID de l'utilisateur
-	UserEmail string    `json:"userEmail" example:"user@example.com"`                     // Email de l'utilisateur (pour affichage)
-	ProductID string    `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000"` // ID du produit
-	CreatedAt time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`                 // Date de création
-	UpdatedAt time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                 // Date de mise à jour
+	ID               string               `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`           // UUID de l'avis
+	Rating           int                  `json:"rating" example:"5"`                                          // Note de 1 à 5
+	Comment          *string              `json:"comment,omitempty" example:"Excellent produit !"`             // Commentaire (optionnel)
+	Status           string               `json:"status" example:"APPROVED" enums:"PENDING,APPROVED,REJECTED"` // Statut de modération
+	ModerationReason *string              `json:"moderationReason,omitempty" example:"Propos injurieux"`       // Motif du refus (si refusé)
+	ModeratedAt      *time.Time           `json:"moderatedAt,omitempty" example:"2024-01-02T00:00:00Z"`        // Date de la dernière décision de modération
+	VerifiedPurchase bool                 `json:"verifiedPurchase" example:"true"`                             // Achat vérifié : l'auteur a reçu le produit
+	HelpfulCount     int                  `json:"helpfulCount" example:"3"`                                    // Nombre de votes « utile »
+	VotedHelpful     bool                 `json:"votedHelpful" example:"false"`                                // L'utilisateur connecté a voté « utile »
+	Reply            *ReviewReplyResponse `json:"reply,omitempty"`                                             // Réponse publique de la marque (si elle existe)
+	UserID           string               `json:"userID" example:"550e8400-e29b-41d4-a716-446655440000"`       // ID de l'utilisateur
+	UserEmail        string               `json:"userEmail" example:"user@example.com"`                        // Email de l'utilisateur (pour affichage)
+	ProductID        string               `json:"productID" example:"550e8400-e29b-41d4-a716-446655440000"`    // ID du produit
+	CreatedAt        time.Time            `json:"createdAt" example:"2024-01-01T00:00:00Z"`                    // Date de création
+	UpdatedAt        time.Time            `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                    // Date de mise à jour
 }
 
 // ProductReviewsResponse DTO pour la réponse avec statistiques
 // @Description Liste des avis avec statistiques (moyenne, total)
 type ProductReviewsResponse struct {
-	Reviews       []ReviewResponse `json:"reviews"`                     // Liste des avis
+	Reviews       []ReviewResponse `json:"reviews"`                     // Page d'avis
 	AverageRating float64          `json:"averageRating" example:"4.5"` // Note moyenne (0-5)
 	TotalReviews  int              `json:"totalReviews" example:"10"`   // Nombre total d'avis
+	Histogram     RatingHistogram  `json:"histogram"`                   // Répartition des avis par note
+	Total         int              `json:"total" example:"7"`           // Nombre d'avis correspondant aux filtres de note
+	Page          int              `json:"page" example:"1"`            // Page actuelle
+	Limit         int              `json:"limit" example:"10"`          // Nombre d'éléments par page
+	TotalPages    int              `json:"totalPages" example:"1"`      // Nombre total de pages
+	HasNext       bool             `json:"hasNext"`                     // Y a-t-il une page suivante ?
+	HasPrev       bool             `json:"hasPrev"`                     // Y a-t-il une page précédente ?
+}
+
+// ReviewFilters regroupe les filtres, le tri et la pagination de GET /products/{productID}/reviews
+type ReviewFilters struct {
+	VerifiedOnly bool   // Uniquement les achats vérifiés (statistiques comprises)
+	Ratings      []int  // Notes retenues (toutes si vide)
+	MinRating    int    // Note minimale (0 : aucune)
+	Sort         string // Tri : recent (défaut), helpful, rating_high, rating_low
+	Page         int    // Numéro de page (défaut: 1)
+	Limit        int    // Nombre d'éléments par page (défaut: 10, max: 50)
+}
+
+// RatingHistogram DTO pour la répartition des avis par note
+// @Description Nombre d'avis publiés pour chaque note de 1 à 5
+type RatingHistogram struct {
+	One   int `json:"1" example:"0"` // Avis notés 1
+	Two   int `json:"2" example:"1"` // Avis notés 2
+	Three int `json:"3" example:"1"` // Avis notés 3
+	Four  int `json:"4" example:"3"` // Avis notés 4
+	Five  int `json:"5" example:"7"` // Avis notés 5
+}
+
+// PaginatedReviewsResponse DTO pour une liste paginée d'avis
+// @Description Liste paginée d'avis (file de modération)
+type PaginatedReviewsResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`    // Liste des avis
+	Total      int              `json:"total"`      // Nombre total d'avis
+	Page       int              `json:"page"`       // Page actuelle
+	Limit      int              `json:"limit"`      // Nombre d'éléments par page
+	TotalPages int              `json:"totalPages"` // Nombre total de pages
+	HasNext    bool             `json:"hasNext"`    // Y a-t-il une page suivante ?
+	HasPrev    bool             `json:"hasPrev"`    // Y a-t-il une page précédente ?
+}
+
+// ModerateReviewRequest DTO pour refuser ou supprimer un avis
+// @Description Motif de la décision de modération
+type ModerateReviewRequest struct {
+	Reason string `json:"reason" example:"Propos injurieux" binding:"required"` // Motif (obligatoire pour un refus ou une suppression)
+}
+
+// ReviewReplyRequest DTO pour répondre à un avis
+// @Description Réponse publique de la marque à un avis
+type ReviewReplyRequest struct {
+	Body string `json:"body" example:"Nous sommes désolés pour l'emballage, contactez-nous à service@porelo.fr" binding:"required"` // Texte de la réponse (2000 caractères max)
+}
+
+// ReviewReplyResponse DTO pour la réponse de la marque à un avis
+// @Description Réponse publique de la marque, affichée sous l'avis
+type ReviewReplyResponse struct {
+	ID        string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                                       // UUID de la réponse
+	Body      string    `json:"body" example:"Nous sommes désolés pour l'emballage, contactez-nous à service@porelo.fr"` // Texte de la réponse
+	CreatedAt time.Time `json:"createdAt" example:"2024-01-02T00:00:00Z"`                                                // Date de la réponse
+	UpdatedAt time.Time `json:"updatedAt" example:"2024-01-02T00:00:00Z"`                                                // Date de la dernière modification
 }
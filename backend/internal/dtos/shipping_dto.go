@@ -0,0 +1,32 @@
+package dtos
+
+import "time"
+
+// ShippingMethodRequest DTO pour la création/mise à jour d'un mode de livraison
+// @Description Mode de livraison et sa règle de prix (forfait ou au poids, gratuit au-delà d'un seuil)
+type ShippingMethodRequest struct {
+	Name          string   `json:"name" example:"Colissimo" binding:"required"`                 // Nom affiché
+	Description   string   `json:"description,omitempty" example:"Livraison à domicile en 48h"` // Description (optionnelle)
+	Type          string   `json:"type" example:"FLAT" enums:"FLAT,WEIGHT"`                     // Règle de prix (défaut: FLAT)
+	Price         float64  `json:"price" example:"4.90"`                                        // Prix fixe (FLAT) ou prix de base (WEIGHT), TTC
+	PricePerKg    float64  `json:"pricePerKg,omitempty" example:"1.50"`                         // Prix par kg entamé (WEIGHT)
+	FreeAbove     *float64 `json:"freeAbove,omitempty" example:"49"`                            // Livraison offerte à partir de ce montant d'articles (optionnel)
+	EstimatedDays int      `json:"estimatedDays,omitempty" example:"2"`                         // Délai indicatif en jours (0 = non renseigné)
+	Active        *bool    `json:"active,omitempty" example:"true"`                             // Proposé aux clients (défaut: true)
+}
+
+// ShippingMethodResponse DTO pour la réponse
+// @Description Mode de livraison
+type ShippingMethodResponse struct {
+	ID            string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`           // UUID du mode de livraison
+	Name          string    `json:"name" example:"Colissimo"`                                    // Nom affiché
+	Description   string    `json:"description,omitempty" example:"Livraison à domicile en 48h"` // Description
+	Type          string    `json:"type" example:"FLAT" enums:"FLAT,WEIGHT"`                     // Règle de prix
+	Price         float64   `json:"price" example:"4.90"`                                        // Prix fixe ou prix de base, TTC
+	PricePerKg    float64   `json:"pricePerKg" example:"0"`                                      // Prix par kg entamé
+	FreeAbove     *float64  `json:"freeAbove,omitempty" example:"49"`                            // Seuil de gratuité
+	EstimatedDays *int      `json:"estimatedDays,omitempty" example:"2"`                         // Délai indicatif en jours
+	Active        bool      `json:"active" example:"true"`                                       // Proposé aux clients
+	CreatedAt     time.Time `json:"createdAt" example:"2024-01-01T00:00:00Z"`                    // Date de création
+	UpdatedAt     time.Time `json:"updatedAt" example:"2024-01-01T00:00:00Z"`                    // Date de mise à jour
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+// SkinProfileRequest DTO pour enregistrer le profil de peau de l'utilisateur connecté
+// @Description Profil de peau utilisé pour personnaliser les recommandations
+type SkinProfileRequest struct {
+	SkinType           string   `json:"skinType" example:"sensitive" enums:"dry,oily,combination,sensitive"` // Type de peau (optionnel)
+	Concerns           []string `json:"concerns" example:"redness"`                                          // Préoccupations (acne, aging, ...)
+	AvoidedIngredients []string `json:"avoidedIngredients" example:"PARFUM,ALCOHOL DENAT."`                  // Ingrédients INCI à éviter
+}
+
+// SkinProfileResponse DTO pour la réponse du profil de peau
+// @Description Profil de peau de l'utilisateur
+type SkinProfileResponse struct {
+	SkinType           string     `json:"skinType,omitempty" example:"sensitive"`             // Type de peau
+	Concerns           []string   `json:"concerns" example:"redness"`                         // Préoccupations
+	AvoidedIngredients []string   `json:"avoidedIngredients" example:"PARFUM"`                // Ingrédients INCI à éviter
+	UpdatedAt          *time.Time `json:"updatedAt,omitempty" example:"2024-01-01T00:00:00Z"` // Date de mise à jour (absente si le profil n'a jamais été renseigné)
+}
+
+// RecommendedProductResponse DTO pour un produit recommandé
+// @Description Produit recommandé avec son score et les raisons de la recommandation
+type RecommendedProductResponse struct {
+	Product ProductResponse `json:"product"`                                       // Produit recommandé
+	Score   float64         `json:"score" example:"7.5"`                           // Score de pertinence (plus élevé = plus pertinent)
+	Reasons []string        `json:"reasons" example:"adapté à votre type de peau"` // Raisons de la recommandation
+}
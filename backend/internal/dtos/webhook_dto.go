@@ -0,0 +1,53 @@
+package dtos
+
+import "time"
+
+// WebhookEndpointRequest DTO pour la création/mise à jour d'une destination de webhooks
+// @Description Destination de webhooks : URL appelée en POST pour chaque événement des types choisis
+type WebhookEndpointRequest struct {
+	URL         string   `json:"url" example:"https://erp.example.com/hooks/porelo" binding:"required"`  // URL http(s) du destinataire
+	Description string   `json:"description,omitempty" example:"Synchronisation ERP"`                    // Description (optionnelle)
+	Events      []string `json:"events" example:"order.created,order.status_changed" binding:"required"` // Types d'événements envoyés ("*" = tous)
+	Active      *bool    `json:"active,omitempty" example:"true"`                                        // Envois actifs (défaut: true) ; réactiver remet à zéro le compteur d'échecs
+}
+
+// WebhookEndpointResponse DTO pour la réponse (sans la clé de signature)
+// @Description Destination de webhooks. Chaque envoi est signé : en-tête Webhook-Signature "t=<timestamp>,v1=<hex HMAC-SHA256(secret, "<timestamp>.<corps>")>"
+type WebhookEndpointResponse struct {
+	ID                  string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`   // UUID de la destination
+	URL                 string     `json:"url" example:"https://erp.example.com/hooks/porelo"`  // URL du destinataire
+	Description         string     `json:"description,omitempty" example:"Synchronisation ERP"` // Description
+	Events              []string   `json:"events" example:"order.created,order.status_changed"` // Types d'événements envoyés
+	Active              bool       `json:"active" example:"true"`                               // Envois actifs
+	ConsecutiveFailures int        `json:"consecutiveFailures" example:"0"`                     // Tentatives échouées d'affilée
+	DisabledAt          *time.Time `json:"disabledAt,omitempty" example:"2024-01-01T00:00:00Z"` // Date de désactivation automatique
+	CreatedAt           time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`            // Date de création
+	UpdatedAt           time.Time  `json:"updatedAt" example:"2024-01-01T00:00:00Z"`            // Date de mise à jour
+}
+
+// WebhookEndpointSecretResponse DTO pour la création d'une destination et la rotation de sa clé
+// @Description Destination de webhooks avec sa clé de signature, retournée uniquement à la création et à la rotation
+type WebhookEndpointSecretResponse struct {
+	WebhookEndpointResponse
+	Secret string `json:"secret" example:"whsec_3f1c..."` // Clé de signature HMAC-SHA256, à conserver : elle n'est plus affichée ensuite
+}
+
+// WebhookDeliveryResponse DTO pour le journal des livraisons
+// @Description Livraison d'un événement à une destination, avec le résultat de la dernière tentative
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                     // UUID de la livraison (en-tête Webhook-Delivery)
+	EndpointID     string     `json:"endpointID" example:"550e8400-e29b-41d4-a716-446655440000"`             // UUID de la destination
+	EventID        string     `json:"eventID" example:"550e8400-e29b-41d4-a716-446655440000"`                // UUID de l'événement (identique pour une relivraison)
+	EventType      string     `json:"eventType" example:"order.created"`                                     // Type de l'événement
+	Status         string     `json:"status" example:"SUCCEEDED" enums:"PENDING,SUCCEEDED,FAILED"`           // Statut de la livraison
+	Attempts       int        `json:"attempts" example:"1"`                                                  // Nombre de tentatives
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty" example:"2024-01-01T00:00:00Z"`                // Prochaine tentative (PENDING)
+	LastAttemptAt  *time.Time `json:"lastAttemptAt,omitempty" example:"2024-01-01T00:00:00Z"`                // Dernière tentative
+	ResponseStatus *int       `json:"responseStatus,omitempty" example:"200"`                                // Code HTTP de la dernière réponse
+	ResponseBody   string     `json:"responseBody,omitempty" example:"ok"`                                   // Début de la dernière réponse
+	LastError      string     `json:"lastError,omitempty" example:"réponse HTTP 500"`                        // Erreur de la dernière tentative
+	DurationMs     *int       `json:"durationMs,omitempty" example:"120"`                                    // Durée de la dernière tentative
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty" example:"2024-01-01T00:00:00Z"`                  // Date de réception confirmée
+	RedeliveryOf   string     `json:"redeliveryOf,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // Livraison d'origine (relivraison manuelle)
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`                              // Date de création
+}
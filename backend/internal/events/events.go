@@ -0,0 +1,175 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Types des événements de domaine
+const (
+	OrderCreated        = "order.created"         // Commande créée (statut PENDING)
+	OrderStatusChanged  = "order.status_changed"  // Changement effectif du statut d'une commande
+	OrderRefunded       = "order.refunded"        // Remboursement enregistré sur une commande (paiement ou retour)
+	ProductStockChanged = "product.stock_changed" // Stock d'une variante modifié (commande, retour, admin, import)
+	ReviewCreated       = "review.created"        // Nouvel avis sur un produit
+	ReviewReplied       = "review.replied"        // Réponse publique de la marque à un avis
+	UserRegistered      = "user.registered"       // Nouveau compte utilisateur
+)
+
+// Types liste tous les types d'événements publiés
+var Types = []string{OrderCreated, OrderStatusChanged, OrderRefunded, ProductStockChanged, ReviewCreated, ReviewReplied, UserRegistered}
+
+// Raisons d'un changement de stock (ProductStockChangedPayload.Reason)
+const (
+	StockReasonOrder  = "order"  // Décrément à la commande
+	StockReasonReturn = "return" // Remise en stock d'un retour reçu
+	StockReasonAdmin  = "admin"  // Modification d'un produit ou d'une variante par un admin
+	StockReasonImport = "import" // Import de catalogue
+)
+
+// Event est un événement lu depuis l'outbox
+type Event struct {
+	ID          string          // Identifiant unique (déduplication côté abonné)
+	Type        string          // Un des types ci-dessus
+	AggregateID string          // Identifiant de l'entité concernée (commande, produit, avis, utilisateur)
+	Payload     json.RawMessage // Charge utile JSON (un des *Payload ci-dessous)
+	OccurredAt  time.Time       // Date du changement métier
+	Attempt     int             // Numéro de la tentative de distribution (1 pour la première)
+	Delivered   []string        // Abonnés ayant déjà traité l'événement lors d'une tentative précédente
+}
+
+// Decode décode la charge utile de l'événement dans v
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("événement %s (%s) invalide: %w", e.ID, e.Type, err)
+	}
+	return nil
+}
+
+// OrderCreatedPayload est la charge utile de OrderCreated
+type OrderCreatedPayload struct {
+	OrderID     string  `json:"orderID"`
+	UserID      string  `json:"userID"`
+	TotalAmount float64 `json:"totalAmount"`
+}
+
+// OrderStatusChangedPayload est la charge utile de OrderStatusChanged
+type OrderStatusChangedPayload struct {
+	OrderID string `json:"orderID"`
+	UserID  string `json:"userID"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// OrderRefundedPayload est la charge utile de OrderRefunded
+type OrderRefundedPayload struct {
+	OrderID  string  `json:"orderID"`
+	RefundID string  `json:"refundID"`
+	Amount   float64 `json:"amount"`
+}
+
+// ProductStockChangedPayload est la charge utile de ProductStockChanged
+// Delta est la variation connue du stock de la variante (0 quand le stock a été fixé à une valeur absolue) ;
+// les abonnés qui ont besoin du stock courant le relisent.
+type ProductStockChangedPayload struct {
+	ProductID string `json:"productID"`
+	VariantID string `json:"variantID,omitempty"`
+	Delta     int    `json:"delta,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// ReviewCreatedPayload est la charge utile de ReviewCreated
+type ReviewCreatedPayload struct {
+	ReviewID  string `json:"reviewID"`
+	ProductID string `json:"productID"`
+	UserID    string `json:"userID"`
+	Rating    int    `json:"rating"`
+}
+
+// ReviewRepliedPayload est la charge utile de ReviewReplied
+type ReviewRepliedPayload struct {
+	ReviewID  string `json:"reviewID"`
+	ReplyID   string `json:"replyID"`
+	ProductID string `json:"productID"`
+	UserID    string `json:"userID"` // Auteur de l'avis
+}
+
+// UserRegisteredPayload est la charge utile de UserRegistered
+type UserRegisteredPayload struct {
+	UserID string `json:"userID"`
+	Email  string `json:"email"`
+}
+
+// Handler traite un événement. Une erreur provoque une nouvelle distribution plus tard :
+// la livraison étant « au moins une fois », un abonné doit tolérer de recevoir deux fois le même événement.
+type Handler func(ctx context.Context, event Event) error
+
+// subscriber est un abonné nommé du bus
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// Bus distribue les événements aux abonnés internes, indexés par type
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]subscriber
+}
+
+// NewBus crée un bus sans abonné
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[string][]subscriber)}
+}
+
+// Subscribe abonne handler aux événements du type donné, sous le nom name.
+// Le nom identifie l'abonné d'une tentative de distribution à l'autre : un abonné qui a réussi
+// n'est pas rappelé quand l'événement est redistribué à cause de l'échec d'un autre abonné.
+// Il doit être unique pour un type d'événement et ne pas changer d'une version à l'autre.
+func (b *Bus) Subscribe(eventType, name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range b.subscribers[eventType] {
+		if s.name == name {
+			panic(fmt.Sprintf("abonné %s déjà enregistré pour %s", name, eventType))
+		}
+	}
+	b.subscribers[eventType] = append(b.subscribers[eventType], subscriber{name: name, handler: handler})
+}
+
+// Publish appelle les abonnés du type de l'événement absents de event.Delivered, dans l'ordre d'abonnement.
+// Tous ces abonnés sont appelés même si l'un échoue ; les erreurs (et panics) sont regroupées.
+// Retourne les noms des abonnés qui ont réussi lors de cet appel.
+func (b *Bus) Publish(ctx context.Context, event Event) ([]string, error) {
+	b.mu.RLock()
+	subscribers := b.subscribers[event.Type]
+	b.mu.RUnlock()
+
+	var succeeded []string
+	var errs []error
+	for _, s := range subscribers {
+		if slices.Contains(event.Delivered, s.name) {
+			continue
+		}
+		if err := call(ctx, s.handler, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			continue
+		}
+		succeeded = append(succeeded, s.name)
+	}
+	return succeeded, errors.Join(errs...)
+}
+
+// call appelle un abonné en convertissant une panic en erreur
+func call(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic dans un abonné à %s: %v", event.Type, r)
+		}
+	}()
+	return handler(ctx, event)
+}
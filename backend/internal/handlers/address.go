@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetMyAddressesHandler gère la récupération du carnet d'adresses de l'utilisateur connecté
+// @Summary      Mes adresses
+// @Description  Liste les adresses de livraison de l'utilisateur connecté, l'adresse par défaut en premier
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dtos.AddressResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /users/me/addresses [get]
+func GetMyAddressesHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		addresses, err := services.GetUserAddresses(client, claims.UserID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des adresses")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, addresses)
+	}
+}
+
+// CreateMyAddressHandler gère l'ajout d'une adresse au carnet de l'utilisateur connecté
+// @Summary      Ajouter une adresse
+// @Description  Ajoute une adresse de livraison. La première adresse devient l'adresse par défaut ; avec isDefault=true, elle remplace l'adresse par défaut actuelle.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dtos.AddressRequest  true  "Adresse"
+// @Success      201      {object}  dtos.AddressResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /users/me/addresses [post]
+func CreateMyAddressHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.AddressRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		address, err := services.CreateAddress(client, claims.UserID, req)
+		if err != nil {
+			respondAddressError(w, err, "Erreur lors de la création de l'adresse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, address)
+	}
+}
+
+// UpdateMyAddressHandler gère la mise à jour d'une adresse de l'utilisateur connecté
+// @Summary      Mettre à jour une adresse
+// @Description  Met à jour une adresse du carnet. Les commandes passées conservent l'adresse d'origine. Pour changer l'adresse par défaut, passez isDefault=true sur la nouvelle.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string               true  "ID de l'adresse"
+// @Param        request  body      dtos.AddressRequest  true  "Nouvelle adresse"
+// @Success      200      {object}  dtos.AddressResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /users/me/addresses/{id} [put]
+func UpdateMyAddressHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.AddressRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		address, err := services.UpdateAddress(client, claims.UserID, chi.URLParam(r, "id"), req)
+		if err != nil {
+			respondAddressError(w, err, "Erreur lors de la mise à jour de l'adresse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, address)
+	}
+}
+
+// DeleteMyAddressHandler gère la suppression d'une adresse de l'utilisateur connecté
+// @Summary      Supprimer une adresse
+// @Description  Supprime une adresse du carnet. Si c'était l'adresse par défaut, la plus ancienne des adresses restantes la remplace.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID de l'adresse"
+// @Success      204  "No Content"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /users/me/addresses/{id} [delete]
+func DeleteMyAddressHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		if err := services.DeleteAddress(client, claims.UserID, chi.URLParam(r, "id")); err != nil {
+			respondAddressError(w, err, "Erreur lors de la suppression de l'adresse")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// respondAddressError associe les erreurs du service des adresses aux codes HTTP
+func respondAddressError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "adresse non trouvée":
+		utils.RespondError(w, http.StatusNotFound, err.Error())
+	case err.Error() == "le destinataire est requis",
+		err.Error() == "l'adresse est requise",
+		err.Error() == "le code postal est requis",
+		err.Error() == "la ville est requise",
+		strings.HasPrefix(err.Error(), "code pays invalide"):
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
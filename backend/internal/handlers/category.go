@@ -3,6 +3,8 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"api/internal/db"
 	"api/internal/docs"
@@ -16,6 +18,27 @@ import (
 // Utilisation des types docs pour Swagger
 var _ = docs.ErrorResponse{}
 
+// GetCategoryTreeHandler gère la récupération de l'arborescence des catégories (public)
+// @Summary      Arborescence des catégories
+// @Description  Retourne les catégories racines et leurs sous-catégories, triées par ordre d'affichage puis par nom.
+// @Description  productCount inclut les produits des sous-catégories ; le slug s'utilise comme filtre ?category= de GET /products.
+// @Tags         Categories
+// @Produce      json
+// @Success      200  {array}   dtos.CategoryTreeResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /categories [get]
+func GetCategoryTreeHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tree, err := services.GetCategoryTree(client)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des catégories")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, tree)
+	}
+}
+
 // GetAllCategoriesHandler gère la récupération de toutes les catégories (admin only)
 // @Summary      Liste toutes les catégories
 // @Description  Récupère la liste de toutes les catégories (admin uniquement)
@@ -79,7 +102,8 @@ func GetCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // CreateCategoryHandler gère la création d'une catégorie (admin only)
 // @Summary      Créer une catégorie
-// @Description  Crée une nouvelle catégorie (admin uniquement)
+// @Description  Crée une nouvelle catégorie (admin uniquement). taxRate définit un taux de TVA propre aux produits de la catégorie (sinon VAT_DEFAULT_RATE).
+// @Description  parentID rattache la catégorie à une catégorie parente ; sans slug, il est dérivé du nom (suffixé de -2, -3... s'il est déjà pris).
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
@@ -89,7 +113,7 @@ func GetCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 // @Failure      400      {object}  docs.ErrorResponse
 // @Failure      401      {object}  docs.ErrorResponse
 // @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
-// @Failure      409      {object}  docs.ErrorResponse  "Catégorie avec ce nom existe déjà"
+// @Failure      409      {object}  docs.ErrorResponse  "Catégorie avec ce nom ou ce slug existe déjà"
 // @Failure      500      {object}  docs.ErrorResponse
 // @Router       /admin/categories [post]
 func CreateCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
@@ -109,10 +133,18 @@ func CreateCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 
 		category, err := services.CreateCategory(client, req)
 		if err != nil {
-			if err.Error() == "une catégorie avec ce nom existe déjà" {
+			if err.Error() == "une catégorie avec ce nom existe déjà" || err.Error() == "une catégorie avec ce slug existe déjà" {
 				utils.RespondError(w, http.StatusConflict, err.Error())
 				return
 			}
+			if err.Error() == "le taux de TVA doit être compris entre 0 et 100" {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if isCategoryInputError(err) {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
 			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la création de la catégorie")
 			return
 		}
@@ -123,7 +155,8 @@ func CreateCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // UpdateCategoryHandler gère la mise à jour d'une catégorie (admin only)
 // @Summary      Mettre à jour une catégorie
-// @Description  Met à jour une catégorie existante (admin uniquement)
+// @Description  Met à jour une catégorie existante (admin uniquement). Sans taxRate, la catégorie revient au taux de TVA par défaut ; sans parentID, elle devient une catégorie racine ; sans slug, le slug existant est conservé.
+// @Description  Une catégorie ne peut pas être déplacée dans l'une de ses sous-catégories.
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
@@ -164,10 +197,18 @@ func UpdateCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 				utils.RespondError(w, http.StatusNotFound, err.Error())
 				return
 			}
-			if err.Error() == "une catégorie avec ce nom existe déjà" {
+			if err.Error() == "une catégorie avec ce nom existe déjà" || err.Error() == "une catégorie avec ce slug existe déjà" {
 				utils.RespondError(w, http.StatusConflict, err.Error())
 				return
 			}
+			if err.Error() == "le taux de TVA doit être compris entre 0 et 100" {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if isCategoryInputError(err) {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
 			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la mise à jour de la catégorie")
 			return
 		}
@@ -178,16 +219,22 @@ func UpdateCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // DeleteCategoryHandler gère la suppression d'une catégorie (admin only)
 // @Summary      Supprimer une catégorie
-// @Description  Supprime une catégorie (admin uniquement)
+// @Description  Supprime une catégorie (admin uniquement). Une catégorie contenant des sous-catégories ne peut pas être supprimée.
+// @Description  Si la catégorie contient des produits, la suppression est refusée (409, avec leur nombre) sauf avec ?reassignTo={categoryID}, qui les déplace dans une autre catégorie, ou ?detach=true, qui les laisse sans catégorie.
+// @Description  Les produits et la catégorie sont modifiés dans une même transaction.
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string  true  "ID de la catégorie"
+// @Param        id          path      string  true   "ID de la catégorie"
+// @Param        reassignTo  query     string  false  "ID de la catégorie recevant les produits"
+// @Param        detach      query     bool    false  "Laisser les produits sans catégorie"
 // @Success      204  "No Content"
+// @Failure      400  {object}  docs.ErrorResponse  "Options incompatibles ou catégorie de destination invalide"
 // @Failure      401  {object}  docs.ErrorResponse
 // @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
 // @Failure      404  {object}  docs.ErrorResponse
+// @Failure      409  {object}  dtos.CategoryInUseResponse  "La catégorie contient des produits ou des sous-catégories"
 // @Failure      500  {object}  docs.ErrorResponse
 // @Router       /admin/categories/{id} [delete]
 func DeleteCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
@@ -198,13 +245,28 @@ func DeleteCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 			return
 		}
 
-		err := services.DeleteCategory(client, categoryID)
+		reassignTo := r.URL.Query().Get("reassignTo")
+		detach, _ := strconv.ParseBool(r.URL.Query().Get("detach"))
+
+		productCount, err := services.DeleteCategory(client, categoryID, reassignTo, detach)
 		if err != nil {
-			if err.Error() == "erreur lors de la suppression de la catégorie: record to delete does not exist" {
+			switch err.Error() {
+			case "catégorie non trouvée":
 				utils.RespondError(w, http.StatusNotFound, "Catégorie non trouvée")
-				return
+			case "la catégorie contient des produits":
+				utils.RespondJSON(w, http.StatusConflict, dtos.CategoryInUseResponse{
+					Error:        "La catégorie contient des produits : précisez reassignTo ou detach=true",
+					ProductCount: productCount,
+				})
+			case "la catégorie contient des sous-catégories":
+				utils.RespondError(w, http.StatusConflict, err.Error())
+			case "reassignTo et detach ne peuvent pas être utilisés ensemble",
+				"une catégorie ne peut pas être réaffectée à elle-même",
+				"catégorie de destination non trouvée":
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+			default:
+				utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la suppression de la catégorie")
 			}
-			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la suppression de la catégorie")
 			return
 		}
 
@@ -214,13 +276,14 @@ func DeleteCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // PatchCategoryHandler gère la mise à jour partielle d'une catégorie (admin only)
 // @Summary      Mettre à jour partiellement une catégorie
-// @Description  Met à jour uniquement le nom d'une catégorie (admin uniquement). Exemple: changer uniquement le nom sans recréer la catégorie.
+// @Description  Met à jour un ou plusieurs champs d'une catégorie (admin uniquement). Exemple: changer uniquement le nom sans recréer la catégorie.
+// @Description  parentID "" fait remonter la catégorie à la racine ; description "" efface la description.
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id       path      string                  true  "ID de la catégorie"
-// @Param        request  body      dtos.PatchCategoryRequest  true  "Champs à mettre à jour (nom optionnel)"
+// @Param        request  body      dtos.PatchCategoryRequest  true  "Champs à mettre à jour (tous optionnels)"
 // @Success      200      {object}  dtos.CategoryResponse
 // @Failure      400      {object}  docs.ErrorResponse
 // @Failure      401      {object}  docs.ErrorResponse
@@ -249,10 +312,18 @@ func PatchCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 				utils.RespondError(w, http.StatusNotFound, err.Error())
 				return
 			}
-			if err.Error() == "une catégorie avec ce nom existe déjà" {
+			if err.Error() == "une catégorie avec ce nom existe déjà" || err.Error() == "une catégorie avec ce slug existe déjà" {
 				utils.RespondError(w, http.StatusConflict, err.Error())
 				return
 			}
+			if err.Error() == "le taux de TVA doit être compris entre 0 et 100" {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if isCategoryInputError(err) {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
 			if err.Error() == "le nom de la catégorie ne peut pas être vide" || err.Error() == "au moins un champ doit être fourni pour la mise à jour" {
 				utils.RespondError(w, http.StatusBadRequest, err.Error())
 				return
@@ -264,3 +335,10 @@ func PatchCategoryHandler(client *db.PrismaClient) http.HandlerFunc {
 		utils.RespondJSON(w, http.StatusOK, category)
 	}
 }
+
+// isCategoryInputError indique si l'erreur provient d'un slug ou d'une catégorie parente invalide
+func isCategoryInputError(err error) bool {
+	return strings.HasPrefix(err.Error(), "slug invalide") ||
+		strings.HasPrefix(err.Error(), "une catégorie ne peut pas") ||
+		err.Error() == "catégorie parente non trouvée"
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetAllCouponsHandler gère la récupération de tous les codes promo (admin only)
+// @Summary      Liste les codes promo
+// @Description  Récupère tous les codes promo, les plus récents en premier (admin uniquement)
+// @Tags         Coupons
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dtos.CouponResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/coupons [get]
+func GetAllCouponsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		coupons, err := services.GetAllCoupons(client)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des codes promo")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, coupons)
+	}
+}
+
+// GetCouponHandler gère la récupération d'un code promo par ID (admin only)
+// @Summary      Détails d'un code promo
+// @Description  Récupère un code promo par son ID (admin uniquement)
+// @Tags         Coupons
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID du code promo"
+// @Success      200  {object}  dtos.CouponResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/coupons/{id} [get]
+func GetCouponHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		coupon, err := services.GetCouponByID(client, chi.URLParam(r, "id"))
+		if err != nil {
+			respondCouponError(w, err, "Erreur lors de la récupération du code promo")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, coupon)
+	}
+}
+
+// CreateCouponHandler gère la création d'un code promo (admin only)
+// @Summary      Créer un code promo
+// @Description  Crée un code promo : pourcentage (PERCENTAGE), montant fixe (FIXED) ou livraison offerte (FREE_SHIPPING).
+// @Description  La remise peut être limitée à des produits ou catégories, soumise à un montant minimum, à une période de validité et à des limites d'utilisation globales ou par client (admin uniquement).
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dtos.CouponRequest  true  "Paramètres du code promo"
+// @Success      201      {object}  dtos.CouponResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      409      {object}  docs.ErrorResponse  "Code déjà utilisé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/coupons [post]
+func CreateCouponHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.CouponRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		coupon, err := services.CreateCoupon(client, req)
+		if err != nil {
+			respondCouponError(w, err, "Erreur lors de la création du code promo")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, coupon)
+	}
+}
+
+// UpdateCouponHandler gère la mise à jour d'un code promo (admin only)
+// @Summary      Mettre à jour un code promo
+// @Description  Remplace les paramètres d'un code promo (admin uniquement). Les commandes déjà passées conservent leur remise.
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true  "ID du code promo"
+// @Param        request  body      dtos.CouponRequest  true  "Nouveaux paramètres du code promo"
+// @Success      200      {object}  dtos.CouponResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      409      {object}  docs.ErrorResponse  "Code déjà utilisé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/coupons/{id} [put]
+func UpdateCouponHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.CouponRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		coupon, err := services.UpdateCoupon(client, chi.URLParam(r, "id"), req)
+		if err != nil {
+			respondCouponError(w, err, "Erreur lors de la mise à jour du code promo")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, coupon)
+	}
+}
+
+// DeleteCouponHandler gère la suppression d'un code promo (admin only)
+// @Summary      Supprimer un code promo
+// @Description  Supprime un code promo (admin uniquement). Les commandes qui l'ont utilisé conservent le code et la remise ; pour suspendre un code, préférez active=false.
+// @Tags         Coupons
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID du code promo"
+// @Success      204  "No Content"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/coupons/{id} [delete]
+func DeleteCouponHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := services.DeleteCoupon(client, chi.URLParam(r, "id")); err != nil {
+			respondCouponError(w, err, "Erreur lors de la suppression du code promo")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetCouponStatsHandler gère les statistiques d'utilisation d'un code promo (admin only)
+// @Summary      Statistiques d'un code promo
+// @Description  Nombre d'utilisations, clients distincts, remises accordées et chiffre d'affaires généré. Les commandes annulées ne consomment pas d'utilisation (admin uniquement).
+// @Tags         Coupons
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID du code promo"
+// @Success      200  {object}  dtos.CouponStatsResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/coupons/{id}/stats [get]
+func GetCouponStatsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats, err := services.GetCouponStats(client, chi.URLParam(r, "id"))
+		if err != nil {
+			respondCouponError(w, err, "Erreur lors du calcul des statistiques")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, stats)
+	}
+}
+
+// respondCouponError associe les erreurs du service des codes promo aux codes HTTP
+func respondCouponError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "coupon non trouvé":
+		utils.RespondError(w, http.StatusNotFound, err.Error())
+	case err.Error() == "un coupon avec ce code existe déjà":
+		utils.RespondError(w, http.StatusConflict, err.Error())
+	case err.Error() == "le code promo est requis",
+		strings.HasPrefix(err.Error(), "type de code promo invalide"),
+		err.Error() == "le pourcentage doit être compris entre 0 et 100",
+		err.Error() == "le montant de la remise doit être supérieur à 0",
+		err.Error() == "le montant minimum ne peut pas être négatif",
+		err.Error() == "les limites d'utilisation ne peuvent pas être négatives",
+		err.Error() == "la date de fin doit être postérieure à la date de début",
+		err.Error() == "produit ciblé non trouvé",
+		err.Error() == "catégorie ciblée non trouvée":
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetFavoritesHandler gère la récupération des favoris de l'utilisateur connecté
+// @Summary      Mes favoris
+// @Description  Liste les produits favoris de l'utilisateur connecté, les plus récemment ajoutés en premier
+// @Tags         Favorites
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dtos.FavoriteResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /favorites [get]
+func GetFavoritesHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		favorites, err := services.GetUserFavorites(client, claims.UserID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des favoris")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, favorites)
+	}
+}
+
+// AddFavoriteHandler gère l'ajout d'un produit aux favoris
+// @Summary      Ajouter un favori
+// @Description  Ajoute un produit aux favoris de l'utilisateur connecté. L'opération est idempotente : si le produit est déjà en favori, le favori existant est retourné avec un code 200.
+// @Tags         Favorites
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dtos.AddFavoriteRequest  true  "Produit à ajouter"
+// @Success      201      {object}  dtos.FavoriteResponse
+// @Success      200      {object}  dtos.FavoriteResponse  "Déjà en favori"
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /favorites [post]
+func AddFavoriteHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.AddFavoriteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+		if req.ProductID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de produit requis")
+			return
+		}
+
+		favorite, created, err := services.AddFavorite(client, claims.UserID, req.ProductID)
+		if err != nil {
+			if err.Error() == "produit non trouvé" {
+				utils.RespondError(w, http.StatusNotFound, "Produit non trouvé")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de l'ajout aux favoris")
+			return
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		utils.RespondJSON(w, status, favorite)
+	}
+}
+
+// RemoveFavoriteHandler gère le retrait d'un produit des favoris
+// @Summary      Retirer un favori
+// @Description  Retire un produit des favoris de l'utilisateur connecté
+// @Tags         Favorites
+// @Produce      json
+// @Security     BearerAuth
+// @Param        productID  path  string  true  "ID du produit"
+// @Success      204  "No Content"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /favorites/{productID} [delete]
+func RemoveFavoriteHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		productID := chi.URLParam(r, "productID")
+
+		if err := services.RemoveFavorite(client, claims.UserID, productID); err != nil {
+			if err.Error() == "favori non trouvé" {
+				utils.RespondError(w, http.StatusNotFound, "Favori non trouvé")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la suppression du favori")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetMostWishedProductsHandler gère le rapport des produits les plus souhaités (admin only)
+// @Summary      Produits les plus souhaités
+// @Description  Classe les produits selon le nombre d'utilisateurs qui les ont ajoutés à leurs favoris (admin uniquement)
+// @Tags         Favorites
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit  query     int  false  "Nombre de produits (défaut: 10, max: 100)"
+// @Success      200    {array}   dtos.MostWishedProductResponse
+// @Failure      401    {object}  docs.ErrorResponse
+// @Failure      403    {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500    {object}  docs.ErrorResponse
+// @Router       /admin/reports/most-wished [get]
+func GetMostWishedProductsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 10
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = l
+		}
+
+		products, err := services.GetMostWishedProducts(client, limit)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la génération du rapport")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, products)
+	}
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"api/internal/db"
 	"api/internal/docs"
@@ -19,16 +22,20 @@ var _ = docs.ErrorResponse{}
 
 // CreateOrderHandler gère la création d'une commande (authentifié)
 // @Summary      Créer une commande
-// @Description  Crée une nouvelle commande avec les produits sélectionnés. Le stock est automatiquement déduit.
+// @Description  Crée une nouvelle commande avec les produits sélectionnés. Chaque ligne peut préciser une variante (variantID), sinon la variante par défaut du produit est commandée. Le stock de la variante est automatiquement déduit. L'adresse (addressID, carnet de l'utilisateur) est copiée dans la commande et les frais de port du mode de livraison (shippingMethodID) sont ajoutés au total. Avec un en-tête Idempotency-Key, un renvoi de la même requête retourne la commande d'origine sans en créer une nouvelle. Un code promo (couponCode) peut être appliqué : la remise est détaillée par ligne et sur la commande. Les prix étant TTC, chaque ligne et la commande sont décomposées en HT / TVA / TTC selon le taux de la catégorie du produit (ou VAT_DEFAULT_RATE).
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        request  body      dtos.CreateOrderRequest  true  "Items de la commande"
-// @Success      201      {object}  dtos.OrderResponse
-// @Failure      400      {object}  docs.ErrorResponse  "Stock insuffisant ou produit non trouvé"
-// @Failure      401      {object}  docs.ErrorResponse
-// @Failure      500      {object}  docs.ErrorResponse
+// @Param        Idempotency-Key  header    string                   false  "Clé d'idempotence choisie par le client (ex: UUID)"
+// @Param        Accept-Language  header    string                   false  "Langue des e-mails de suivi (fr, en ; défaut: fr)"
+// @Param        request          body      dtos.CreateOrderRequest  true   "Items de la commande"
+// @Success      201              {object}  dtos.OrderResponse
+// @Failure      400              {object}  docs.ErrorResponse  "Stock insuffisant, produit ou variante non trouvé, code promo invalide ou non applicable, adresse ou mode de livraison manquant"
+// @Failure      401              {object}  docs.ErrorResponse
+// @Failure      409              {object}  docs.ErrorResponse  "Requête avec la même Idempotency-Key en cours de traitement"
+// @Failure      422              {object}  docs.ErrorResponse  "Idempotency-Key déjà utilisée pour une requête différente"
+// @Failure      500              {object}  docs.ErrorResponse
 // @Router       /orders [post]
 func CreateOrderHandler(client *db.PrismaClient) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -51,11 +58,15 @@ func CreateOrderHandler(client *db.PrismaClient) http.HandlerFunc {
 			return
 		}
 
-		order, err := services.CreateOrder(client, claims.UserID, req)
+		order, err := services.CreateOrder(client, claims.UserID, r.Header.Get("Accept-Language"), req)
 		if err != nil {
 			if err.Error() == "une commande doit contenir au moins un produit" ||
-				err.Error() == "stock insuffisant" ||
-				err.Error()[:24] == "produit avec l'ID" {
+				strings.HasPrefix(err.Error(), "stock insuffisant") ||
+				strings.HasPrefix(err.Error(), "produit avec l'ID") ||
+				strings.HasPrefix(err.Error(), "variante avec l'ID") ||
+				strings.HasPrefix(err.Error(), "code promo") ||
+				strings.HasPrefix(err.Error(), "adresse de livraison") ||
+				strings.HasPrefix(err.Error(), "mode de livraison") {
 				utils.RespondError(w, http.StatusBadRequest, err.Error())
 				return
 			}
@@ -162,6 +173,57 @@ func GetOrderHandler(client *db.PrismaClient) http.HandlerFunc {
 	}
 }
 
+// GetOrderInvoiceHandler gère le téléchargement de la facture PDF d'une commande
+// @Summary      Facture PDF d'une commande
+// @Description  Génère la facture PDF d'une commande payée ou expédiée : mentions du vendeur (INVOICE_SELLER_*), client, lignes, récapitulatif TVA et totaux.
+// @Description  Le numéro de facture est séquentiel et sans trou, attribué au paiement ou à l'expédition. Seul le propriétaire de la commande ou un admin peut la télécharger.
+// @Tags         Orders
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID de la commande"
+// @Success      200  {file}    binary
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      404  {object}  docs.ErrorResponse  "Commande non trouvée"
+// @Failure      409  {object}  docs.ErrorResponse  "Commande ni payée ni expédiée"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /orders/{id}/invoice.pdf [get]
+func GetOrderInvoiceHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de commande requis")
+			return
+		}
+
+		isAdmin := claims.Role == "ADMIN"
+		number, pdf, err := services.GetOrderInvoice(client, orderID, claims.UserID, isAdmin)
+		if err != nil {
+			if err.Error() == "commande non trouvée" || err.Error() == "accès non autorisé à cette commande" {
+				utils.RespondError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			if strings.HasPrefix(err.Error(), "facture non disponible") {
+				utils.RespondError(w, http.StatusConflict, err.Error())
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la génération de la facture")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="facture-%s.pdf"`, number))
+		w.Header().Set("Content-Length", strconv.Itoa(len(pdf)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(pdf)
+	}
+}
+
 // UpdateOrderStatusHandler gère la mise à jour du statut d'une commande (admin only)
 // @Summary      Mettre à jour le statut d'une commande
 // @Description  Met à jour le statut d'une commande (PENDING, SHIPPED, DELIVERED, CANCELLED) - admin uniquement
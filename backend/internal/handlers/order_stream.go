@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"api/internal/events"
+	"api/internal/middlewares"
+	"api/internal/realtime"
+	"api/internal/utils"
+)
+
+// StreamOrdersHandler diffuse en temps réel les changements de statut des commandes de l'utilisateur
+// @Summary      Flux temps réel de mes commandes (SSE)
+// @Description  Flux Server-Sent Events des changements de statut des commandes de l'utilisateur connecté (événement "order.status_changed").
+// @Description  Un commentaire ": heartbeat" est envoyé toutes les SSE_HEARTBEAT_SECONDS secondes (15 par défaut). Après une coupure, renvoyer l'id du dernier événement reçu dans l'en-tête Last-Event-ID :
+// @Description  les événements manqués encore en mémoire sont rejoués, sinon un événement "resync" invite à recharger les commandes. Le flux est fermé à l'expiration du token.
+// @Tags         Orders
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        Last-Event-ID  header    string  false  "Identifiant du dernier événement reçu"
+// @Success      200            {object}  dtos.OrderStreamEvent  "Flux d'événements"
+// @Failure      401            {object}  docs.ErrorResponse
+// @Router       /orders/stream [get]
+func StreamOrdersHandler(hub *realtime.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		serveOrderStream(w, r, hub, func(msg realtime.Message) bool {
+			return msg.Event == events.OrderStatusChanged && msg.UserID == claims.UserID
+		})
+	}
+}
+
+// StreamAdminOrdersHandler diffuse en temps réel les nouvelles commandes et les changements de statut (admin only)
+// @Summary      Flux temps réel des commandes (SSE, admin)
+// @Description  Flux Server-Sent Events des nouvelles commandes ("order.created") et des changements de statut ("order.status_changed") de tous les clients (admin uniquement).
+// @Description  Heartbeat, reprise via Last-Event-ID et événement "resync" comme pour /orders/stream.
+// @Tags         Orders
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        Last-Event-ID  header    string  false  "Identifiant du dernier événement reçu"
+// @Success      200            {object}  dtos.OrderStreamEvent  "Flux d'événements"
+// @Failure      401            {object}  docs.ErrorResponse
+// @Failure      403            {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Router       /admin/orders/stream [get]
+func StreamAdminOrdersHandler(hub *realtime.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveOrderStream(w, r, hub, func(msg realtime.Message) bool {
+			return msg.Event == events.OrderCreated || msg.Event == events.OrderStatusChanged
+		})
+	}
+}
+
+// sseHeartbeatInterval retourne l'intervalle des heartbeats SSE (SSE_HEARTBEAT_SECONDS, défaut: 15)
+func sseHeartbeatInterval() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("SSE_HEARTBEAT_SECONDS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Second
+	}
+	return 15 * time.Second
+}
+
+// serveOrderStream envoie le flux SSE des messages acceptés par filter jusqu'à la déconnexion du client,
+// sa déconnexion par le hub (client trop lent) ou l'expiration de son token
+func serveOrderStream(w http.ResponseWriter, r *http.Request, hub *realtime.Hub, filter func(realtime.Message) bool) {
+	claims, ok := middlewares.GetUserClaims(r)
+	if !ok {
+		utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	lastID, _ := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	sub, replay, complete := hub.Subscribe(lastID, filter)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Pas de mise en tampon par un reverse proxy nginx
+	w.WriteHeader(http.StatusOK)
+
+	// Délai de reconnexion suggéré au client, puis rattrapage
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if !complete {
+		fmt.Fprint(w, "event: resync\ndata: {}\n\n")
+	}
+	for _, msg := range replay {
+		if realtime.Write(w, msg) != nil {
+			return
+		}
+	}
+	if rc.Flush() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval())
+	defer heartbeat.Stop()
+
+	var expired <-chan time.Time
+	if claims.ExpiresAt != nil {
+		timer := time.NewTimer(time.Until(claims.ExpiresAt.Time))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-expired:
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if realtime.Write(w, msg) != nil || rc.Flush() != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/payments"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxWebhookBodySize limite la taille des webhooks acceptés (1 Mo)
+const maxWebhookBodySize = 1 << 20
+
+// PayOrderHandler gère l'initiation du paiement d'une commande
+// @Summary      Payer une commande
+// @Description  Crée une intention de paiement auprès du prestataire choisi (prestataire par défaut si omis) et retourne le client secret à transmettre au front.
+// @Description  Tant que le paiement est en attente, un nouvel appel retourne la même intention. La commande est marquée payée à réception du webhook de capture.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id               path      string                true   "ID de la commande"
+// @Param        Idempotency-Key  header    string                false  "Clé d'idempotence choisie par le client (ex: UUID)"
+// @Param        request          body      dtos.PayOrderRequest  false  "Prestataire de paiement"
+// @Success      201              {object}  dtos.PaymentResponse
+// @Failure      400              {object}  docs.ErrorResponse  "Prestataire inconnu"
+// @Failure      401              {object}  docs.ErrorResponse
+// @Failure      404              {object}  docs.ErrorResponse  "Commande non trouvée"
+// @Failure      409              {object}  docs.ErrorResponse  "Commande annulée ou déjà payée, ou requête avec la même Idempotency-Key en cours"
+// @Failure      422              {object}  docs.ErrorResponse  "Idempotency-Key déjà utilisée pour une requête différente"
+// @Failure      502              {object}  docs.ErrorResponse  "Erreur du prestataire de paiement"
+// @Failure      500              {object}  docs.ErrorResponse
+// @Router       /orders/{id}/pay [post]
+func PayOrderHandler(client *db.PrismaClient, registry *payments.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de commande requis")
+			return
+		}
+
+		// Le corps est optionnel
+		var req dtos.PayOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		payment, err := services.PayOrder(client, registry, orderID, claims.UserID, req.Provider)
+		if err != nil {
+			respondPaymentError(w, err, "Erreur lors de l'initiation du paiement")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, payment)
+	}
+}
+
+// GetOrderPaymentsHandler gère la récupération des paiements d'une commande
+// @Summary      Paiements d'une commande
+// @Description  Liste les tentatives de paiement d'une commande et leur statut (propriétaire de la commande ou admin)
+// @Tags         Payments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID de la commande"
+// @Success      200  {array}   dtos.PaymentResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      404  {object}  docs.ErrorResponse  "Commande non trouvée"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /orders/{id}/payments [get]
+func GetOrderPaymentsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de commande requis")
+			return
+		}
+
+		isAdmin := claims.Role == "ADMIN"
+		result, err := services.GetOrderPayments(client, orderID, claims.UserID, isAdmin)
+		if err != nil {
+			respondPaymentError(w, err, "Erreur lors de la récupération des paiements")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// PaymentWebhookHandler reçoit les webhooks des prestataires de paiement
+// @Summary      Webhook de paiement
+// @Description  Point d'entrée des notifications du prestataire (paiement autorisé, capturé, échoué, remboursé). La signature est vérifiée avant tout traitement ;
+// @Description  un événement déjà reçu est acquitté sans être rejoué. Un paiement capturé pour un montant différent de celui du paiement est refusé.
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Param        provider  path      string  true  "Nom du prestataire (ex: fake)"
+// @Success      200       {object}  map[string]string
+// @Failure      400       {object}  docs.ErrorResponse  "Webhook invalide ou montant incohérent"
+// @Failure      401       {object}  docs.ErrorResponse  "Signature invalide"
+// @Failure      404       {object}  docs.ErrorResponse  "Prestataire ou paiement inconnu"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /webhooks/payments/{provider} [post]
+func PaymentWebhookHandler(client *db.PrismaClient, registry *payments.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "provider")
+		provider, ok := registry.Get(name)
+		if name == "" || !ok {
+			utils.RespondError(w, http.StatusNotFound, "Prestataire de paiement inconnu")
+			return
+		}
+
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Corps de requête invalide")
+			return
+		}
+
+		if err := services.HandlePaymentWebhook(client, provider, payload, r.Header); err != nil {
+			if errors.Is(err, payments.ErrInvalidSignature) {
+				utils.RespondError(w, http.StatusUnauthorized, "Signature invalide")
+				return
+			}
+			if err.Error() == "paiement non trouvé" {
+				utils.RespondError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			if strings.HasPrefix(err.Error(), "erreur") {
+				utils.RespondError(w, http.StatusInternalServerError, "Erreur lors du traitement du webhook")
+				return
+			}
+			utils.RespondError(w, http.StatusBadRequest, "Webhook invalide")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}
+}
+
+// RefundPaymentHandler gère le remboursement d'un paiement (admin only)
+// @Summary      Rembourser un paiement
+// @Description  Rembourse tout ou partie d'un paiement capturé auprès du prestataire. Sans montant, le solde restant est remboursé (admin uniquement)
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                     true   "ID du paiement"
+// @Param        request  body      dtos.RefundPaymentRequest  false  "Montant à rembourser"
+// @Success      200      {object}  dtos.PaymentResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Montant invalide"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse  "Paiement non trouvé"
+// @Failure      409      {object}  docs.ErrorResponse  "Paiement non capturé"
+// @Failure      502      {object}  docs.ErrorResponse  "Erreur du prestataire de paiement"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/payments/{id}/refund [post]
+func RefundPaymentHandler(client *db.PrismaClient, registry *payments.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		paymentID := chi.URLParam(r, "id")
+		if paymentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de paiement requis")
+			return
+		}
+
+		var req dtos.RefundPaymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		payment, err := services.RefundPayment(client, registry, paymentID, req.Amount)
+		if err != nil {
+			respondPaymentError(w, err, "Erreur lors du remboursement")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, payment)
+	}
+}
+
+// SimulatePaymentWebhookHandler construit un webhook signé du faux prestataire (admin only)
+// @Summary      Simuler un webhook de paiement
+// @Description  Retourne un webhook signé du faux prestataire pour un paiement donné, à rejouer tel quel sur l'URL indiquée avec l'en-tête de signature.
+// @Description  Disponible uniquement lorsque le faux prestataire est activé (PAYMENT_FAKE_ENABLED=true, en développement) (admin uniquement)
+// @Tags         Payments
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                              true  "ID du paiement"
+// @Param        request  body      dtos.SimulatePaymentWebhookRequest  true  "Événement à simuler"
+// @Success      200      {object}  dtos.SignedWebhookResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Type d'événement invalide"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse  "Paiement non trouvé ou faux prestataire désactivé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/payments/{id}/simulate [post]
+func SimulatePaymentWebhookHandler(client *db.PrismaClient, registry *payments.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider, ok := registry.Get(payments.FakeProviderName)
+		fake, isFake := provider.(*payments.FakeProvider)
+		if !ok || !isFake {
+			utils.RespondError(w, http.StatusNotFound, "Faux prestataire de paiement désactivé")
+			return
+		}
+
+		paymentID := chi.URLParam(r, "id")
+		if paymentID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de paiement requis")
+			return
+		}
+
+		var req dtos.SimulatePaymentWebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		webhook, err := services.BuildFakeWebhook(client, fake, paymentID, req)
+		if err != nil {
+			respondPaymentError(w, err, "Erreur lors de la simulation du webhook")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, webhook)
+	}
+}
+
+// respondPaymentError traduit les erreurs du service de paiement en réponses HTTP
+func respondPaymentError(w http.ResponseWriter, err error, fallback string) {
+	msg := err.Error()
+	switch {
+	case msg == "commande non trouvée" || msg == "accès non autorisé à cette commande" || msg == "paiement non trouvé":
+		utils.RespondError(w, http.StatusNotFound, msg)
+	case msg == "commande annulée" || msg == "commande déjà payée" || msg == "seul un paiement capturé peut être remboursé":
+		utils.RespondError(w, http.StatusConflict, msg)
+	case strings.HasPrefix(msg, "prestataire de paiement inconnu"),
+		strings.HasPrefix(msg, "le montant du remboursement"),
+		strings.HasPrefix(msg, "type d'événement invalide"):
+		utils.RespondError(w, http.StatusBadRequest, msg)
+	case strings.HasPrefix(msg, "erreur du prestataire de paiement"):
+		utils.RespondError(w, http.StatusBadGateway, msg)
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"api/internal/db"
 	"api/internal/docs"
 	"api/internal/dtos"
+	"api/internal/middlewares"
 	"api/internal/services"
 	"api/internal/utils"
 
@@ -18,30 +21,53 @@ import (
 var _ = docs.ErrorResponse{}
 
 // GetAllProductsHandler gère la récupération de tous les produits (authentifié)
-// Supporte la pagination via les query params ?page=1&limit=10
+// Supporte la pagination via les query params ?page=1&limit=10 et les filtres skincare
 // @Summary      Liste tous les produits
 // @Description  Récupère la liste de tous les produits disponibles avec leurs catégories (authentification requise). Supporte la pagination via ?page=1&limit=10
+// @Description  Filtres : ?category=visage (slug, sous-catégories incluses), ?skinType=sensitive, ?concern=acne, ?excludeIngredient=parfum (répétable ou séparé par des virgules) pour écarter les produits contenant un allergène. Les produits sans liste d'ingrédients ne sont pas exclus.
+// @Description  Chaque produit porte sa note moyenne, son nombre d'avis publiés et leur répartition par note ; ?sort=rating trie par note moyenne décroissante.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page   query     int     false  "Numéro de page (défaut: 1)"
 // @Param        limit  query     int     false  "Nombre d'éléments par page (défaut: 10, max: 100)"
+// @Param        category           query  string  false  "Slug de catégorie (produits des sous-catégories inclus)"
+// @Param        skinType           query  string  false  "Type de peau adapté"  Enums(dry, oily, combination, sensitive)
+// @Param        concern            query  string  false  "Préoccupation ciblée"  Enums(acne, aging, hyperpigmentation, dehydration, redness, pores, dullness)
+// @Param        excludeIngredient  query  string  false  "Ingrédient(s) INCI à exclure, séparés par des virgules"
+// @Param        sort               query  string  false  "Tri : rating (meilleures notes d'abord)"  Enums(rating)
 // @Success      200  {object}  dtos.PaginatedProductsResponse
 // @Success      200  {array}   dtos.ProductResponse  "Si page et limit ne sont pas fournis"
+// @Failure      400  {object}  docs.ErrorResponse  "Filtre invalide"
 // @Failure      401  {object}  docs.ErrorResponse
 // @Failure      500  {object}  docs.ErrorResponse
 // @Router       /products [get]
 func GetAllProductsHandler(client *db.PrismaClient) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
 		// Récupérer les paramètres de pagination
 		pageStr := r.URL.Query().Get("page")
 		limitStr := r.URL.Query().Get("limit")
+		filters := parseProductFilters(r)
 
 		// Si pas de paramètres de pagination, retourner tous les produits (compatibilité)
 		if pageStr == "" && limitStr == "" {
-			products, err := services.GetAllProducts(client)
+			products, err := services.GetAllProducts(client, filters)
 			if err != nil {
+				if isProductFilterError(err) {
+					utils.RespondError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des produits")
+				return
+			}
+			if err := services.MarkFavorites(client, claims.UserID, products); err != nil {
 				utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des produits")
 				return
 			}
@@ -66,8 +92,16 @@ func GetAllProductsHandler(client *db.PrismaClient) http.HandlerFunc {
 		}
 
 		// Récupérer les produits paginés
-		result, err := services.GetProductsPaginated(client, page, limit)
+		result, err := services.GetProductsPaginated(client, page, limit, filters)
 		if err != nil {
+			if isProductFilterError(err) {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des produits")
+			return
+		}
+		if err := services.MarkFavorites(client, claims.UserID, result.Products); err != nil {
 			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des produits")
 			return
 		}
@@ -78,7 +112,7 @@ func GetAllProductsHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // GetProductHandler gère la récupération d'un produit par ID (authentifié)
 // @Summary      Détails d'un produit
-// @Description  Récupère les détails d'un produit par son ID (authentification requise)
+// @Description  Récupère les détails d'un produit par son ID (authentification requise), avec le nombre de questions ayant une réponse publiée (answeredQuestionCount)
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -109,13 +143,22 @@ func GetProductHandler(client *db.PrismaClient) http.HandlerFunc {
 			return
 		}
 
+		// Indiquer si le produit est dans les favoris de l'utilisateur connecté
+		if claims, ok := middlewares.GetUserClaims(r); ok {
+			product.IsFavorite, err = services.IsFavorite(client, claims.UserID, productID)
+			if err != nil {
+				utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération du produit")
+				return
+			}
+		}
+
 		utils.RespondJSON(w, http.StatusOK, product)
 	}
 }
 
 // CreateProductHandler gère la création d'un produit (admin only)
 // @Summary      Créer un produit
-// @Description  Crée un nouveau produit (admin uniquement)
+// @Description  Crée un nouveau produit avec une variante par défaut "Standard" portant le prix et le stock (admin uniquement)
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -137,29 +180,19 @@ func CreateProductHandler(client *db.PrismaClient) http.HandlerFunc {
 			return
 		}
 
-		// Validation basique
-		if req.Name == "" {
-			utils.RespondError(w, http.StatusBadRequest, "Le nom du produit est requis")
-			return
-		}
-
-		if req.Price <= 0 {
-			utils.RespondError(w, http.StatusBadRequest, "Le prix doit être supérieur à 0")
-			return
-		}
-
-		if req.Stock < 0 {
-			utils.RespondError(w, http.StatusBadRequest, "Le stock ne peut pas être négatif")
+		if err := services.ValidateProductRequest(req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		product, err := services.CreateProduct(client, req)
 		if err != nil {
-			if err.Error() == "un produit avec ce nom existe déjà" {
+			if err.Error() == "un produit avec ce nom existe déjà" || err.Error() == "un produit avec ce SKU existe déjà" {
 				utils.RespondError(w, http.StatusConflict, err.Error())
 				return
 			}
-			if err.Error() == "catégorie non trouvée" {
+			if err.Error() == "catégorie non trouvée" || isProductFilterError(err) ||
+				err.Error() == "la contenance ne peut pas être négative" || err.Error() == "la PAO ne peut pas être négative" {
 				utils.RespondError(w, http.StatusBadRequest, err.Error())
 				return
 			}
@@ -173,7 +206,7 @@ func CreateProductHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // UpdateProductHandler gère la mise à jour d'un produit (admin only)
 // @Summary      Mettre à jour un produit
-// @Description  Met à jour un produit existant (admin uniquement)
+// @Description  Met à jour un produit existant (admin uniquement). Le prix et le stock sont appliqués à la variante par défaut ; le stock du produit est la somme des variantes.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -202,19 +235,8 @@ func UpdateProductHandler(client *db.PrismaClient) http.HandlerFunc {
 			return
 		}
 
-		// Validation basique
-		if req.Name == "" {
-			utils.RespondError(w, http.StatusBadRequest, "Le nom du produit est requis")
-			return
-		}
-
-		if req.Price <= 0 {
-			utils.RespondError(w, http.StatusBadRequest, "Le prix doit être supérieur à 0")
-			return
-		}
-
-		if req.Stock < 0 {
-			utils.RespondError(w, http.StatusBadRequest, "Le stock ne peut pas être négatif")
+		if err := services.ValidateProductRequest(req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
@@ -224,11 +246,12 @@ func UpdateProductHandler(client *db.PrismaClient) http.HandlerFunc {
 				utils.RespondError(w, http.StatusNotFound, err.Error())
 				return
 			}
-			if err.Error() == "un produit avec ce nom existe déjà" {
+			if err.Error() == "un produit avec ce nom existe déjà" || err.Error() == "un produit avec ce SKU existe déjà" {
 				utils.RespondError(w, http.StatusConflict, err.Error())
 				return
 			}
-			if err.Error() == "catégorie non trouvée" {
+			if err.Error() == "catégorie non trouvée" || isProductFilterError(err) ||
+				err.Error() == "la contenance ne peut pas être négative" || err.Error() == "la PAO ne peut pas être négative" {
 				utils.RespondError(w, http.StatusBadRequest, err.Error())
 				return
 			}
@@ -313,11 +336,12 @@ func PatchProductHandler(client *db.PrismaClient) http.HandlerFunc {
 				utils.RespondError(w, http.StatusNotFound, err.Error())
 				return
 			}
-			if err.Error() == "un produit avec ce nom existe déjà" {
+			if err.Error() == "un produit avec ce nom existe déjà" || err.Error() == "un produit avec ce SKU existe déjà" {
 				utils.RespondError(w, http.StatusConflict, err.Error())
 				return
 			}
-			if err.Error() == "catégorie non trouvée" {
+			if err.Error() == "catégorie non trouvée" || isProductFilterError(err) ||
+				err.Error() == "la contenance ne peut pas être négative" || err.Error() == "la PAO ne peut pas être négative" {
 				utils.RespondError(w, http.StatusBadRequest, err.Error())
 				return
 			}
@@ -336,3 +360,120 @@ func PatchProductHandler(client *db.PrismaClient) http.HandlerFunc {
 		utils.RespondJSON(w, http.StatusOK, product)
 	}
 }
+
+// ImportProductsHandler gère l'import en masse de produits depuis un fichier CSV ou JSON (admin only)
+// @Summary      Importer des produits (CSV/JSON)
+// @Description  Crée ou met à jour des produits en masse (admin uniquement). Les produits sont rapprochés par SKU puis par nom, les catégories par nom (créées si besoin).
+// @Description  Le corps peut être un CSV (Content-Type: text/csv, en-têtes: name,sku,description,price,stock,imageURL,category et optionnellement ingredients,skinTypes,concerns (valeurs séparées par |),volumeML,paoMonths), un tableau JSON (application/json) ou un formulaire multipart avec un champ "file".
+// @Description  Les attributs skincare sont validés comme à la création ; pour un produit existant, un attribut absent de la ligne n'est pas modifié.
+// @Description  Toutes les écritures sont faites dans une seule transaction : si une ligne est en erreur, rien n'est enregistré. Utilisez ?dryRun=true pour obtenir le rapport sans rien modifier.
+// @Tags         Products
+// @Accept       json,text/csv,multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        dryRun   query     bool                     false  "Simulation sans écriture (défaut: false)"
+// @Param        request  body      []dtos.ImportProductRow  false  "Produits à importer (JSON)"
+// @Param        file     formData  file                     false  "Fichier CSV ou JSON (multipart)"
+// @Success      200      {object}  dtos.ImportProductsResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Fichier invalide"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      422      {object}  dtos.ImportProductsResponse  "Au moins une ligne est en erreur - rien n'a été importé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/products/import [post]
+func ImportProductsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+		// Limiter la taille du fichier importé (10 Mo)
+		r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
+
+		var body io.Reader = r.Body
+		isJSON := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				utils.RespondError(w, http.StatusBadRequest, "Fichier requis (champ \"file\")")
+				return
+			}
+			defer file.Close()
+			body = file
+			isJSON = strings.HasSuffix(strings.ToLower(header.Filename), ".json")
+		}
+
+		var report *dtos.ImportProductsResponse
+		var err error
+		if isJSON {
+			report, err = services.ImportProductsJSON(client, body, dryRun)
+		} else {
+			report, err = services.ImportProductsCSV(client, body, dryRun)
+		}
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "fichier d'import invalide") {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de l'import des produits")
+			return
+		}
+
+		if report.Errors > 0 {
+			utils.RespondJSON(w, http.StatusUnprocessableEntity, report)
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, report)
+	}
+}
+
+// RecomputeProductRatingsHandler gère le recalcul des notes des produits (admin only)
+// @Summary      Recalculer les notes des produits
+// @Description  Recalcule la note moyenne, le nombre d'avis et l'histogramme de chaque produit à partir de ses avis approuvés, et retourne le nombre de produits corrigés.
+// @Description  Ces agrégats sont tenus à jour à chaque écriture d'avis : le recalcul sert après une intervention directe en base (admin uniquement).
+// @Tags         Products
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.RecomputeRatingsResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/products/ratings/recompute [post]
+func RecomputeProductRatingsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := services.RecomputeProductRatings(client)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors du recalcul des notes")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// parseProductFilters lit les filtres de catégorie et skincare de la query string
+// excludeIngredient peut être répété et/ou contenir plusieurs valeurs séparées par des virgules
+func parseProductFilters(r *http.Request) dtos.ProductFilters {
+	query := r.URL.Query()
+
+	var excluded []string
+	for _, value := range query["excludeIngredient"] {
+		excluded = append(excluded, strings.Split(value, ",")...)
+	}
+
+	return dtos.ProductFilters{
+		Category:           query.Get("category"),
+		SkinType:           query.Get("skinType"),
+		Concern:            query.Get("concern"),
+		ExcludeIngredients: excluded,
+		Sort:               query.Get("sort"),
+	}
+}
+
+// isProductFilterError indique si l'erreur provient d'un filtre ou attribut skincare invalide
+func isProductFilterError(err error) bool {
+	return strings.HasPrefix(err.Error(), "catégorie inconnue") ||
+		strings.HasPrefix(err.Error(), "type de peau invalide") ||
+		strings.HasPrefix(err.Error(), "préoccupation invalide") ||
+		strings.HasPrefix(err.Error(), "tri invalide")
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/services"
+	"api/internal/storage"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// UploadProductImageHandler gère l'upload d'une image de produit (admin only)
+// @Summary      Ajouter une image à un produit
+// @Description  Upload une image (JPEG ou PNG, 5 Mo et 40 mégapixels maximum) via un formulaire multipart avec un champ "file" (admin uniquement).
+// @Description  Une miniature (200px) et une version moyenne (800px) sont générées automatiquement. L'image est ajoutée en dernière position.
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string  true  "ID du produit"
+// @Param        file  formData  file    true  "Image à uploader (JPEG ou PNG)"
+// @Success      201   {object}  dtos.ProductImageResponse
+// @Failure      400   {object}  docs.ErrorResponse  "Fichier manquant ou image invalide"
+// @Failure      401   {object}  docs.ErrorResponse
+// @Failure      403   {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404   {object}  docs.ErrorResponse
+// @Failure      413   {object}  docs.ErrorResponse  "Image trop volumineuse (poids ou dimensions)"
+// @Failure      415   {object}  docs.ErrorResponse  "Type d'image non supporté"
+// @Failure      500   {object}  docs.ErrorResponse
+// @Router       /admin/products/{id}/images [post]
+func UploadProductImageHandler(client *db.PrismaClient, store storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "id")
+		if productID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de produit requis")
+			return
+		}
+
+		// Marge pour les en-têtes multipart au-delà de la taille maximale de l'image
+		r.Body = http.MaxBytesReader(w, r.Body, services.MaxProductImageSize+(1<<20))
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			if strings.Contains(err.Error(), "request body too large") {
+				utils.RespondError(w, http.StatusRequestEntityTooLarge, "Image trop volumineuse (maximum 5 Mo)")
+				return
+			}
+			utils.RespondError(w, http.StatusBadRequest, "Fichier requis (champ \"file\")")
+			return
+		}
+		defer file.Close()
+
+		// Lire un octet de plus que la limite pour détecter les fichiers trop gros
+		data, err := io.ReadAll(io.LimitReader(file, services.MaxProductImageSize+1))
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "Impossible de lire le fichier")
+			return
+		}
+
+		image, err := services.UploadProductImage(client, store, productID, data)
+		if err != nil {
+			switch {
+			case err.Error() == "produit non trouvé":
+				utils.RespondError(w, http.StatusNotFound, err.Error())
+			case strings.HasPrefix(err.Error(), "image trop volumineuse"):
+				utils.RespondError(w, http.StatusRequestEntityTooLarge, err.Error())
+			case strings.HasPrefix(err.Error(), "type d'image non supporté"):
+				utils.RespondError(w, http.StatusUnsupportedMediaType, err.Error())
+			case strings.HasPrefix(err.Error(), "image invalide"):
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+			default:
+				utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de l'upload de l'image")
+			}
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, image)
+	}
+}
+
+// ReorderProductImagesHandler gère le réordonnancement des images d'un produit (admin only)
+// @Summary      Réordonner les images d'un produit
+// @Description  Définit l'ordre d'affichage des images (admin uniquement). La liste doit contenir tous les IDs d'images du produit ; la première devient l'image principale.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                            true  "ID du produit"
+// @Param        request  body      dtos.ReorderProductImagesRequest  true  "Nouvel ordre des images"
+// @Success      200      {array}   dtos.ProductImageResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/products/{id}/images/order [put]
+func ReorderProductImagesHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "id")
+		if productID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de produit requis")
+			return
+		}
+
+		var req dtos.ReorderProductImagesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		images, err := services.ReorderProductImages(client, productID, req.ImageIDs)
+		if err != nil {
+			if err.Error() == "produit non trouvé" {
+				utils.RespondError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			if err.Error() == "la liste doit contenir toutes les images du produit" {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors du réordonnancement des images")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, images)
+	}
+}
+
+// DeleteProductImageHandler gère la suppression d'une image de produit (admin only)
+// @Summary      Supprimer une image d'un produit
+// @Description  Supprime une image et ses miniatures (admin uniquement). Les images suivantes remontent d'une position.
+// @Tags         Products
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true  "ID du produit"
+// @Param        imageID  path      string  true  "ID de l'image"
+// @Success      204  "No Content"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/products/{id}/images/{imageID} [delete]
+func DeleteProductImageHandler(client *db.PrismaClient, store storage.BlobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "id")
+		imageID := chi.URLParam(r, "imageID")
+
+		if err := services.DeleteProductImage(client, store, productID, imageID); err != nil {
+			if err.Error() == "image non trouvée" {
+				utils.RespondError(w, http.StatusNotFound, "Image non trouvée")
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la suppression de l'image")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateProductVariantHandler gère l'ajout d'une variante à un produit (admin only)
+// @Summary      Ajouter une variante à un produit
+// @Description  Ajoute un format (ex: 30ml, 50ml) avec son propre prix, stock, SKU et code-barres (admin uniquement). Avec isDefault=true, la variante remplace la variante par défaut actuelle.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                      true  "ID du produit"
+// @Param        request  body      dtos.ProductVariantRequest  true  "Informations de la variante"
+// @Success      201      {object}  dtos.ProductVariantResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      409      {object}  docs.ErrorResponse  "SKU ou code-barres déjà utilisé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/products/{id}/variants [post]
+func CreateProductVariantHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "id")
+		if productID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de produit requis")
+			return
+		}
+
+		var req dtos.ProductVariantRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		variant, err := services.CreateProductVariant(client, productID, req)
+		if err != nil {
+			respondProductVariantError(w, err, "Erreur lors de la création de la variante")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, variant)
+	}
+}
+
+// UpdateProductVariantHandler gère la mise à jour d'une variante (admin only)
+// @Summary      Mettre à jour une variante
+// @Description  Met à jour une variante d'un produit (admin uniquement). Un SKU ou code-barres vide est supprimé. Pour changer la variante par défaut, passez isDefault=true sur la nouvelle.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string                      true  "ID du produit"
+// @Param        variantID  path      string                      true  "ID de la variante"
+// @Param        request    body      dtos.ProductVariantRequest  true  "Nouvelles informations de la variante"
+// @Success      200        {object}  dtos.ProductVariantResponse
+// @Failure      400        {object}  docs.ErrorResponse
+// @Failure      401        {object}  docs.ErrorResponse
+// @Failure      403        {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404        {object}  docs.ErrorResponse
+// @Failure      409        {object}  docs.ErrorResponse  "SKU ou code-barres déjà utilisé"
+// @Failure      500        {object}  docs.ErrorResponse
+// @Router       /admin/products/{id}/variants/{variantID} [put]
+func UpdateProductVariantHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "id")
+		variantID := chi.URLParam(r, "variantID")
+
+		var req dtos.ProductVariantRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		variant, err := services.UpdateProductVariant(client, productID, variantID, req)
+		if err != nil {
+			respondProductVariantError(w, err, "Erreur lors de la mise à jour de la variante")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, variant)
+	}
+}
+
+// DeleteProductVariantHandler gère la suppression d'une variante (admin only)
+// @Summary      Supprimer une variante
+// @Description  Supprime une variante d'un produit (admin uniquement). Le produit doit conserver au moins une variante ; si la variante par défaut est supprimée, la plus ancienne restante devient la variante par défaut.
+// @Tags         Products
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID du produit"
+// @Param        variantID  path      string  true  "ID de la variante"
+// @Success      204  "No Content"
+// @Failure      400  {object}  docs.ErrorResponse  "Dernière variante du produit"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/products/{id}/variants/{variantID} [delete]
+func DeleteProductVariantHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "id")
+		variantID := chi.URLParam(r, "variantID")
+
+		if err := services.DeleteProductVariant(client, productID, variantID); err != nil {
+			respondProductVariantError(w, err, "Erreur lors de la suppression de la variante")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// respondProductVariantError associe les erreurs du service des variantes aux codes HTTP
+func respondProductVariantError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "produit non trouvé", "variante non trouvée":
+		utils.RespondError(w, http.StatusNotFound, err.Error())
+	case "une variante avec ce SKU existe déjà", "une variante avec ce code-barres existe déjà":
+		utils.RespondError(w, http.StatusConflict, err.Error())
+	case "le libellé de la variante est requis", "le prix doit être supérieur à 0",
+		"le stock ne peut pas être négatif", "le poids ne peut pas être négatif",
+		"un produit doit conserver au moins une variante":
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateQuestionHandler gère la création d'une question sur un produit (authentifié)
+// @Summary      Poser une question sur un produit
+// @Description  Enregistre la question de l'utilisateur connecté. Elle est publiée après validation par un administrateur (statut PENDING), sauf si QUESTION_AUTO_APPROVE=true. Une question contenant un terme de REVIEW_BANNED_WORDS est refusée.
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                      true  "ID du produit"
+// @Param        request  body      dtos.CreateQuestionRequest  true  "Question"
+// @Success      201      {object}  dtos.QuestionResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Question vide, trop longue ou termes interdits"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      404      {object}  docs.ErrorResponse  "Produit non trouvé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /products/{id}/questions [post]
+func CreateQuestionHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.CreateQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		question, err := services.CreateQuestion(client, chi.URLParam(r, "id"), claims.UserID, req)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de la création de la question")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, question)
+	}
+}
+
+// GetProductQuestionsHandler gère la liste des questions d'un produit (authentifié)
+// @Summary      Questions d'un produit
+// @Description  Page des questions publiées d'un produit avec leurs réponses publiées : réponses officielles (administrateur) d'abord, puis les plus votées. upvoted indique les questions et réponses pour lesquelles l'utilisateur connecté a voté.
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string  true   "ID du produit"
+// @Param        answered  query     bool    false  "Uniquement les questions avec (true) ou sans (false) réponse"
+// @Param        sort      query     string  false  "Tri (défaut: recent)"  Enums(recent, popular)
+// @Param        page      query     int     false  "Numéro de page (défaut: 1)"
+// @Param        limit     query     int     false  "Nombre d'éléments par page (défaut: 10, max: 50)"
+// @Success      200       {object}  dtos.PaginatedQuestionsResponse
+// @Failure      400       {object}  docs.ErrorResponse  "Tri invalide"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      404       {object}  docs.ErrorResponse  "Produit non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /products/{id}/questions [get]
+func GetProductQuestionsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		questions, err := services.GetProductQuestions(client, chi.URLParam(r, "id"), claims.UserID, parseQuestionFilters(r))
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de la récupération des questions")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, questions)
+	}
+}
+
+// parseQuestionFilters lit les filtres, le tri et la pagination de la liste des questions
+func parseQuestionFilters(r *http.Request) dtos.QuestionFilters {
+	query := r.URL.Query()
+
+	var answered *bool
+	if v, err := strconv.ParseBool(query.Get("answered")); err == nil {
+		answered = &v
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	return dtos.QuestionFilters{
+		Answered: answered,
+		Sort:     query.Get("sort"),
+		Page:     page,
+		Limit:    limit,
+	}
+}
+
+// DeleteQuestionHandler gère la suppression d'une question par son auteur (authentifié)
+// @Summary      Supprimer ma question
+// @Description  Supprime une question de l'utilisateur connecté, avec ses réponses
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path      string  true  "ID de la question"
+// @Success      200         {object}  map[string]string
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Question d'un autre utilisateur"
+// @Failure      404         {object}  docs.ErrorResponse  "Question non trouvée"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /questions/{questionID} [delete]
+func DeleteQuestionHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		if err := services.DeleteQuestion(client, chi.URLParam(r, "questionID"), claims.UserID); err != nil {
+			respondQuestionError(w, err, "Erreur lors de la suppression de la question")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, map[string]string{"message": "Question supprimée avec succès"})
+	}
+}
+
+// CreateAnswerHandler gère la réponse à une question (authentifié)
+// @Summary      Répondre à une question
+// @Description  Un administrateur publie directement une réponse officielle. Un client doit avoir reçu le produit (commande livrée) et ne peut pas répondre à sa propre question ; sa réponse est publiée après modération, sauf si QUESTION_AUTO_APPROVE=true.
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path      string                    true  "ID de la question"
+// @Param        request     body      dtos.CreateAnswerRequest  true  "Réponse"
+// @Success      201         {object}  dtos.AnswerResponse
+// @Failure      400         {object}  docs.ErrorResponse  "Réponse vide, trop longue ou termes interdits"
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Produit non reçu ou question de l'utilisateur"
+// @Failure      404         {object}  docs.ErrorResponse  "Question non trouvée ou non publiée"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /questions/{questionID}/answers [post]
+func CreateAnswerHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.CreateAnswerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		isAdmin := claims.Role == "ADMIN"
+		answer, err := services.CreateAnswer(client, chi.URLParam(r, "questionID"), claims.UserID, isAdmin, req)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de la création de la réponse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, answer)
+	}
+}
+
+// DeleteAnswerHandler gère la suppression d'une réponse par son auteur (authentifié)
+// @Summary      Supprimer ma réponse
+// @Description  Supprime une réponse de l'utilisateur connecté
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        answerID  path      string  true  "ID de la réponse"
+// @Success      200       {object}  map[string]string
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Réponse d'un autre utilisateur"
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse non trouvée"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /answers/{answerID} [delete]
+func DeleteAnswerHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		if err := services.DeleteAnswer(client, chi.URLParam(r, "answerID"), claims.UserID); err != nil {
+			respondQuestionError(w, err, "Erreur lors de la suppression de la réponse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, map[string]string{"message": "Réponse supprimée avec succès"})
+	}
+}
+
+// UpvoteQuestionHandler gère le vote pour une question (authentifié)
+// @Summary      Voter pour une question
+// @Description  Indique que l'utilisateur connecté se pose aussi la question. Un seul vote par utilisateur et par question ; impossible de voter pour sa propre question
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path      string  true  "ID de la question"
+// @Success      200         {object}  dtos.QuestionResponse
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Vote pour sa propre question"
+// @Failure      404         {object}  docs.ErrorResponse  "Question non trouvée ou non publiée"
+// @Failure      409         {object}  docs.ErrorResponse  "Vote déjà enregistré"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /questions/{questionID}/upvote [post]
+func UpvoteQuestionHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		question, err := services.UpvoteQuestion(client, chi.URLParam(r, "questionID"), claims.UserID)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de l'enregistrement du vote")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, question)
+	}
+}
+
+// RemoveQuestionUpvoteHandler gère le retrait du vote pour une question (authentifié)
+// @Summary      Retirer mon vote pour une question
+// @Description  Retire le vote de l'utilisateur connecté pour une question publiée
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path      string  true  "ID de la question"
+// @Success      200         {object}  dtos.QuestionResponse
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      404         {object}  docs.ErrorResponse  "Question ou vote non trouvé"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /questions/{questionID}/upvote [delete]
+func RemoveQuestionUpvoteHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		question, err := services.RemoveQuestionUpvote(client, chi.URLParam(r, "questionID"), claims.UserID)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors du retrait du vote")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, question)
+	}
+}
+
+// UpvoteAnswerHandler gère le vote pour une réponse (authentifié)
+// @Summary      Voter pour une réponse
+// @Description  Indique qu'une réponse publiée a été utile. Un seul vote par utilisateur et par réponse ; impossible de voter pour sa propre réponse
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        answerID  path      string  true  "ID de la réponse"
+// @Success      200       {object}  dtos.AnswerResponse
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Vote pour sa propre réponse"
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse non trouvée ou non publiée"
+// @Failure      409       {object}  docs.ErrorResponse  "Vote déjà enregistré"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /answers/{answerID}/upvote [post]
+func UpvoteAnswerHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		answer, err := services.UpvoteAnswer(client, chi.URLParam(r, "answerID"), claims.UserID)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de l'enregistrement du vote")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, answer)
+	}
+}
+
+// RemoveAnswerUpvoteHandler gère le retrait du vote pour une réponse (authentifié)
+// @Summary      Retirer mon vote pour une réponse
+// @Description  Retire le vote de l'utilisateur connecté pour une réponse publiée
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        answerID  path      string  true  "ID de la réponse"
+// @Success      200       {object}  dtos.AnswerResponse
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse ou vote non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /answers/{answerID}/upvote [delete]
+func RemoveAnswerUpvoteHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		answer, err := services.RemoveAnswerUpvote(client, chi.URLParam(r, "answerID"), claims.UserID)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors du retrait du vote")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, answer)
+	}
+}
+
+// respondQuestionError associe les erreurs des services de questions / réponses aux codes HTTP
+func respondQuestionError(w http.ResponseWriter, err error, fallback string) {
+	msg := err.Error()
+	switch {
+	case msg == "produit non trouvé" || msg == "question non trouvée" || msg == "réponse non trouvée" || msg == "vote non trouvé":
+		utils.RespondError(w, http.StatusNotFound, msg)
+	case strings.HasPrefix(msg, "vous n'êtes pas autorisé"), strings.HasPrefix(msg, "vous ne pouvez pas"):
+		utils.RespondError(w, http.StatusForbidden, msg)
+	case strings.HasPrefix(msg, "vous avez déjà voté"):
+		utils.RespondError(w, http.StatusConflict, msg)
+	case strings.HasSuffix(msg, "est requise"),
+		strings.Contains(msg, "ne doit pas dépasser"),
+		strings.HasSuffix(msg, "contient des termes interdits"),
+		strings.HasPrefix(msg, "le motif"),
+		strings.HasPrefix(msg, "statut invalide"),
+		strings.HasPrefix(msg, "tri invalide"):
+		utils.RespondError(w, http.StatusBadRequest, msg)
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
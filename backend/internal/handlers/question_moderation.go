@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetQuestionsForModerationHandler gère la file de modération des questions (admin only)
+// @Summary      Liste des questions à modérer
+// @Description  Liste paginée de toutes les questions avec toutes leurs réponses, des plus récentes aux plus anciennes, filtrable par statut et par produit (admin uniquement)
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status     query     string  false  "Statut"  Enums(PENDING, APPROVED, REJECTED)
+// @Param        productID  query     string  false  "ID du produit"
+// @Param        page       query     int     false  "Numéro de page (défaut: 1)"
+// @Param        limit      query     int     false  "Nombre d'éléments par page (défaut: 20, max: 100)"
+// @Success      200        {object}  dtos.PaginatedQuestionsResponse
+// @Failure      400        {object}  docs.ErrorResponse  "Statut invalide"
+// @Failure      401        {object}  docs.ErrorResponse
+// @Failure      403        {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500        {object}  docs.ErrorResponse
+// @Router       /admin/questions [get]
+func GetQuestionsForModerationHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		result, err := services.GetQuestionsForModeration(client, query.Get("status"), query.Get("productID"), page, limit)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de la récupération des questions")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// GetAnswersForModerationHandler gère la file de modération des réponses (admin only)
+// @Summary      Liste des réponses à modérer
+// @Description  Liste paginée de toutes les réponses, des plus récentes aux plus anciennes, filtrable par statut (admin uniquement)
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "Statut"  Enums(PENDING, APPROVED, REJECTED)
+// @Param        page    query     int     false  "Numéro de page (défaut: 1)"
+// @Param        limit   query     int     false  "Nombre d'éléments par page (défaut: 20, max: 100)"
+// @Success      200     {object}  dtos.PaginatedAnswersResponse
+// @Failure      400     {object}  docs.ErrorResponse  "Statut invalide"
+// @Failure      401     {object}  docs.ErrorResponse
+// @Failure      403     {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500     {object}  docs.ErrorResponse
+// @Router       /admin/answers [get]
+func GetAnswersForModerationHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		result, err := services.GetAnswersForModeration(client, query.Get("status"), page, limit)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de la récupération des réponses")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// ApproveQuestionHandler gère la publication d'une question (admin only)
+// @Summary      Approuver une question
+// @Description  Publie une question en attente ou précédemment refusée : elle devient visible sur la fiche produit (admin uniquement)
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path      string  true  "ID de la question"
+// @Success      200         {object}  dtos.QuestionResponse
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404         {object}  docs.ErrorResponse  "Question non trouvée"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /admin/questions/{questionID}/approve [post]
+func ApproveQuestionHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		question, err := services.ApproveQuestion(client, chi.URLParam(r, "questionID"))
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de l'approbation de la question")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, question)
+	}
+}
+
+// RejectQuestionHandler gère le refus d'une question (admin only)
+// @Summary      Refuser une question
+// @Description  Retire une question de la fiche produit ; son auteur la voit avec le motif du refus (admin uniquement)
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path      string                        true  "ID de la question"
+// @Param        request     body      dtos.ModerateQuestionRequest  true  "Motif du refus"
+// @Success      200         {object}  dtos.QuestionResponse
+// @Failure      400         {object}  docs.ErrorResponse  "Motif manquant"
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404         {object}  docs.ErrorResponse  "Question non trouvée"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /admin/questions/{questionID}/reject [post]
+func RejectQuestionHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.ModerateQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		question, err := services.RejectQuestion(client, chi.URLParam(r, "questionID"), req)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors du refus de la question")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, question)
+	}
+}
+
+// DeleteQuestionAsAdminHandler gère la suppression d'une question par un administrateur (admin only)
+// @Summary      Supprimer une question (admin)
+// @Description  Supprime définitivement une question et ses réponses. Le motif, obligatoire, est enregistré avec l'administrateur et le texte supprimé dans le journal de modération (admin uniquement)
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        questionID  path  string                        true  "ID de la question"
+// @Param        request     body  dtos.ModerateQuestionRequest  true  "Motif de la suppression"
+// @Success      204         "No Content"
+// @Failure      400         {object}  docs.ErrorResponse  "Motif manquant"
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404         {object}  docs.ErrorResponse  "Question non trouvée"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /admin/questions/{questionID} [delete]
+func DeleteQuestionAsAdminHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.ModerateQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		if err := services.DeleteQuestionAsAdmin(client, chi.URLParam(r, "questionID"), claims.UserID, req); err != nil {
+			respondQuestionError(w, err, "Erreur lors de la suppression de la question")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ApproveAnswerHandler gère la publication d'une réponse (admin only)
+// @Summary      Approuver une réponse
+// @Description  Publie une réponse en attente ou précédemment refusée ; elle est visible dès que sa question est publiée (admin uniquement)
+// @Tags         Questions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        answerID  path      string  true  "ID de la réponse"
+// @Success      200       {object}  dtos.AnswerResponse
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse non trouvée"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/answers/{answerID}/approve [post]
+func ApproveAnswerHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		answer, err := services.ApproveAnswer(client, chi.URLParam(r, "answerID"))
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors de l'approbation de la réponse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, answer)
+	}
+}
+
+// RejectAnswerHandler gère le refus d'une réponse (admin only)
+// @Summary      Refuser une réponse
+// @Description  Retire une réponse de la fiche produit ; son auteur la voit avec le motif du refus (admin uniquement)
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        answerID  path      string                        true  "ID de la réponse"
+// @Param        request   body      dtos.ModerateQuestionRequest  true  "Motif du refus"
+// @Success      200       {object}  dtos.AnswerResponse
+// @Failure      400       {object}  docs.ErrorResponse  "Motif manquant"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse non trouvée"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/answers/{answerID}/reject [post]
+func RejectAnswerHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.ModerateQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		answer, err := services.RejectAnswer(client, chi.URLParam(r, "answerID"), req)
+		if err != nil {
+			respondQuestionError(w, err, "Erreur lors du refus de la réponse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, answer)
+	}
+}
+
+// DeleteAnswerAsAdminHandler gère la suppression d'une réponse par un administrateur (admin only)
+// @Summary      Supprimer une réponse (admin)
+// @Description  Supprime définitivement une réponse. Le motif, obligatoire, est enregistré avec l'administrateur et le texte supprimé dans le journal de modération (admin uniquement)
+// @Tags         Questions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        answerID  path  string                        true  "ID de la réponse"
+// @Param        request   body  dtos.ModerateQuestionRequest  true  "Motif de la suppression"
+// @Success      204       "No Content"
+// @Failure      400       {object}  docs.ErrorResponse  "Motif manquant"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse non trouvée"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/answers/{answerID} [delete]
+func DeleteAnswerAsAdminHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.ModerateQuestionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		if err := services.DeleteAnswerAsAdmin(client, chi.URLParam(r, "answerID"), claims.UserID, req); err != nil {
+			respondQuestionError(w, err, "Erreur lors de la suppression de la réponse")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/payments"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateReturnHandler gère la demande de retour d'articles d'une commande livrée
+// @Summary      Demander un retour
+// @Description  Demande le retour de tout ou partie des articles d'une commande livrée, avec un motif. La demande doit être faite dans les RETURN_WINDOW_DAYS jours suivant la livraison (14 par défaut) ;
+// @Description  un article ne peut pas être retourné au-delà de la quantité commandée (les retours refusés ne comptent pas).
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "ID de la commande"
+// @Param        request  body      dtos.CreateReturnRequest  true  "Articles à retourner"
+// @Success      201      {object}  dtos.ReturnResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Motif manquant, article inconnu ou quantité invalide"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      404      {object}  docs.ErrorResponse  "Commande non trouvée"
+// @Failure      409      {object}  docs.ErrorResponse  "Commande non livrée ou délai de retour dépassé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /orders/{id}/returns [post]
+func CreateReturnHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de commande requis")
+			return
+		}
+
+		var req dtos.CreateReturnRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		result, err := services.CreateReturn(client, orderID, claims.UserID, req)
+		if err != nil {
+			respondReturnError(w, err, "Erreur lors de la création du retour")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, result)
+	}
+}
+
+// GetOrderReturnsHandler gère la récupération des retours d'une commande
+// @Summary      Retours d'une commande
+// @Description  Liste les demandes de retour d'une commande et leur statut (propriétaire de la commande ou admin)
+// @Tags         Returns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID de la commande"
+// @Success      200  {array}   dtos.ReturnResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      404  {object}  docs.ErrorResponse  "Commande non trouvée"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /orders/{id}/returns [get]
+func GetOrderReturnsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de commande requis")
+			return
+		}
+
+		isAdmin := claims.Role == "ADMIN"
+		result, err := services.GetOrderReturns(client, orderID, claims.UserID, isAdmin)
+		if err != nil {
+			respondReturnError(w, err, "Erreur lors de la récupération des retours")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// GetAllReturnsHandler gère la récupération de toutes les demandes de retour (admin only)
+// @Summary      Liste des retours
+// @Description  Liste toutes les demandes de retour, des plus récentes aux plus anciennes, filtrables par statut (admin uniquement)
+// @Tags         Returns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "Statut"  Enums(REQUESTED, APPROVED, REJECTED, RECEIVED)
+// @Success      200     {array}   dtos.ReturnResponse
+// @Failure      400     {object}  docs.ErrorResponse  "Statut invalide"
+// @Failure      401     {object}  docs.ErrorResponse
+// @Failure      403     {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500     {object}  docs.ErrorResponse
+// @Router       /admin/returns [get]
+func GetAllReturnsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := services.GetAllReturns(client, r.URL.Query().Get("status"))
+		if err != nil {
+			respondReturnError(w, err, "Erreur lors de la récupération des retours")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// GetReturnHandler gère la récupération d'une demande de retour (admin only)
+// @Summary      Détails d'un retour
+// @Description  Récupère une demande de retour avec ses articles et le montant remboursable (admin uniquement)
+// @Tags         Returns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID du retour"
+// @Success      200  {object}  dtos.ReturnResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse  "Retour non trouvé"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/returns/{id} [get]
+func GetReturnHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		returnID := chi.URLParam(r, "id")
+		if returnID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de retour requis")
+			return
+		}
+
+		result, err := services.GetReturnByID(client, returnID)
+		if err != nil {
+			respondReturnError(w, err, "Erreur lors de la récupération du retour")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// ApproveReturnHandler gère l'acceptation d'une demande de retour (admin only)
+// @Summary      Approuver un retour
+// @Description  Accepte une demande de retour en attente ; le client peut renvoyer les articles (admin uniquement)
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true   "ID du retour"
+// @Param        request  body      dtos.ReviewReturnRequest  false  "Note pour le client"
+// @Success      200      {object}  dtos.ReturnResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse  "Retour non trouvé"
+// @Failure      409      {object}  docs.ErrorResponse  "Retour déjà traité"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/returns/{id}/approve [post]
+func ApproveReturnHandler(client *db.PrismaClient) http.HandlerFunc {
+	return reviewReturnHandler(client, services.ApproveReturn, "Erreur lors de l'approbation du retour")
+}
+
+// RejectReturnHandler gère le refus d'une demande de retour (admin only)
+// @Summary      Refuser un retour
+// @Description  Refuse une demande de retour en attente ; les articles redeviennent retournables dans le délai de retour (admin uniquement)
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                    true   "ID du retour"
+// @Param        request  body      dtos.ReviewReturnRequest  false  "Motif du refus"
+// @Success      200      {object}  dtos.ReturnResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse  "Retour non trouvé"
+// @Failure      409      {object}  docs.ErrorResponse  "Retour déjà traité"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/returns/{id}/reject [post]
+func RejectReturnHandler(client *db.PrismaClient) http.HandlerFunc {
+	return reviewReturnHandler(client, services.RejectReturn, "Erreur lors du refus du retour")
+}
+
+// ReceiveReturnHandler gère la réception des articles d'un retour (admin only)
+// @Summary      Réceptionner un retour
+// @Description  Enregistre la réception des articles d'un retour approuvé : remise en stock des variantes et remboursement (total par défaut, ou partiel via refundAmount) sur le paiement de la commande.
+// @Description  Le remboursement est reporté dans le total remboursé de la commande (refundedAmount) et déduit du chiffre d'affaires des statistiques (admin uniquement)
+// @Tags         Returns
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                     true   "ID du retour"
+// @Param        request  body      dtos.ReceiveReturnRequest  false  "Montant remboursé"
+// @Success      200      {object}  dtos.ReturnResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Montant invalide"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse  "Retour non trouvé"
+// @Failure      409      {object}  docs.ErrorResponse  "Retour non approuvé"
+// @Failure      502      {object}  docs.ErrorResponse  "Erreur du prestataire de paiement"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/returns/{id}/receive [post]
+func ReceiveReturnHandler(client *db.PrismaClient, registry *payments.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		returnID := chi.URLParam(r, "id")
+		if returnID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de retour requis")
+			return
+		}
+
+		var req dtos.ReceiveReturnRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		result, err := services.ReceiveReturn(client, registry, returnID, req)
+		if err != nil {
+			respondReturnError(w, err, "Erreur lors de la réception du retour")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// reviewReturnHandler factorise l'approbation et le refus d'un retour
+func reviewReturnHandler(client *db.PrismaClient, review func(*db.PrismaClient, string, dtos.ReviewReturnRequest) (*dtos.ReturnResponse, error), fallback string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		returnID := chi.URLParam(r, "id")
+		if returnID == "" {
+			utils.RespondError(w, http.StatusBadRequest, "ID de retour requis")
+			return
+		}
+
+		var req dtos.ReviewReturnRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		result, err := review(client, returnID, req)
+		if err != nil {
+			respondReturnError(w, err, fallback)
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// respondReturnError traduit les erreurs du service de retours en réponses HTTP
+func respondReturnError(w http.ResponseWriter, err error, fallback string) {
+	msg := err.Error()
+	switch {
+	case msg == "commande non trouvée" || msg == "accès non autorisé à cette commande" || msg == "retour non trouvé":
+		utils.RespondError(w, http.StatusNotFound, msg)
+	case strings.HasPrefix(msg, "seul"), strings.HasPrefix(msg, "délai de retour dépassé"):
+		utils.RespondError(w, http.StatusConflict, msg)
+	case strings.HasPrefix(msg, "erreur du prestataire de paiement"):
+		utils.RespondError(w, http.StatusBadGateway, msg)
+	case strings.HasPrefix(msg, "erreur"):
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	default:
+		utils.RespondError(w, http.StatusBadRequest, msg)
+	}
+}
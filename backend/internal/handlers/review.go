@@ -3,6 +3,8 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"api/internal/db"
 	"api/internal/docs"
@@ -19,15 +21,18 @@ var _ = docs.ErrorResponse{}
 
 // CreateReviewHandler gère la création d'un avis (authentifié)
 // @Summary      Créer un avis pour un produit
-// @Description  Permet à un utilisateur authentifié de laisser un avis (note et commentaire) sur un produit
+// @Description  Permet à un utilisateur authentifié de laisser un avis (note et commentaire) sur un produit.
+// @Description  L'avis est publié après validation par un administrateur (statut PENDING), sauf si sa note atteint REVIEW_AUTO_APPROVE_MIN_RATING. Un commentaire contenant un terme de REVIEW_BANNED_WORDS est refusé.
+// @Description  L'avis est marqué verifiedPurchase si l'utilisateur a une commande livrée contenant le produit ; avec REVIEW_REQUIRE_PURCHASE=true, les autres avis sont refusés (403).
 // @Tags         Reviews
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request  body      dtos.CreateReviewRequest  true  "Données de l'avis"
 // @Success      201      {object}  dtos.ReviewResponse
-// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Note invalide ou termes interdits"
 // @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Produit non reçu (REVIEW_REQUIRE_PURCHASE)"
 // @Failure      404      {object}  docs.ErrorResponse
 // @Failure      500      {object}  docs.ErrorResponse
 // @Router       /products/{productID}/reviews [post]
@@ -64,11 +69,7 @@ func CreateReviewHandler(client *db.PrismaClient) http.HandlerFunc {
 
 		review, err := services.CreateReview(client, userID, req)
 		if err != nil {
-			if err.Error() == "produit non trouvé" {
-				utils.RespondError(w, http.StatusNotFound, err.Error())
-			} else {
-				utils.RespondError(w, http.StatusInternalServerError, err.Error())
-			}
+			respondReviewError(w, err, "Erreur lors de la création de l'avis")
 			return
 		}
 
@@ -78,33 +79,42 @@ func CreateReviewHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // GetProductReviewsHandler gère la récupération des avis d'un produit (authentifié)
 // @Summary      Liste des avis d'un produit
-// @Description  Récupère tous les avis d'un produit avec statistiques (moyenne, total)
+// @Description  Récupère une page des avis publiés (approuvés) d'un produit avec les statistiques du produit (moyenne, total, histogramme). Avec ?verifiedOnly=true, seuls les achats vérifiés (auteur ayant reçu le produit) sont retenus, statistiques comprises.
+// @Description  Les filtres ?rating=4,5 (notes exactes) et ?minRating=4 réduisent la liste sans changer les statistiques ; total indique le nombre d'avis correspondants. votedHelpful indique les avis pour lesquels l'utilisateur connecté a voté.
 // @Tags         Reviews
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        productID  path      string  true  "ID du produit"
-// @Success      200        {object}  dtos.ProductReviewsResponse
-// @Failure      400        {object}  docs.ErrorResponse
-// @Failure      401        {object}  docs.ErrorResponse
-// @Failure      404        {object}  docs.ErrorResponse
-// @Failure      500        {object}  docs.ErrorResponse
+// @Param        productID     path      string  true   "ID du produit"
+// @Param        verifiedOnly  query     bool    false  "Uniquement les achats vérifiés (défaut: false)"
+// @Param        rating        query     string  false  "Note(s) exacte(s), séparées par des virgules (ex: 4,5)"
+// @Param        minRating     query     int     false  "Note minimale (1 à 5)"
+// @Param        sort          query     string  false  "Tri (défaut: recent)"  Enums(recent, helpful, rating_high, rating_low)
+// @Param        page          query     int     false  "Numéro de page (défaut: 1)"
+// @Param        limit         query     int     false  "Nombre d'éléments par page (défaut: 10, max: 50)"
+// @Success      200           {object}  dtos.ProductReviewsResponse
+// @Failure      400           {object}  docs.ErrorResponse  "Tri ou filtre de note invalide"
+// @Failure      401           {object}  docs.ErrorResponse
+// @Failure      404           {object}  docs.ErrorResponse
+// @Failure      500           {object}  docs.ErrorResponse
 // @Router       /products/{productID}/reviews [get]
 func GetProductReviewsHandler(client *db.PrismaClient) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
 		productID := chi.URLParam(r, "productID")
 		if productID == "" {
 			utils.RespondError(w, http.StatusBadRequest, "ID de produit requis")
 			return
 		}
 
-		reviews, err := services.GetProductReviews(client, productID)
+		reviews, err := services.GetProductReviews(client, productID, claims.UserID, parseReviewFilters(r))
 		if err != nil {
-			if err.Error() == "produit non trouvé" {
-				utils.RespondError(w, http.StatusNotFound, err.Error())
-			} else {
-				utils.RespondError(w, http.StatusInternalServerError, err.Error())
-			}
+			respondReviewError(w, err, "Erreur lors de la récupération des avis")
 			return
 		}
 
@@ -112,9 +122,39 @@ func GetProductReviewsHandler(client *db.PrismaClient) http.HandlerFunc {
 	}
 }
 
+// parseReviewFilters lit les filtres, le tri et la pagination de la liste des avis
+// Une note non numérique est transmise comme 0, refusé par le service
+func parseReviewFilters(r *http.Request) dtos.ReviewFilters {
+	query := r.URL.Query()
+
+	var ratings []int
+	for _, value := range query["rating"] {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				rating, _ := strconv.Atoi(part)
+				ratings = append(ratings, rating)
+			}
+		}
+	}
+
+	verifiedOnly, _ := strconv.ParseBool(query.Get("verifiedOnly"))
+	minRating, _ := strconv.Atoi(query.Get("minRating"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	return dtos.ReviewFilters{
+		VerifiedOnly: verifiedOnly,
+		Ratings:      ratings,
+		MinRating:    minRating,
+		Sort:         query.Get("sort"),
+		Page:         page,
+		Limit:        limit,
+	}
+}
+
 // GetUserReviewHandler gère la récupération de l'avis d'un utilisateur pour un produit (authentifié)
 // @Summary      Avis de l'utilisateur pour un produit
-// @Description  Récupère l'avis de l'utilisateur connecté pour un produit spécifique
+// @Description  Récupère l'avis de l'utilisateur connecté pour un produit spécifique, avec son statut de modération et le motif d'un éventuel refus
 // @Tags         Reviews
 // @Accept       json
 // @Produce      json
@@ -159,7 +199,7 @@ func GetUserReviewHandler(client *db.PrismaClient) http.HandlerFunc {
 
 // UpdateReviewHandler gère la mise à jour d'un avis (authentifié)
 // @Summary      Mettre à jour un avis
-// @Description  Permet à un utilisateur de modifier son propre avis
+// @Description  Permet à un utilisateur de modifier son propre avis ; l'avis modifié repasse en modération (sauf approbation automatique)
 // @Tags         Reviews
 // @Accept       json
 // @Produce      json
@@ -203,13 +243,7 @@ func UpdateReviewHandler(client *db.PrismaClient) http.HandlerFunc {
 
 		review, err := services.UpdateReview(client, reviewID, userID, req)
 		if err != nil {
-			if err.Error() == "avis non trouvé" {
-				utils.RespondError(w, http.StatusNotFound, err.Error())
-			} else if err.Error() == "vous n'êtes pas autorisé à modifier cet avis" {
-				utils.RespondError(w, http.StatusForbidden, err.Error())
-			} else {
-				utils.RespondError(w, http.StatusInternalServerError, err.Error())
-			}
+			respondReviewError(w, err, "Erreur lors de la mise à jour de l'avis")
 			return
 		}
 
@@ -263,3 +297,90 @@ func DeleteReviewHandler(client *db.PrismaClient) http.HandlerFunc {
 		utils.RespondJSON(w, http.StatusOK, map[string]string{"message": "Avis supprimé avec succès"})
 	}
 }
+
+// VoteReviewHelpfulHandler gère le vote « utile » sur un avis (authentifié)
+// @Summary      Voter « avis utile »
+// @Description  Indique qu'un avis publié a été utile. Un seul vote par utilisateur et par avis ; impossible de voter pour son propre avis
+// @Tags         Reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path      string  true  "ID de l'avis"
+// @Success      200       {object}  dtos.ReviewResponse
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Vote pour son propre avis"
+// @Failure      404       {object}  docs.ErrorResponse  "Avis non trouvé ou non publié"
+// @Failure      409       {object}  docs.ErrorResponse  "Vote déjà enregistré"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /reviews/{reviewID}/helpful [post]
+func VoteReviewHelpfulHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		review, err := services.VoteReviewHelpful(client, chi.URLParam(r, "reviewID"), claims.UserID)
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors de l'enregistrement du vote")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, review)
+	}
+}
+
+// RemoveReviewHelpfulVoteHandler gère le retrait du vote « utile » sur un avis (authentifié)
+// @Summary      Retirer mon vote « avis utile »
+// @Description  Retire le vote « utile » de l'utilisateur connecté sur un avis publié
+// @Tags         Reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path      string  true  "ID de l'avis"
+// @Success      200       {object}  dtos.ReviewResponse
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      404       {object}  docs.ErrorResponse  "Avis ou vote non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /reviews/{reviewID}/helpful [delete]
+func RemoveReviewHelpfulVoteHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		review, err := services.RemoveReviewHelpfulVote(client, chi.URLParam(r, "reviewID"), claims.UserID)
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors du retrait du vote")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, review)
+	}
+}
+
+// respondReviewError associe les erreurs du service des avis aux codes HTTP
+func respondReviewError(w http.ResponseWriter, err error, fallback string) {
+	msg := err.Error()
+	switch {
+	case msg == "produit non trouvé" || msg == "avis non trouvé" || msg == "vote non trouvé" || msg == "réponse non trouvée":
+		utils.RespondError(w, http.StatusNotFound, msg)
+	case strings.HasPrefix(msg, "vous n'êtes pas autorisé"), strings.HasPrefix(msg, "vous ne pouvez pas"):
+		utils.RespondError(w, http.StatusForbidden, msg)
+	case strings.HasPrefix(msg, "vous avez déjà voté"), msg == "une réponse existe déjà pour cet avis",
+		msg == "seul un avis publié peut recevoir une réponse":
+		utils.RespondError(w, http.StatusConflict, msg)
+	case msg == "le commentaire contient des termes interdits",
+		msg == "aucune modification à effectuer",
+		strings.HasPrefix(msg, "le motif"),
+		msg == "la réponse est requise",
+		strings.HasPrefix(msg, "la réponse ne doit pas dépasser"),
+		strings.HasPrefix(msg, "statut d'avis invalide"),
+		strings.HasPrefix(msg, "tri invalide"),
+		strings.HasPrefix(msg, "filtre de note invalide"):
+		utils.RespondError(w, http.StatusBadRequest, msg)
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
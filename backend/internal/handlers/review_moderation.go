@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetReviewsForModerationHandler gère la file de modération des avis (admin only)
+// @Summary      Liste des avis à modérer
+// @Description  Liste paginée de tous les avis, des plus récents aux plus anciens, filtrable par statut et par produit (admin uniquement)
+// @Tags         Reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status     query     string  false  "Statut"  Enums(PENDING, APPROVED, REJECTED)
+// @Param        productID  query     string  false  "ID du produit"
+// @Param        page       query     int     false  "Numéro de page (défaut: 1)"
+// @Param        limit      query     int     false  "Nombre d'éléments par page (défaut: 20, max: 100)"
+// @Success      200        {object}  dtos.PaginatedReviewsResponse
+// @Failure      400        {object}  docs.ErrorResponse  "Statut invalide"
+// @Failure      401        {object}  docs.ErrorResponse
+// @Failure      403        {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500        {object}  docs.ErrorResponse
+// @Router       /admin/reviews [get]
+func GetReviewsForModerationHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		result, err := services.GetReviewsForModeration(client, query.Get("status"), query.Get("productID"), page, limit)
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors de la récupération des avis")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, result)
+	}
+}
+
+// ApproveReviewHandler gère la publication d'un avis (admin only)
+// @Summary      Approuver un avis
+// @Description  Publie un avis en attente ou précédemment refusé : il devient visible sur la fiche produit (admin uniquement)
+// @Tags         Reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path      string  true  "ID de l'avis"
+// @Success      200       {object}  dtos.ReviewResponse
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Avis non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/reviews/{reviewID}/approve [post]
+func ApproveReviewHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		review, err := services.ApproveReview(client, chi.URLParam(r, "reviewID"))
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors de l'approbation de l'avis")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, review)
+	}
+}
+
+// RejectReviewHandler gère le refus d'un avis (admin only)
+// @Summary      Refuser un avis
+// @Description  Retire un avis de la fiche produit ; son auteur le voit toujours avec le motif du refus, et l'avis repasse en modération s'il le modifie (admin uniquement)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path      string                      true  "ID de l'avis"
+// @Param        request   body      dtos.ModerateReviewRequest  true  "Motif du refus"
+// @Success      200       {object}  dtos.ReviewResponse
+// @Failure      400       {object}  docs.ErrorResponse  "Motif manquant"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Avis non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/reviews/{reviewID}/reject [post]
+func RejectReviewHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.ModerateReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		review, err := services.RejectReview(client, chi.URLParam(r, "reviewID"), req)
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors du refus de l'avis")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, review)
+	}
+}
+
+// DeleteReviewAsAdminHandler gère la suppression d'un avis par un administrateur (admin only)
+// @Summary      Supprimer un avis (admin)
+// @Description  Supprime définitivement un avis. Le motif, obligatoire, est enregistré avec l'administrateur et le commentaire supprimé dans le journal de modération ; pour masquer un avis en conservant une trace visible par son auteur, préférez le refus (admin uniquement)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path  string                      true  "ID de l'avis"
+// @Param        request   body  dtos.ModerateReviewRequest  true  "Motif de la suppression"
+// @Success      204       "No Content"
+// @Failure      400       {object}  docs.ErrorResponse  "Motif manquant"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Avis non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/reviews/{reviewID} [delete]
+func DeleteReviewAsAdminHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.ModerateReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		if err := services.DeleteReviewAsAdmin(client, chi.URLParam(r, "reviewID"), claims.UserID, req); err != nil {
+			respondReviewError(w, err, "Erreur lors de la suppression de l'avis")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreateReviewReplyHandler gère la réponse publique de la marque à un avis (admin only)
+// @Summary      Répondre à un avis
+// @Description  Publie la réponse du service client sous un avis publié (une seule réponse par avis). L'auteur de l'avis est prévenu par e-mail (événement "review.replied") (admin uniquement)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path      string                   true  "ID de l'avis"
+// @Param        request   body      dtos.ReviewReplyRequest  true  "Texte de la réponse"
+// @Success      201       {object}  dtos.ReviewResponse
+// @Failure      400       {object}  docs.ErrorResponse  "Réponse vide ou trop longue"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Avis non trouvé"
+// @Failure      409       {object}  docs.ErrorResponse  "Une réponse existe déjà, ou avis non publié"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/reviews/{reviewID}/reply [post]
+func CreateReviewReplyHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.ReviewReplyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		review, err := services.CreateReviewReply(client, chi.URLParam(r, "reviewID"), claims.UserID, req)
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors de la création de la réponse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, review)
+	}
+}
+
+// UpdateReviewReplyHandler gère la correction de la réponse de la marque à un avis (admin only)
+// @Summary      Modifier la réponse à un avis
+// @Description  Remplace le texte de la réponse publiée sous un avis ; l'auteur de l'avis n'est pas prévenu à nouveau (admin uniquement)
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path      string                   true  "ID de l'avis"
+// @Param        request   body      dtos.ReviewReplyRequest  true  "Texte de la réponse"
+// @Success      200       {object}  dtos.ReviewResponse
+// @Failure      400       {object}  docs.ErrorResponse  "Réponse vide ou trop longue"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Avis ou réponse non trouvé"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/reviews/{reviewID}/reply [put]
+func UpdateReviewReplyHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.ReviewReplyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		review, err := services.UpdateReviewReply(client, chi.URLParam(r, "reviewID"), claims.UserID, req)
+		if err != nil {
+			respondReviewError(w, err, "Erreur lors de la mise à jour de la réponse")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, review)
+	}
+}
+
+// DeleteReviewReplyHandler gère le retrait de la réponse de la marque à un avis (admin only)
+// @Summary      Supprimer la réponse à un avis
+// @Description  Retire la réponse publiée sous un avis (admin uniquement)
+// @Tags         Reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reviewID  path  string  true  "ID de l'avis"
+// @Success      204       "No Content"
+// @Failure      401       {object}  docs.ErrorResponse
+// @Failure      403       {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404       {object}  docs.ErrorResponse  "Réponse non trouvée"
+// @Failure      500       {object}  docs.ErrorResponse
+// @Router       /admin/reviews/{reviewID}/reply [delete]
+func DeleteReviewReplyHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := services.DeleteReviewReply(client, chi.URLParam(r, "reviewID")); err != nil {
+			respondReviewError(w, err, "Erreur lors de la suppression de la réponse")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetShippingMethodsHandler gère la récupération des modes de livraison proposés
+// @Summary      Modes de livraison
+// @Description  Liste les modes de livraison actifs, du moins cher au plus cher. Les frais de port sont calculés à la commande selon la règle du mode (forfait ou au poids) et le seuil de gratuité.
+// @Tags         Shipping
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dtos.ShippingMethodResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /shipping-methods [get]
+func GetShippingMethodsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods, err := services.GetShippingMethods(client, true)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des modes de livraison")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, methods)
+	}
+}
+
+// GetAllShippingMethodsHandler gère la récupération de tous les modes de livraison (admin only)
+// @Summary      Liste tous les modes de livraison
+// @Description  Liste tous les modes de livraison, y compris les inactifs (admin uniquement)
+// @Tags         Shipping
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dtos.ShippingMethodResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/shipping-methods [get]
+func GetAllShippingMethodsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods, err := services.GetShippingMethods(client, false)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des modes de livraison")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, methods)
+	}
+}
+
+// CreateShippingMethodHandler gère la création d'un mode de livraison (admin only)
+// @Summary      Créer un mode de livraison
+// @Description  Crée un mode de livraison : forfait (FLAT) ou prix de base + prix par kg entamé (WEIGHT), avec un seuil de gratuité optionnel (admin uniquement)
+// @Tags         Shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dtos.ShippingMethodRequest  true  "Mode de livraison"
+// @Success      201      {object}  dtos.ShippingMethodResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      409      {object}  docs.ErrorResponse  "Nom déjà utilisé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/shipping-methods [post]
+func CreateShippingMethodHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.ShippingMethodRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		method, err := services.CreateShippingMethod(client, req)
+		if err != nil {
+			respondShippingMethodError(w, err, "Erreur lors de la création du mode de livraison")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, method)
+	}
+}
+
+// UpdateShippingMethodHandler gère la mise à jour d'un mode de livraison (admin only)
+// @Summary      Mettre à jour un mode de livraison
+// @Description  Remplace les paramètres d'un mode de livraison (admin uniquement). Les commandes passées conservent leurs frais de port.
+// @Tags         Shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                      true  "ID du mode de livraison"
+// @Param        request  body      dtos.ShippingMethodRequest  true  "Nouveaux paramètres"
+// @Success      200      {object}  dtos.ShippingMethodResponse
+// @Failure      400      {object}  docs.ErrorResponse
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      409      {object}  docs.ErrorResponse  "Nom déjà utilisé"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/shipping-methods/{id} [put]
+func UpdateShippingMethodHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.ShippingMethodRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		method, err := services.UpdateShippingMethod(client, chi.URLParam(r, "id"), req)
+		if err != nil {
+			respondShippingMethodError(w, err, "Erreur lors de la mise à jour du mode de livraison")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, method)
+	}
+}
+
+// DeleteShippingMethodHandler gère la suppression d'un mode de livraison (admin only)
+// @Summary      Supprimer un mode de livraison
+// @Description  Supprime un mode de livraison (admin uniquement). Les commandes conservent son nom et leurs frais de port ; pour le retirer temporairement, préférez active=false.
+// @Tags         Shipping
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID du mode de livraison"
+// @Success      204  "No Content"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/shipping-methods/{id} [delete]
+func DeleteShippingMethodHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := services.DeleteShippingMethod(client, chi.URLParam(r, "id")); err != nil {
+			respondShippingMethodError(w, err, "Erreur lors de la suppression du mode de livraison")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// respondShippingMethodError associe les erreurs du service des modes de livraison aux codes HTTP
+func respondShippingMethodError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "mode de livraison non trouvé":
+		utils.RespondError(w, http.StatusNotFound, err.Error())
+	case err.Error() == "un mode de livraison avec ce nom existe déjà":
+		utils.RespondError(w, http.StatusConflict, err.Error())
+	case err.Error() == "le nom du mode de livraison est requis",
+		strings.HasPrefix(err.Error(), "type de tarif invalide"),
+		err.Error() == "les montants ne peuvent pas être négatifs",
+		err.Error() == "le délai de livraison ne peut pas être négatif":
+		utils.RespondError(w, http.StatusBadRequest, err.Error())
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/middlewares"
+	"api/internal/services"
+	"api/internal/utils"
+)
+
+// GetMySkinProfileHandler gère la récupération du profil de peau de l'utilisateur connecté
+// @Summary      Mon profil de peau
+// @Description  Récupère le profil de peau de l'utilisateur connecté (type de peau, préoccupations, ingrédients à éviter). Retourne un profil vide s'il n'a jamais été renseigné.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dtos.SkinProfileResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /users/me/profile [get]
+func GetMySkinProfileHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		profile, err := services.GetSkinProfile(client, claims.UserID)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération du profil de peau")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, profile)
+	}
+}
+
+// UpdateMySkinProfileHandler gère l'enregistrement du profil de peau de l'utilisateur connecté
+// @Summary      Enregistrer mon profil de peau
+// @Description  Crée ou remplace le profil de peau de l'utilisateur connecté. Les ingrédients à éviter sont exclus des recommandations.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dtos.SkinProfileRequest  true  "Profil de peau"
+// @Success      200      {object}  dtos.SkinProfileResponse
+// @Failure      400      {object}  docs.ErrorResponse  "Type de peau ou préoccupation invalide"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /users/me/profile [put]
+func UpdateMySkinProfileHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		var req dtos.SkinProfileRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		profile, err := services.UpdateSkinProfile(client, claims.UserID, req)
+		if err != nil {
+			if isProductFilterError(err) {
+				utils.RespondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de l'enregistrement du profil de peau")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, profile)
+	}
+}
+
+// GetRecommendedProductsHandler gère la récupération des recommandations personnalisées
+// @Summary      Produits recommandés
+// @Description  Classe les produits selon le profil de peau de l'utilisateur connecté, la note moyenne des avis et les achats croisés des autres clients.
+// @Description  Les produits achetés récemment (RECOMMENDATION_RECENT_DAYS, 90 jours par défaut), en rupture de stock ou contenant un ingrédient à éviter sont exclus.
+// @Tags         Products
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit  query     int  false  "Nombre de produits (défaut: 10, max: 50)"
+// @Success      200    {array}   dtos.RecommendedProductResponse
+// @Failure      401    {object}  docs.ErrorResponse
+// @Failure      500    {object}  docs.ErrorResponse
+// @Router       /products/recommended [get]
+func GetRecommendedProductsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middlewares.GetUserClaims(r)
+		if !ok {
+			utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+			return
+		}
+
+		limit := 10
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = l
+		}
+
+		products, err := services.GetRecommendedProducts(client, claims.UserID, limit)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors du calcul des recommandations")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, products)
+	}
+}
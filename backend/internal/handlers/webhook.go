@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetWebhookEndpointsHandler gère la liste des destinations de webhooks (admin only)
+// @Summary      Liste des webhooks
+// @Description  Liste les destinations de webhooks sortants, actives ou non (admin uniquement)
+// @Tags         Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dtos.WebhookEndpointResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/webhooks [get]
+func GetWebhookEndpointsHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoints, err := services.GetWebhookEndpoints(client)
+		if err != nil {
+			utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la récupération des webhooks")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, endpoints)
+	}
+}
+
+// GetWebhookEndpointHandler gère la récupération d'une destination de webhooks (admin only)
+// @Summary      Détails d'un webhook
+// @Description  Retourne une destination de webhooks, sans sa clé de signature (admin uniquement)
+// @Tags         Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID du webhook"
+// @Success      200  {object}  dtos.WebhookEndpointResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Router       /admin/webhooks/{id} [get]
+func GetWebhookEndpointHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoint, err := services.GetWebhookEndpoint(client, chi.URLParam(r, "id"))
+		if err != nil {
+			respondWebhookError(w, err, "Erreur lors de la récupération du webhook")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, endpoint)
+	}
+}
+
+// CreateWebhookEndpointHandler gère la création d'une destination de webhooks (admin only)
+// @Summary      Créer un webhook
+// @Description  Enregistre une URL appelée en POST pour chaque événement des types choisis (order.created, order.status_changed, order.refunded, product.stock_changed, review.created, review.replied, user.registered, ou "*" pour tous).
+// @Description  Le corps est {"id", "type", "createdAt", "data"} ; il est signé avec la clé retournée (en-tête Webhook-Signature "t=<timestamp>,v1=<hex HMAC-SHA256(secret, "<timestamp>.<corps>")>"). La clé n'est plus affichée ensuite : en cas de perte, générez-en une nouvelle (rotate-secret).
+// @Description  Toute réponse hors 2xx est retentée avec un délai exponentiel (WEBHOOK_MAX_ATTEMPTS tentatives) ; le webhook est désactivé après WEBHOOK_DISABLE_AFTER_FAILURES échecs d'affilée (admin uniquement).
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dtos.WebhookEndpointRequest  true  "Destination"
+// @Success      201      {object}  dtos.WebhookEndpointSecretResponse
+// @Failure      400      {object}  docs.ErrorResponse  "URL invalide ou type d'événement inconnu"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/webhooks [post]
+func CreateWebhookEndpointHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.WebhookEndpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		endpoint, err := services.CreateWebhookEndpoint(client, req)
+		if err != nil {
+			respondWebhookError(w, err, "Erreur lors de la création du webhook")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusCreated, endpoint)
+	}
+}
+
+// UpdateWebhookEndpointHandler gère la mise à jour d'une destination de webhooks (admin only)
+// @Summary      Mettre à jour un webhook
+// @Description  Remplace l'URL, la description et les types d'événements d'un webhook. Réactiver (active=true) un webhook désactivé remet à zéro son compteur d'échecs et reprend les livraisons en attente (admin uniquement).
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                       true  "ID du webhook"
+// @Param        request  body      dtos.WebhookEndpointRequest  true  "Destination"
+// @Success      200      {object}  dtos.WebhookEndpointResponse
+// @Failure      400      {object}  docs.ErrorResponse  "URL invalide ou type d'événement inconnu"
+// @Failure      401      {object}  docs.ErrorResponse
+// @Failure      403      {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404      {object}  docs.ErrorResponse
+// @Failure      500      {object}  docs.ErrorResponse
+// @Router       /admin/webhooks/{id} [put]
+func UpdateWebhookEndpointHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dtos.WebhookEndpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "JSON invalide")
+			return
+		}
+
+		endpoint, err := services.UpdateWebhookEndpoint(client, chi.URLParam(r, "id"), req)
+		if err != nil {
+			respondWebhookError(w, err, "Erreur lors de la mise à jour du webhook")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, endpoint)
+	}
+}
+
+// RotateWebhookSecretHandler gère la rotation de la clé de signature d'un webhook (admin only)
+// @Summary      Changer la clé d'un webhook
+// @Description  Génère une nouvelle clé de signature et la retourne ; l'ancienne est aussitôt invalide, y compris pour les livraisons en attente (admin uniquement)
+// @Tags         Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID du webhook"
+// @Success      200  {object}  dtos.WebhookEndpointSecretResponse
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/webhooks/{id}/rotate-secret [post]
+func RotateWebhookSecretHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoint, err := services.RotateWebhookSecret(client, chi.URLParam(r, "id"))
+		if err != nil {
+			respondWebhookError(w, err, "Erreur lors de la rotation de la clé du webhook")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, endpoint)
+	}
+}
+
+// DeleteWebhookEndpointHandler gère la suppression d'une destination de webhooks (admin only)
+// @Summary      Supprimer un webhook
+// @Description  Supprime un webhook et son journal de livraisons (admin uniquement). Pour suspendre les envois, préférez active=false.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "ID du webhook"
+// @Success      204  "No Content"
+// @Failure      401  {object}  docs.ErrorResponse
+// @Failure      403  {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404  {object}  docs.ErrorResponse
+// @Failure      500  {object}  docs.ErrorResponse
+// @Router       /admin/webhooks/{id} [delete]
+func DeleteWebhookEndpointHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := services.DeleteWebhookEndpoint(client, chi.URLParam(r, "id")); err != nil {
+			respondWebhookError(w, err, "Erreur lors de la suppression du webhook")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetWebhookDeliveriesHandler gère le journal des livraisons d'un webhook (admin only)
+// @Summary      Journal des livraisons
+// @Description  Liste les livraisons d'un webhook, des plus récentes aux plus anciennes, avec le résultat de la dernière tentative (admin uniquement)
+// @Tags         Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true   "ID du webhook"
+// @Param        status  query     string  false  "Filtrer par statut"  Enums(PENDING, SUCCEEDED, FAILED)
+// @Param        limit   query     int     false  "Nombre de livraisons (défaut: 50, max: 200)"
+// @Success      200     {array}   dtos.WebhookDeliveryResponse
+// @Failure      400     {object}  docs.ErrorResponse  "Statut invalide"
+// @Failure      401     {object}  docs.ErrorResponse
+// @Failure      403     {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404     {object}  docs.ErrorResponse
+// @Failure      500     {object}  docs.ErrorResponse
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func GetWebhookDeliveriesHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 50
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = min(l, 200)
+		}
+
+		deliveries, err := services.GetWebhookDeliveries(client, chi.URLParam(r, "id"), r.URL.Query().Get("status"), limit)
+		if err != nil {
+			respondWebhookError(w, err, "Erreur lors de la récupération des livraisons")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusOK, deliveries)
+	}
+}
+
+// RedeliverWebhookHandler gère la relivraison manuelle d'un webhook (admin only)
+// @Summary      Relivrer un webhook
+// @Description  Programme un nouvel envoi immédiat d'une livraison, avec le même corps et le même identifiant d'événement (admin uniquement). La livraison d'origine reste dans le journal.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string  true  "ID du webhook"
+// @Param        deliveryID  path      string  true  "ID de la livraison"
+// @Success      202         {object}  dtos.WebhookDeliveryResponse
+// @Failure      401         {object}  docs.ErrorResponse
+// @Failure      403         {object}  docs.ErrorResponse  "Accès refusé - Admin requis"
+// @Failure      404         {object}  docs.ErrorResponse
+// @Failure      409         {object}  docs.ErrorResponse  "Webhook désactivé"
+// @Failure      500         {object}  docs.ErrorResponse
+// @Router       /admin/webhooks/{id}/deliveries/{deliveryID}/redeliver [post]
+func RedeliverWebhookHandler(client *db.PrismaClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		delivery, err := services.RedeliverWebhook(client, chi.URLParam(r, "id"), chi.URLParam(r, "deliveryID"))
+		if err != nil {
+			respondWebhookError(w, err, "Erreur lors de la relivraison")
+			return
+		}
+
+		utils.RespondJSON(w, http.StatusAccepted, delivery)
+	}
+}
+
+// respondWebhookError associe les erreurs du service des webhooks aux codes HTTP
+func respondWebhookError(w http.ResponseWriter, err error, fallback string) {
+	msg := err.Error()
+	switch {
+	case msg == "webhook non trouvé" || msg == "livraison non trouvée":
+		utils.RespondError(w, http.StatusNotFound, msg)
+	case strings.HasPrefix(msg, "webhook désactivé"):
+		utils.RespondError(w, http.StatusConflict, msg)
+	case strings.HasPrefix(msg, "URL de webhook invalide"),
+		msg == "au moins un type d'événement est requis",
+		strings.HasPrefix(msg, "type d'événement inconnu"),
+		strings.HasPrefix(msg, "statut de livraison invalide"):
+		utils.RespondError(w, http.StatusBadRequest, msg)
+	default:
+		utils.RespondError(w, http.StatusInternalServerError, fallback)
+	}
+}
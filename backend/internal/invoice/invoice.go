@@ -0,0 +1,303 @@
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Seller regroupe les mentions du vendeur imprimées sur les factures
+type Seller struct {
+	Name         string
+	AddressLines []string
+	SIRET        string
+	VATNumber    string // Numéro de TVA intracommunautaire
+	Email        string
+	LegalNotice  string // Mentions légales en pied de page (pénalités de retard, ...)
+}
+
+// SellerFromEnv lit les mentions du vendeur depuis l'environnement
+// INVOICE_SELLER_ADDRESS sépare les lignes de l'adresse par "|"
+func SellerFromEnv() Seller {
+	seller := Seller{
+		Name:        os.Getenv("INVOICE_SELLER_NAME"),
+		SIRET:       os.Getenv("INVOICE_SELLER_SIRET"),
+		VATNumber:   os.Getenv("INVOICE_SELLER_VAT_NUMBER"),
+		Email:       os.Getenv("INVOICE_SELLER_EMAIL"),
+		LegalNotice: os.Getenv("INVOICE_LEGAL_NOTICE"),
+	}
+	if seller.Name == "" {
+		seller.Name = "PORELO"
+	}
+	for _, line := range strings.Split(os.Getenv("INVOICE_SELLER_ADDRESS"), "|") {
+		if line = strings.TrimSpace(line); line != "" {
+			seller.AddressLines = append(seller.AddressLines, line)
+		}
+	}
+	return seller
+}
+
+// FormatNumber formate un numéro de facture séquentiel avec le préfixe INVOICE_NUMBER_PREFIX (défaut: FA)
+func FormatNumber(number int) string {
+	prefix := os.Getenv("INVOICE_NUMBER_PREFIX")
+	if prefix == "" {
+		prefix = "FA"
+	}
+	return fmt.Sprintf("%s-%06d", prefix, number)
+}
+
+// Invoice contient les données d'une facture, montants en euros TTC sauf mention contraire
+type Invoice struct {
+	Number         string
+	Date           time.Time
+	OrderID        string
+	OrderDate      time.Time
+	PaidAt         *time.Time
+	Seller         Seller
+	CustomerName   string
+	CustomerLines  []string // Adresse de facturation (adresse de livraison de la commande)
+	CustomerEmail  string
+	Lines          []Line
+	TaxLines       []TaxLine
+	SubtotalAmount float64 // Articles avant remise
+	DiscountAmount float64
+	CouponCode     string
+	ShippingAmount float64
+	NetAmount      float64 // Total HT
+	TaxAmount      float64 // Total TVA
+	TotalAmount    float64 // Total TTC
+}
+
+// Line est une ligne de facture
+type Line struct {
+	Description string
+	Quantity    int
+	UnitPrice   float64 // Prix unitaire TTC avant remise
+	Discount    float64 // Remise de la ligne
+	TaxRate     float64 // Taux de TVA en %
+	NetAmount   float64 // Total HT
+	GrossAmount float64 // Total TTC
+}
+
+// TaxLine récapitule les montants soumis à un même taux de TVA
+type TaxLine struct {
+	Rate        float64
+	NetAmount   float64
+	TaxAmount   float64
+	GrossAmount float64
+}
+
+// Mise en page (points)
+const (
+	marginLeft   = 50.0
+	marginRight  = pageWidth - 50.0
+	marginBottom = pageHeight - 60.0
+	rowHeight    = 16.0
+)
+
+// Colonnes du tableau des lignes : bord droit de chaque colonne chiffrée
+var (
+	colDescriptionWidth = 235.0
+	colQuantity         = 310.0
+	colUnitPrice        = 365.0
+	colDiscount         = 415.0
+	colTaxRate          = 450.0
+	colNet              = 500.0
+	colGross            = marginRight
+)
+
+// Render génère le PDF d'une facture
+func Render(inv Invoice) []byte {
+	doc := &document{}
+	doc.addPage()
+
+	y := renderHeader(doc, inv)
+	y = renderLinesHeader(doc, y)
+
+	for _, line := range inv.Lines {
+		if y+rowHeight > marginBottom {
+			doc.addPage()
+			y = renderLinesHeader(doc, 60)
+		}
+		doc.text(marginLeft+4, y, 9, false, truncateText(line.Description, colDescriptionWidth, 9, false))
+		doc.textRight(colQuantity, y, 9, false, strconv.Itoa(line.Quantity))
+		doc.textRight(colUnitPrice, y, 9, false, formatAmount(line.UnitPrice))
+		if line.Discount > 0 {
+			doc.textRight(colDiscount, y, 9, false, "-"+formatAmount(line.Discount))
+		}
+		doc.textRight(colTaxRate, y, 9, false, formatRate(line.TaxRate))
+		doc.textRight(colNet, y, 9, false, formatAmount(line.NetAmount))
+		doc.textRight(colGross, y, 9, false, formatAmount(line.GrossAmount))
+		y += rowHeight
+	}
+	doc.line(marginLeft, y-10, marginRight, y-10, 0.5)
+
+	// Le récapitulatif TVA et les totaux restent groupés
+	summaryHeight := 30 + float64(len(inv.TaxLines)+1)*rowHeight + 7*rowHeight
+	if y+summaryHeight > marginBottom {
+		doc.addPage()
+		y = 60
+	}
+	y = renderSummary(doc, inv, y+10)
+
+	renderFooters(doc, inv)
+	return doc.bytes()
+}
+
+// renderHeader écrit le vendeur, les références de la facture et le client ; retourne la hauteur atteinte
+func renderHeader(doc *document, inv Invoice) float64 {
+	// Vendeur
+	y := 60.0
+	doc.text(marginLeft, y, 16, true, inv.Seller.Name)
+	y += 16
+	for _, line := range inv.Seller.AddressLines {
+		doc.text(marginLeft, y, 9, false, line)
+		y += 12
+	}
+	if inv.Seller.SIRET != "" {
+		doc.text(marginLeft, y, 9, false, "SIRET : "+inv.Seller.SIRET)
+		y += 12
+	}
+	if inv.Seller.VATNumber != "" {
+		doc.text(marginLeft, y, 9, false, "TVA intracommunautaire : "+inv.Seller.VATNumber)
+		y += 12
+	}
+	if inv.Seller.Email != "" {
+		doc.text(marginLeft, y, 9, false, inv.Seller.Email)
+		y += 12
+	}
+
+	// Références de la facture
+	ry := 60.0
+	doc.textRight(marginRight, ry, 20, true, "FACTURE")
+	ry += 20
+	doc.textRight(marginRight, ry, 10, true, "N° "+inv.Number)
+	ry += 14
+	doc.textRight(marginRight, ry, 9, false, "Date : "+formatDate(inv.Date))
+	ry += 12
+	doc.textRight(marginRight, ry, 9, false, "Commande : "+inv.OrderID)
+	ry += 12
+	doc.textRight(marginRight, ry, 9, false, "Date de commande : "+formatDate(inv.OrderDate))
+	ry += 12
+	if inv.PaidAt != nil {
+		doc.textRight(marginRight, ry, 9, false, "Payée le "+formatDate(*inv.PaidAt))
+		ry += 12
+	}
+
+	// Client
+	y = math.Max(y, ry) + 20
+	doc.text(330, y, 9, true, "Facturé à")
+	y += 14
+	doc.text(330, y, 10, true, inv.CustomerName)
+	y += 13
+	for _, line := range inv.CustomerLines {
+		doc.text(330, y, 9, false, line)
+		y += 12
+	}
+	if inv.CustomerEmail != "" {
+		doc.text(330, y, 9, false, inv.CustomerEmail)
+		y += 12
+	}
+
+	return y + 25
+}
+
+// renderLinesHeader écrit l'en-tête du tableau des lignes ; retourne la position de la première ligne
+func renderLinesHeader(doc *document, y float64) float64 {
+	doc.fillRect(marginLeft, y-12, marginRight-marginLeft, 18, 0.9)
+	doc.text(marginLeft+4, y, 8, true, "Désignation")
+	doc.textRight(colQuantity, y, 8, true, "Qté")
+	doc.textRight(colUnitPrice, y, 8, true, "PU TTC")
+	doc.textRight(colDiscount, y, 8, true, "Remise")
+	doc.textRight(colTaxRate, y, 8, true, "TVA")
+	doc.textRight(colNet, y, 8, true, "Total HT")
+	doc.textRight(colGross, y, 8, true, "Total TTC")
+	return y + 22
+}
+
+// renderSummary écrit le récapitulatif TVA et les totaux ; retourne la hauteur atteinte
+func renderSummary(doc *document, inv Invoice, y float64) float64 {
+	// Récapitulatif TVA (à gauche)
+	ty := y
+	doc.text(marginLeft, ty, 9, true, "Récapitulatif TVA")
+	ty += 16
+	doc.text(marginLeft, ty, 8, true, "Taux")
+	doc.textRight(170, ty, 8, true, "Base HT")
+	doc.textRight(230, ty, 8, true, "TVA")
+	doc.textRight(290, ty, 8, true, "TTC")
+	for _, line := range inv.TaxLines {
+		ty += rowHeight - 2
+		doc.text(marginLeft, ty, 9, false, formatRate(line.Rate))
+		doc.textRight(170, ty, 9, false, formatAmount(line.NetAmount))
+		doc.textRight(230, ty, 9, false, formatAmount(line.TaxAmount))
+		doc.textRight(290, ty, 9, false, formatAmount(line.GrossAmount))
+	}
+
+	// Totaux (à droite)
+	total := func(label, value string, bold bool) {
+		doc.text(360, y, 9, bold, label)
+		doc.textRight(marginRight, y, 9, bold, value)
+		y += rowHeight - 2
+	}
+	total("Sous-total articles", formatAmount(inv.SubtotalAmount), false)
+	if inv.DiscountAmount > 0 {
+		label := "Remise"
+		if inv.CouponCode != "" {
+			label += " (" + inv.CouponCode + ")"
+		}
+		total(label, "-"+formatAmount(inv.DiscountAmount), false)
+	}
+	total("Livraison", formatAmount(inv.ShippingAmount), false)
+	total("Total HT", formatAmount(inv.NetAmount), false)
+	total("Total TVA", formatAmount(inv.TaxAmount), false)
+	doc.line(360, y-9, marginRight, y-9, 0.5)
+	y += 3
+	doc.text(360, y, 11, true, "Total TTC")
+	doc.textRight(marginRight, y, 11, true, formatAmount(inv.TotalAmount))
+
+	return math.Max(y, ty) + rowHeight
+}
+
+// renderFooters écrit les mentions légales et la pagination en bas de chaque page
+func renderFooters(doc *document, inv Invoice) {
+	for i := range doc.pages {
+		doc.selectPage(i)
+		if inv.Seller.LegalNotice != "" {
+			doc.text(marginLeft, pageHeight-40, 7, false, truncateText(inv.Seller.LegalNotice, marginRight-marginLeft-60, 7, false))
+		}
+		doc.textRight(marginRight, pageHeight-40, 7, false, fmt.Sprintf("Page %d/%d", i+1, len(doc.pages)))
+	}
+}
+
+// formatAmount formate un montant en euros à la française (ex: "1 234,50 €")
+func formatAmount(amount float64) string {
+	s := strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+	intPart, decPart := s[:len(s)-3], s[len(s)-2:]
+
+	var grouped strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			grouped.WriteRune('\u202f')
+		}
+		grouped.WriteRune(c)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	return sign + grouped.String() + "," + decPart + "\u00a0€"
+}
+
+// formatRate formate un taux de TVA (ex: "20 %", "5,5 %")
+func formatRate(rate float64) string {
+	return strings.Replace(strconv.FormatFloat(rate, 'f', -1, 64), ".", ",", 1) + "\u00a0%"
+}
+
+// formatDate formate une date au format français JJ/MM/AAAA
+func formatDate(t time.Time) string {
+	return t.Format("02/01/2006")
+}
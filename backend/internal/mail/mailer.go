@@ -0,0 +1,98 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Message est un e-mail texte prêt à être envoyé
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer abstrait l'envoi des e-mails (SMTP, API d'un prestataire, fichiers en développement, ...)
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogMailer écrit les e-mails dans les logs du serveur (développement)
+type LogMailer struct {
+	from string
+}
+
+// NewLogMailer crée un mailer qui journalise les e-mails au lieu de les envoyer
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+// Send journalise l'e-mail
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("mail: from=%q to=%q subject=%q\n%s", m.from, msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileMailer enregistre chaque e-mail dans un fichier .eml (développement, lisible par un client mail)
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer crée le dossier de destination si nécessaire et retourne le mailer
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("impossible de créer le dossier des e-mails %s: %w", dir, err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]+`)
+
+// Send écrit l'e-mail au format RFC 5322
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("impossible d'écrire l'e-mail: %w", err)
+	}
+	return nil
+}
+
+// NewMailerFromEnv configure le mailer depuis MAIL_DRIVER (log ou file, défaut: log), MAIL_DIR et MAIL_FROM
+func NewMailerFromEnv() (Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "PORELO <no-reply@porelo.fr>"
+	}
+
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "", "log":
+		return NewLogMailer(from), nil
+	case "file":
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = "./mails"
+		}
+		return NewFileMailer(dir, from)
+	default:
+		return nil, fmt.Errorf("MAIL_DRIVER inconnu: %s", driver)
+	}
+}
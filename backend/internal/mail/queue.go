@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Queue envoie les e-mails en arrière-plan : l'appelant n'attend jamais l'envoi et un échec
+// est retenté avec un délai exponentiel (2s, 4s, 8s, ... plafonné à 5 min) jusqu'à MAIL_MAX_ATTEMPTS tentatives
+type Queue struct {
+	mailer      Mailer
+	jobs        chan job
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+type job struct {
+	msg     Message
+	attempt int
+}
+
+// NewQueue crée la file et démarre son worker
+func NewQueue(mailer Mailer) *Queue {
+	maxAttempts := 5
+	if v, err := strconv.Atoi(os.Getenv("MAIL_MAX_ATTEMPTS")); err == nil && v > 0 {
+		maxAttempts = v
+	}
+
+	q := &Queue{
+		mailer:      mailer,
+		jobs:        make(chan job, 256),
+		maxAttempts: maxAttempts,
+		baseDelay:   2 * time.Second,
+		maxDelay:    5 * time.Minute,
+	}
+	go q.run()
+	return q
+}
+
+// Enqueue programme l'envoi d'un e-mail sans bloquer ; si la file est pleine, l'e-mail est abandonné et journalisé
+// Une file nil ignore les e-mails (notifications désactivées)
+func (q *Queue) Enqueue(msg Message) {
+	if q == nil {
+		return
+	}
+	q.push(job{msg: msg, attempt: 1})
+}
+
+func (q *Queue) push(j job) {
+	select {
+	case q.jobs <- j:
+	default:
+		log.Printf("mail: file pleine, e-mail abandonné (to=%q subject=%q)", j.msg.To, j.msg.Subject)
+	}
+}
+
+func (q *Queue) run() {
+	for j := range q.jobs {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := q.mailer.Send(ctx, j.msg)
+		cancel()
+		if err == nil {
+			continue
+		}
+
+		if j.attempt >= q.maxAttempts {
+			log.Printf("mail: échec définitif après %d tentatives (to=%q subject=%q): %v", j.attempt, j.msg.To, j.msg.Subject, err)
+			continue
+		}
+
+		delay := q.baseDelay << (j.attempt - 1)
+		if delay > q.maxDelay || delay <= 0 {
+			delay = q.maxDelay
+		}
+		log.Printf("mail: échec de la tentative %d (to=%q), nouvel essai dans %s: %v", j.attempt, j.msg.To, delay, err)
+
+		// La nouvelle tentative est reprogrammée sans bloquer les autres envois
+		retry := job{msg: j.msg, attempt: j.attempt + 1}
+		time.AfterFunc(delay, func() { q.push(retry) })
+	}
+}
@@ -0,0 +1,123 @@
+package mail
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Modèles d'e-mails disponibles (un fichier par langue dans templates/<langue>/)
+const (
+	TemplateOrderConfirmed = "order_confirmed"
+	TemplateOrderShipped   = "order_shipped"
+	TemplateOrderDelivered = "order_delivered"
+	TemplateOrderCancelled = "order_cancelled"
+	TemplateOrderRefunded  = "order_refunded"
+	TemplateReviewReplied  = "review_replied"
+)
+
+// DefaultLocale est la langue utilisée quand celle du client n'est pas prise en charge
+const DefaultLocale = "fr"
+
+var supportedLocales = map[string]bool{"fr": true, "en": true}
+
+// NormalizeLocale retourne la langue prise en charge correspondant à un en-tête Accept-Language ou à un code langue
+// (ex: "en-GB,en;q=0.9" -> "en") ; à défaut, DefaultLocale
+func NormalizeLocale(value string) string {
+	for _, part := range strings.Split(value, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		lang := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if supportedLocales[lang] {
+			return lang
+		}
+	}
+	return DefaultLocale
+}
+
+// OrderEmail contient les données d'une commande disponibles dans les modèles
+type OrderEmail struct {
+	To             string
+	Locale         string
+	OrderID        string
+	OrderNumber    string // Référence courte affichée au client
+	Items          []OrderEmailItem
+	ShippingMethod string
+	AddressLines   []string
+	DiscountAmount float64
+	ShippingAmount float64
+	TotalAmount    float64
+	RefundAmount   float64
+	InvoiceNumber  string
+}
+
+// OrderEmailItem est une ligne de commande dans un e-mail
+type OrderEmailItem struct {
+	Name     string
+	Quantity int
+	Amount   float64 // Montant TTC de la ligne
+}
+
+// ReviewReplyEmail contient les données de la réponse de la marque à un avis disponibles dans les modèles
+type ReviewReplyEmail struct {
+	To          string
+	Locale      string
+	ProductName string
+	Rating      int
+	Comment     string // Commentaire de l'avis (vide si l'avis n'en a pas)
+	Reply       string // Texte de la réponse
+}
+
+// Render construit l'e-mail d'un modèle dans la langue de la commande
+func Render(name string, data OrderEmail) (Message, error) {
+	return render(name, data.Locale, data.To, data)
+}
+
+// RenderReviewReply construit l'e-mail informant l'auteur d'un avis de la réponse de la marque
+func RenderReviewReply(data ReviewReplyEmail) (Message, error) {
+	return render(TemplateReviewReplied, data.Locale, data.To, data)
+}
+
+// render construit l'e-mail d'un modèle dans la langue donnée
+func render(name, locale, to string, data any) (Message, error) {
+	locale = NormalizeLocale(locale)
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"money": func(amount float64) string { return formatMoney(amount, locale) },
+	}).ParseFS(templatesFS, "templates/"+locale+"/"+name+".tmpl")
+	if err != nil {
+		return Message{}, fmt.Errorf("modèle d'e-mail %s/%s introuvable: %w", locale, name, err)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("erreur de rendu du sujet %s/%s: %w", locale, name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Message{}, fmt.Errorf("erreur de rendu du corps %s/%s: %w", locale, name, err)
+	}
+
+	return Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
+
+// formatMoney formate un montant en euros selon la langue (fr: "53,99 €", en: "€53.99")
+func formatMoney(amount float64, locale string) string {
+	s := strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	if locale == "en" {
+		return sign + "€" + s
+	}
+	return sign + strings.Replace(s, ".", ",", 1) + " €"
+}
@@ -0,0 +1,129 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+
+	"api/internal/db"
+	"api/internal/services"
+	"api/internal/utils"
+)
+
+// IdempotencyKeyHeader est l'en-tête portant la clé d'idempotence choisie par le client
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength limite la taille des clés acceptées
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentBodySize limite la taille des corps de requête hachés (10 Mo, comme les imports produits)
+const maxIdempotentBodySize = 10 << 20
+
+// Idempotency : Rejoue la réponse d'origine lorsqu'une requête mutante est renvoyée avec le même en-tête Idempotency-Key
+// (doit être utilisé après AuthMiddleware, les clés étant propres à chaque utilisateur).
+// Sans en-tête, la requête est traitée normalement.
+func Idempotency(client *db.PrismaClient) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				utils.RespondError(w, http.StatusBadRequest, "Idempotency-Key trop longue (255 caractères maximum)")
+				return
+			}
+
+			claims, ok := GetUserClaims(r)
+			if !ok {
+				utils.RespondError(w, http.StatusUnauthorized, "Non authentifié")
+				return
+			}
+
+			// 1. Lire le corps pour calculer l'empreinte de la requête, puis le restituer au handler
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			if err != nil {
+				utils.RespondError(w, http.StatusRequestEntityTooLarge, "Corps de requête trop volumineux")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// La query string fait partie de la requête (ex: ?dryRun=true) ; Encode la trie par clé
+			hash := sha256.New()
+			hash.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.Query().Encode() + "\n"))
+			hash.Write(body)
+			requestHash := hex.EncodeToString(hash.Sum(nil))
+
+			// 2. Réserver la clé ou rejouer la réponse enregistrée
+			stored, err := services.ReserveIdempotencyKey(client, claims.UserID, key, r.Method, r.URL.Path, requestHash)
+			if err != nil {
+				switch err.Error() {
+				case "clé d'idempotence déjà utilisée pour une requête différente":
+					utils.RespondError(w, http.StatusUnprocessableEntity, err.Error())
+				case "requête avec cette clé d'idempotence en cours de traitement":
+					utils.RespondError(w, http.StatusConflict, err.Error())
+				default:
+					utils.RespondError(w, http.StatusInternalServerError, "Erreur lors de la vérification de la clé d'idempotence")
+				}
+				return
+			}
+			if stored != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.Body)
+				return
+			}
+
+			// 3. Traiter la requête en capturant la réponse
+			rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			defer func() {
+				// Un panic ne doit pas bloquer la clé jusqu'à son expiration
+				if p := recover(); p != nil {
+					services.ReleaseIdempotencyKey(client, claims.UserID, key)
+					panic(p)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+
+			// Les erreurs serveur ne sont pas figées : le client peut réessayer avec la même clé
+			if rec.statusCode >= http.StatusInternalServerError {
+				if err := services.ReleaseIdempotencyKey(client, claims.UserID, key); err != nil {
+					log.Printf("idempotency: %v", err)
+				}
+				return
+			}
+
+			response := services.IdempotentResponse{StatusCode: rec.statusCode, Body: rec.body.Bytes()}
+			if err := services.CompleteIdempotencyKey(client, claims.UserID, key, response); err != nil {
+				log.Printf("idempotency: %v", err)
+			}
+		})
+	}
+}
+
+// responseRecorder transmet la réponse au client tout en la conservant
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode = statusCode
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
@@ -0,0 +1,186 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FakeProviderName est le nom d'enregistrement du faux prestataire
+const FakeProviderName = "fake"
+
+// FakeSignatureHeader est l'en-tête portant la signature des webhooks du faux prestataire
+// Format: "t=<timestamp unix>,v1=<hex HMAC-SHA256(secret, "<timestamp>.<payload>")>"
+const FakeSignatureHeader = "Fake-Signature"
+
+// fakeSignatureTolerance est l'écart maximal accepté entre l'horodatage signé et l'heure de réception
+const fakeSignatureTolerance = 5 * time.Minute
+
+// FakeProvider est un prestataire local : les identifiants de paiement sont dérivés des références,
+// aucun appel réseau n'est effectué et les webhooks sont signés comme chez un vrai prestataire.
+// Chaque remboursement et chaque événement reçoit un identifiant unique, comme chez un vrai prestataire.
+type FakeProvider struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// fakeWebhookPayload est le corps JSON des webhooks du faux prestataire
+type fakeWebhookPayload struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		PaymentID string  `json:"paymentID"`
+		Amount    float64 `json:"amount"`
+		Reason    string  `json:"reason,omitempty"`
+	} `json:"data"`
+}
+
+// NewFakeProvider crée un faux prestataire signant ses webhooks avec secret
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: []byte(secret), now: time.Now}
+}
+
+// NewFakeProviderFromEnv configure le faux prestataire depuis PAYMENT_FAKE_WEBHOOK_SECRET
+// Le secret est obligatoire : sans lui, n'importe qui pourrait signer un webhook de paiement réussi.
+func NewFakeProviderFromEnv() (*FakeProvider, error) {
+	secret := os.Getenv("PAYMENT_FAKE_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("PAYMENT_FAKE_WEBHOOK_SECRET est requis pour activer le faux prestataire de paiement")
+	}
+	return NewFakeProvider(secret), nil
+}
+
+// Name retourne "fake"
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+// CreateIntent dérive l'identifiant du paiement de la référence : la même tentative donne le même paiement
+func (p *FakeProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("montant de paiement invalide: %.2f", req.Amount)
+	}
+	id := "fake_pi_" + p.digest(req.Reference)[:24]
+	return &Intent{
+		ID:           id,
+		ClientSecret: id + "_secret_" + p.digest(id)[:16],
+	}, nil
+}
+
+// Capture accepte toute capture d'un paiement du faux prestataire
+func (p *FakeProvider) Capture(ctx context.Context, paymentID string, amount float64) error {
+	if !strings.HasPrefix(paymentID, "fake_pi_") {
+		return fmt.Errorf("paiement inconnu: %s", paymentID)
+	}
+	return nil
+}
+
+// Refund accepte tout remboursement ; deux remboursements du même montant ont des identifiants distincts
+func (p *FakeProvider) Refund(ctx context.Context, paymentID string, amount float64) (string, error) {
+	if !strings.HasPrefix(paymentID, "fake_pi_") {
+		return "", fmt.Errorf("paiement inconnu: %s", paymentID)
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return "", err
+	}
+	return "fake_re_" + p.digest(fmt.Sprintf("%s:%.2f:%s", paymentID, amount, nonce))[:24], nil
+}
+
+// VerifyWebhook vérifie l'en-tête Fake-Signature (HMAC-SHA256 et fraîcheur) puis décode l'événement
+func (p *FakeProvider) VerifyWebhook(payload []byte, header http.Header) (*WebhookEvent, error) {
+	var timestamp, signature string
+	for _, part := range strings.Split(header.Get(FakeSignatureHeader), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return nil, ErrInvalidSignature
+	}
+	if age := p.now().Sub(time.Unix(ts, 0)); age > fakeSignatureTolerance || age < -fakeSignatureTolerance {
+		return nil, ErrInvalidSignature
+	}
+	expected := p.sign(timestamp, payload)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var body fakeWebhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("webhook invalide: %w", err)
+	}
+	if body.ID == "" || body.Type == "" || body.Data.PaymentID == "" {
+		return nil, fmt.Errorf("webhook invalide: champs manquants")
+	}
+
+	return &WebhookEvent{
+		ID:        body.ID,
+		Type:      body.Type,
+		PaymentID: body.Data.PaymentID,
+		Amount:    body.Data.Amount,
+		Reason:    body.Data.Reason,
+	}, nil
+}
+
+// SignedWebhook construit un webhook signé, tel que le prestataire l'enverrait, pour tester le flux en local
+// Chaque appel produit un nouvel événement (identifiant unique) ; renvoyer le même corps simule un rejeu.
+// Retourne le corps et la valeur de l'en-tête FakeSignatureHeader
+func (p *FakeProvider) SignedWebhook(eventType, paymentID string, amount float64, reason string) ([]byte, string, error) {
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var body fakeWebhookPayload
+	body.Type = eventType
+	body.Data.PaymentID = paymentID
+	body.Data.Amount = amount
+	body.Data.Reason = reason
+	body.ID = "fake_evt_" + p.digest(fmt.Sprintf("%s:%s:%.2f:%s", eventType, paymentID, amount, nonce))[:24]
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", err
+	}
+	timestamp := strconv.FormatInt(p.now().Unix(), 10)
+	return payload, "t=" + timestamp + ",v1=" + p.sign(timestamp, payload), nil
+}
+
+// sign calcule la signature HMAC-SHA256 hexadécimale de "<timestamp>.<payload>"
+func (p *FakeProvider) sign(timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// digest dérive un identifiant déterministe d'une valeur
+func (p *FakeProvider) digest(value string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newNonce retourne une valeur aléatoire rendant unique un identifiant dérivé
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erreur lors de la génération d'un identifiant: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
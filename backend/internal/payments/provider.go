@@ -0,0 +1,119 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Types d'événements normalisés, quel que soit le prestataire
+const (
+	EventAuthorized = "payment.authorized" // Paiement autorisé, en attente de capture
+	EventSucceeded  = "payment.succeeded"  // Paiement capturé
+	EventFailed     = "payment.failed"     // Paiement refusé
+	EventRefunded   = "payment.refunded"   // Remboursement effectué (total ou partiel)
+)
+
+// ErrInvalidSignature est retournée quand la signature d'un webhook est absente, invalide ou expirée
+var ErrInvalidSignature = errors.New("signature de webhook invalide")
+
+// IntentRequest décrit un paiement à initier auprès du prestataire
+type IntentRequest struct {
+	OrderID   string
+	Amount    float64 // Montant TTC
+	Currency  string  // Code ISO 4217 (ex: "EUR")
+	Reference string  // Référence unique de la tentative (clé d'idempotence côté prestataire)
+}
+
+// Intent est l'intention de paiement créée par le prestataire
+type Intent struct {
+	ID           string // Identifiant du paiement chez le prestataire
+	ClientSecret string // Secret transmis au client pour finaliser le paiement
+}
+
+// WebhookEvent est un événement reçu du prestataire, après vérification de la signature
+type WebhookEvent struct {
+	ID        string  // Identifiant unique de l'événement (déduplication)
+	Type      string  // Un des Event* ci-dessus
+	PaymentID string  // Identifiant du paiement chez le prestataire
+	Amount    float64 // Montant concerné (capturé ou remboursé)
+	Reason    string  // Motif d'échec éventuel
+}
+
+// PaymentProvider abstrait un prestataire de paiement (Stripe, PayPal, faux prestataire local, ...)
+type PaymentProvider interface {
+	// Name retourne l'identifiant du prestataire utilisé dans les URLs (ex: "fake")
+	Name() string
+	// CreateIntent initie un paiement
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	// Capture encaisse un paiement autorisé
+	Capture(ctx context.Context, paymentID string, amount float64) error
+	// Refund rembourse tout ou partie d'un paiement capturé et retourne l'identifiant du remboursement
+	Refund(ctx context.Context, paymentID string, amount float64) (string, error)
+	// VerifyWebhook vérifie la signature d'un webhook et décode l'événement
+	VerifyWebhook(payload []byte, header http.Header) (*WebhookEvent, error)
+}
+
+// Registry regroupe les prestataires configurés, indexés par nom
+type Registry struct {
+	providers       map[string]PaymentProvider
+	defaultProvider string
+}
+
+// NewRegistry crée un registre ; le premier prestataire est utilisé par défaut
+func NewRegistry(providers ...PaymentProvider) *Registry {
+	reg := &Registry{providers: make(map[string]PaymentProvider)}
+	for _, p := range providers {
+		reg.providers[p.Name()] = p
+		if reg.defaultProvider == "" {
+			reg.defaultProvider = p.Name()
+		}
+	}
+	return reg
+}
+
+// NewRegistryFromEnv configure les prestataires depuis l'environnement
+// Le faux prestataire n'est activé que sur demande (PAYMENT_FAKE_ENABLED=true) et uniquement en développement
+// (ENVIRONMENT=development) ; PAYMENT_DEFAULT_PROVIDER choisit le prestataire utilisé quand la requête n'en précise pas
+func NewRegistryFromEnv() (*Registry, error) {
+	var providers []PaymentProvider
+	if os.Getenv("PAYMENT_FAKE_ENABLED") == "true" {
+		if os.Getenv("ENVIRONMENT") != "development" {
+			return nil, fmt.Errorf("le faux prestataire de paiement n'est autorisé qu'en développement (ENVIRONMENT=development)")
+		}
+		fake, err := NewFakeProviderFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, fake)
+	}
+
+	reg := NewRegistry(providers...)
+	if name := os.Getenv("PAYMENT_DEFAULT_PROVIDER"); name != "" {
+		if _, ok := reg.providers[name]; !ok {
+			return nil, fmt.Errorf("prestataire de paiement par défaut inconnu: %s", name)
+		}
+		reg.defaultProvider = name
+	}
+	return reg, nil
+}
+
+// Get retourne un prestataire par son nom (le prestataire par défaut si name est vide)
+func (r *Registry) Get(name string) (PaymentProvider, bool) {
+	if name == "" {
+		name = r.defaultProvider
+	}
+	p, ok := r.providers[strings.ToLower(name)]
+	return p, ok
+}
+
+// Currency retourne la devise des paiements (PAYMENT_CURRENCY, défaut: EUR)
+func Currency() string {
+	if c := os.Getenv("PAYMENT_CURRENCY"); c != "" {
+		return strings.ToUpper(c)
+	}
+	return "EUR"
+}
@@ -0,0 +1,148 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer est le nombre de messages en attente par abonné ; un abonné trop lent est déconnecté
+// et reprend ensuite depuis le tampon grâce à Last-Event-ID
+const subscriberBuffer = 64
+
+// Message est un événement diffusé aux clients connectés
+type Message struct {
+	ID     uint64          // Identifiant croissant (id SSE, renvoyé par le client dans Last-Event-ID)
+	Event  string          // Nom de l'événement (event SSE)
+	UserID string          // Utilisateur concerné, pour filtrer les flux par client
+	Data   json.RawMessage // Corps JSON (data SSE)
+}
+
+// Subscription est l'abonnement d'un client au hub
+type Subscription struct {
+	C      <-chan Message // Messages publiés après l'abonnement ; fermé si le client est trop lent
+	hub    *Hub
+	ch     chan Message
+	filter func(Message) bool
+}
+
+// Hub diffuse les messages aux abonnés et conserve les derniers dans un tampon borné pour la reprise.
+// Le hub est en mémoire : chaque instance de l'API ne diffuse que les événements qu'elle a distribués.
+type Hub struct {
+	mu       sync.Mutex
+	buffer   []Message // Tampon borné des derniers messages, du plus ancien au plus récent
+	size     int
+	nextID   uint64
+	sources  map[string]bool // Identifiants source présents dans le tampon (déduplication)
+	sourceOf map[uint64]string
+	subs     map[*Subscription]struct{}
+}
+
+// NewHub crée un hub conservant les size derniers messages
+// Les identifiants partent de l'horloge (microsecondes) : après un redémarrage, un Last-Event-ID
+// de l'instance précédente est plus ancien que le tampon et le client est invité à se resynchroniser.
+func NewHub(size int) *Hub {
+	return &Hub{
+		size:     size,
+		nextID:   uint64(time.Now().UnixMicro()),
+		sources:  make(map[string]bool),
+		sourceOf: make(map[uint64]string),
+		subs:     make(map[*Subscription]struct{}),
+	}
+}
+
+// NewHubFromEnv crée un hub dont la taille du tampon vient de SSE_BUFFER_SIZE (défaut: 1000)
+func NewHubFromEnv() *Hub {
+	size := 1000
+	if v, err := strconv.Atoi(os.Getenv("SSE_BUFFER_SIZE")); err == nil && v > 0 {
+		size = v
+	}
+	return NewHub(size)
+}
+
+// Publish diffuse un message aux abonnés dont le filtre l'accepte
+// sourceID identifie l'origine (ex: événement de l'outbox) : un message déjà publié n'est pas rediffusé.
+func (h *Hub) Publish(sourceID, event, userID string, data json.RawMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sourceID != "" && h.sources[sourceID] {
+		return
+	}
+
+	h.nextID++
+	msg := Message{ID: h.nextID, Event: event, UserID: userID, Data: data}
+	if len(h.buffer) == h.size {
+		oldest := h.buffer[0]
+		delete(h.sources, h.sourceOf[oldest.ID])
+		delete(h.sourceOf, oldest.ID)
+		h.buffer = h.buffer[1:]
+	}
+	h.buffer = append(h.buffer, msg)
+	if sourceID != "" {
+		h.sources[sourceID] = true
+		h.sourceOf[msg.ID] = sourceID
+	}
+
+	for sub := range h.subs {
+		if !sub.filter(msg) {
+			continue
+		}
+		select {
+		case sub.ch <- msg:
+		default:
+			// Abonné trop lent : déconnecté, il reprendra depuis le tampon
+			delete(h.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe abonne un client aux messages acceptés par filter.
+// Avec lastID > 0 (Last-Event-ID), les messages manqués encore présents dans le tampon sont retournés ;
+// complete est faux si des messages ont pu être perdus (tampon dépassé ou redémarrage) : le client doit se resynchroniser.
+func (h *Hub) Subscribe(lastID uint64, filter func(Message) bool) (sub *Subscription, replay []Message, complete bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	complete = true
+	if lastID > 0 {
+		oldest := h.nextID + 1
+		if len(h.buffer) > 0 {
+			oldest = h.buffer[0].ID
+		}
+		if lastID+1 < oldest || lastID > h.nextID {
+			complete = false
+		}
+		for _, msg := range h.buffer {
+			if msg.ID > lastID && filter(msg) {
+				replay = append(replay, msg)
+			}
+		}
+	}
+
+	ch := make(chan Message, subscriberBuffer)
+	sub = &Subscription{C: ch, hub: h, ch: ch, filter: filter}
+	h.subs[sub] = struct{}{}
+	return sub, replay, complete
+}
+
+// Close désabonne le client
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subs[s]; ok {
+		delete(s.hub.subs, s)
+		close(s.ch)
+	}
+}
+
+// Write écrit un message au format Server-Sent Events
+func Write(w io.Writer, msg Message) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.ID, msg.Event, msg.Data)
+	return err
+}
@@ -8,12 +8,16 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-// RegisterCategoryRoutes enregistre les routes des catégories (admin only)
+// RegisterCategoryRoutes enregistre les routes des catégories
 func RegisterCategoryRoutes(r chi.Router, client *db.PrismaClient) {
+	// Arborescence publique, consultable sans authentification
+	r.Get("/categories", handlers.GetCategoryTreeHandler(client))
+
 	// Toutes les routes nécessitent authentification + rôle ADMIN
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
 		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
 		r.Get("/admin/categories", handlers.GetAllCategoriesHandler(client))
 		r.Get("/admin/categories/{id}", handlers.GetCategoryHandler(client))
 		r.Post("/admin/categories", handlers.CreateCategoryHandler(client))
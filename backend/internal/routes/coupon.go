@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterCouponRoutes enregistre les routes de gestion des codes promo (admin uniquement)
+// Les codes sont appliqués par les clients via couponCode dans POST /orders
+func RegisterCouponRoutes(r chi.Router, client *db.PrismaClient) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/admin/coupons", handlers.GetAllCouponsHandler(client))
+		r.Post("/admin/coupons", handlers.CreateCouponHandler(client))
+		r.Get("/admin/coupons/{id}", handlers.GetCouponHandler(client))
+		r.Put("/admin/coupons/{id}", handlers.UpdateCouponHandler(client))
+		r.Delete("/admin/coupons/{id}", handlers.DeleteCouponHandler(client))
+		r.Get("/admin/coupons/{id}/stats", handlers.GetCouponStatsHandler(client))
+	})
+}
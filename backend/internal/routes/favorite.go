@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Définition des routes liées aux favoris
+func RegisterFavoriteRoutes(r chi.Router, client *db.PrismaClient) {
+	// Routes authentifiées : chaque utilisateur gère ses propres favoris
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/favorites", handlers.GetFavoritesHandler(client))
+		r.Post("/favorites", handlers.AddFavoriteHandler(client))
+		r.Delete("/favorites/{productID}", handlers.RemoveFavoriteHandler(client))
+	})
+
+	// Routes admin uniquement : rapports
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Get("/admin/reports/most-wished", handlers.GetMostWishedProductsHandler(client))
+	})
+}
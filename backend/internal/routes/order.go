@@ -4,25 +4,32 @@ import (
 	"api/internal/db"
 	"api/internal/handlers"
 	"api/internal/middlewares"
+	"api/internal/realtime"
 
 	"github.com/go-chi/chi/v5"
 )
 
 // RegisterOrderRoutes enregistre les routes des commandes
-func RegisterOrderRoutes(r chi.Router, client *db.PrismaClient) {
+// Les flux temps réel (SSE) diffusent les messages du hub
+func RegisterOrderRoutes(r chi.Router, client *db.PrismaClient, hub *realtime.Hub) {
 	// Routes pour utilisateurs authentifiés
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
 		r.Post("/orders", handlers.CreateOrderHandler(client))
 		r.Get("/orders", handlers.GetUserOrdersHandler(client))
+		r.Get("/orders/stream", handlers.StreamOrdersHandler(hub))
 		r.Get("/orders/{id}", handlers.GetOrderHandler(client))
+		r.Get("/orders/{id}/invoice.pdf", handlers.GetOrderInvoiceHandler(client))
 	})
 
 	// Routes admin
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
 		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
 		r.Get("/admin/orders", handlers.GetAllOrdersHandler(client))
+		r.Get("/admin/orders/stream", handlers.StreamAdminOrdersHandler(hub))
 		r.Put("/admin/orders/{id}/status", handlers.UpdateOrderStatusHandler(client))
 	})
 }
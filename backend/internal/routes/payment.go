@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+	"api/internal/payments"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterPaymentRoutes enregistre les routes de paiement
+func RegisterPaymentRoutes(r chi.Router, client *db.PrismaClient, registry *payments.Registry) {
+	// Webhooks des prestataires : publics, authentifiés par signature
+	r.Post("/webhooks/payments/{provider}", handlers.PaymentWebhookHandler(client, registry))
+
+	// Routes pour utilisateurs authentifiés
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
+		r.Post("/orders/{id}/pay", handlers.PayOrderHandler(client, registry))
+		r.Get("/orders/{id}/payments", handlers.GetOrderPaymentsHandler(client))
+	})
+
+	// Routes admin
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Post("/admin/payments/{id}/refund", handlers.RefundPaymentHandler(client, registry))
+		r.Post("/admin/payments/{id}/simulate", handlers.SimulatePaymentWebhookHandler(client, registry))
+	})
+}
@@ -4,16 +4,19 @@ import (
 	"api/internal/db"
 	"api/internal/handlers"
 	"api/internal/middlewares"
+	"api/internal/storage"
 
 	"github.com/go-chi/chi/v5"
 )
 
 // RegisterProductRoutes enregistre les routes des produits
-func RegisterProductRoutes(r chi.Router, client *db.PrismaClient) {
+// Les images uploadées sont enregistrées dans store
+func RegisterProductRoutes(r chi.Router, client *db.PrismaClient, store storage.BlobStore) {
 	// Routes protégées (nécessitent authentification - USER ou ADMIN)
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
 		r.Get("/products", handlers.GetAllProductsHandler(client))
+		r.Get("/products/recommended", handlers.GetRecommendedProductsHandler(client))
 		r.Get("/products/{id}", handlers.GetProductHandler(client))
 	})
 
@@ -21,9 +24,18 @@ func RegisterProductRoutes(r chi.Router, client *db.PrismaClient) {
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
 		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
 		r.Post("/admin/products", handlers.CreateProductHandler(client))
+		r.Post("/admin/products/import", handlers.ImportProductsHandler(client))
+		r.Post("/admin/products/ratings/recompute", handlers.RecomputeProductRatingsHandler(client))
 		r.Put("/admin/products/{id}", handlers.UpdateProductHandler(client))
 		r.Patch("/admin/products/{id}", handlers.PatchProductHandler(client))
 		r.Delete("/admin/products/{id}", handlers.DeleteProductHandler(client))
+		r.Post("/admin/products/{id}/images", handlers.UploadProductImageHandler(client, store))
+		r.Put("/admin/products/{id}/images/order", handlers.ReorderProductImagesHandler(client))
+		r.Delete("/admin/products/{id}/images/{imageID}", handlers.DeleteProductImageHandler(client, store))
+		r.Post("/admin/products/{id}/variants", handlers.CreateProductVariantHandler(client))
+		r.Put("/admin/products/{id}/variants/{variantID}", handlers.UpdateProductVariantHandler(client))
+		r.Delete("/admin/products/{id}/variants/{variantID}", handlers.DeleteProductVariantHandler(client))
 	})
 }
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterQuestionRoutes enregistre les routes des questions / réponses sur les produits
+func RegisterQuestionRoutes(r chi.Router, client *db.PrismaClient) {
+	// Routes authentifiées
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Get("/products/{id}/questions", handlers.GetProductQuestionsHandler(client))
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
+		r.Post("/products/{id}/questions", handlers.CreateQuestionHandler(client))
+		r.Delete("/questions/{questionID}", handlers.DeleteQuestionHandler(client))
+		r.Post("/questions/{questionID}/answers", handlers.CreateAnswerHandler(client))
+		r.Delete("/answers/{answerID}", handlers.DeleteAnswerHandler(client))
+
+		// Votes sur les questions et les réponses
+		r.Post("/questions/{questionID}/upvote", handlers.UpvoteQuestionHandler(client))
+		r.Delete("/questions/{questionID}/upvote", handlers.RemoveQuestionUpvoteHandler(client))
+		r.Post("/answers/{answerID}/upvote", handlers.UpvoteAnswerHandler(client))
+		r.Delete("/answers/{answerID}/upvote", handlers.RemoveAnswerUpvoteHandler(client))
+	})
+
+	// Modération (admin uniquement)
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/admin/questions", handlers.GetQuestionsForModerationHandler(client))
+		r.Post("/admin/questions/{questionID}/approve", handlers.ApproveQuestionHandler(client))
+		r.Post("/admin/questions/{questionID}/reject", handlers.RejectQuestionHandler(client))
+		r.Delete("/admin/questions/{questionID}", handlers.DeleteQuestionAsAdminHandler(client))
+		r.Get("/admin/answers", handlers.GetAnswersForModerationHandler(client))
+		r.Post("/admin/answers/{answerID}/approve", handlers.ApproveAnswerHandler(client))
+		r.Post("/admin/answers/{answerID}/reject", handlers.RejectAnswerHandler(client))
+		r.Delete("/admin/answers/{answerID}", handlers.DeleteAnswerAsAdminHandler(client))
+	})
+}
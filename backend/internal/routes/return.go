@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+	"api/internal/payments"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterReturnRoutes enregistre les routes des retours (RMA)
+func RegisterReturnRoutes(r chi.Router, client *db.PrismaClient, registry *payments.Registry) {
+	// Routes pour utilisateurs authentifiés
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
+		r.Post("/orders/{id}/returns", handlers.CreateReturnHandler(client))
+		r.Get("/orders/{id}/returns", handlers.GetOrderReturnsHandler(client))
+	})
+
+	// Routes admin
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/admin/returns", handlers.GetAllReturnsHandler(client))
+		r.Get("/admin/returns/{id}", handlers.GetReturnHandler(client))
+		r.Post("/admin/returns/{id}/approve", handlers.ApproveReturnHandler(client))
+		r.Post("/admin/returns/{id}/reject", handlers.RejectReturnHandler(client))
+		r.Post("/admin/returns/{id}/receive", handlers.ReceiveReturnHandler(client, registry))
+	})
+}
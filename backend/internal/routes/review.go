@@ -15,6 +15,7 @@ func ReviewRoutes(client *db.PrismaClient) chi.Router {
 	// Routes authentifiées pour les avis
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
 
 		// Créer un avis pour un produit
 		r.Post("/products/{productID}/reviews", handlers.CreateReviewHandler(client))
@@ -27,6 +28,10 @@ func ReviewRoutes(client *db.PrismaClient) chi.Router {
 
 		// Supprimer un avis
 		r.Delete("/reviews/{reviewID}", handlers.DeleteReviewHandler(client))
+
+		// Voter « avis utile » / retirer son vote
+		r.Post("/reviews/{reviewID}/helpful", handlers.VoteReviewHelpfulHandler(client))
+		r.Delete("/reviews/{reviewID}/helpful", handlers.RemoveReviewHelpfulVoteHandler(client))
 	})
 
 	// Route publique pour récupérer tous les avis d'un produit (authentifiée)
@@ -35,5 +40,21 @@ func ReviewRoutes(client *db.PrismaClient) chi.Router {
 		r.Get("/products/{productID}/reviews", handlers.GetProductReviewsHandler(client))
 	})
 
+	// Modération des avis (admin uniquement)
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/admin/reviews", handlers.GetReviewsForModerationHandler(client))
+		r.Post("/admin/reviews/{reviewID}/approve", handlers.ApproveReviewHandler(client))
+		r.Post("/admin/reviews/{reviewID}/reject", handlers.RejectReviewHandler(client))
+		r.Delete("/admin/reviews/{reviewID}", handlers.DeleteReviewAsAdminHandler(client))
+
+		// Réponse publique de la marque à un avis
+		r.Post("/admin/reviews/{reviewID}/reply", handlers.CreateReviewReplyHandler(client))
+		r.Put("/admin/reviews/{reviewID}/reply", handlers.UpdateReviewReplyHandler(client))
+		r.Delete("/admin/reviews/{reviewID}/reply", handlers.DeleteReviewReplyHandler(client))
+	})
+
 	return r
 }
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterShippingRoutes enregistre les routes des modes de livraison
+func RegisterShippingRoutes(r chi.Router, client *db.PrismaClient) {
+	// Routes pour utilisateurs authentifiés : modes proposés à la commande
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Get("/shipping-methods", handlers.GetShippingMethodsHandler(client))
+	})
+
+	// Routes admin
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/admin/shipping-methods", handlers.GetAllShippingMethodsHandler(client))
+		r.Post("/admin/shipping-methods", handlers.CreateShippingMethodHandler(client))
+		r.Put("/admin/shipping-methods/{id}", handlers.UpdateShippingMethodHandler(client))
+		r.Delete("/admin/shipping-methods/{id}", handlers.DeleteShippingMethodHandler(client))
+	})
+}
@@ -18,14 +18,22 @@ func RegisterUserRoutes(r chi.Router, client *db.PrismaClient) {
 	// Routes authentifiées : utilisateur peut voir/modifier son propre profil
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.Idempotency(client))
 		r.Get("/user/{id}", handlers.GetUserHandler(client))
 		r.Put("/user/{id}", handlers.UpdateUserHandler(client))
+		r.Get("/users/me/profile", handlers.GetMySkinProfileHandler(client))
+		r.Put("/users/me/profile", handlers.UpdateMySkinProfileHandler(client))
+		r.Get("/users/me/addresses", handlers.GetMyAddressesHandler(client))
+		r.Post("/users/me/addresses", handlers.CreateMyAddressHandler(client))
+		r.Put("/users/me/addresses/{id}", handlers.UpdateMyAddressHandler(client))
+		r.Delete("/users/me/addresses/{id}", handlers.DeleteMyAddressHandler(client))
 	})
 
 	// Routes admin uniquement : gestion de tous les utilisateurs
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares.AuthMiddleware)
 		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
 		r.Get("/admin/users", handlers.GetAllUsersHandler(client))
 		r.Delete("/admin/user/{id}", handlers.DeleteUserHandler(client))
 	})
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"api/internal/db"
+	"api/internal/handlers"
+	"api/internal/middlewares"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterWebhookRoutes enregistre les routes de gestion des webhooks sortants
+func RegisterWebhookRoutes(r chi.Router, client *db.PrismaClient) {
+	// Routes admin uniquement
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.AuthMiddleware)
+		r.Use(middlewares.RequireRole("ADMIN"))
+		r.Use(middlewares.Idempotency(client))
+		r.Get("/admin/webhooks", handlers.GetWebhookEndpointsHandler(client))
+		r.Post("/admin/webhooks", handlers.CreateWebhookEndpointHandler(client))
+		r.Get("/admin/webhooks/{id}", handlers.GetWebhookEndpointHandler(client))
+		r.Put("/admin/webhooks/{id}", handlers.UpdateWebhookEndpointHandler(client))
+		r.Delete("/admin/webhooks/{id}", handlers.DeleteWebhookEndpointHandler(client))
+		r.Post("/admin/webhooks/{id}/rotate-secret", handlers.RotateWebhookSecretHandler(client))
+		r.Get("/admin/webhooks/{id}/deliveries", handlers.GetWebhookDeliveriesHandler(client))
+		r.Post("/admin/webhooks/{id}/deliveries/{deliveryID}/redeliver", handlers.RedeliverWebhookHandler(client))
+	})
+}
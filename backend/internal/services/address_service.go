@@ -0,0 +1,246 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetUserAddresses récupère le carnet d'adresses d'un utilisateur (adresse par défaut en premier)
+func GetUserAddresses(client *db.PrismaClient, userID string) ([]dtos.AddressResponse, error) {
+	ctx := context.Background()
+
+	addresses, err := client.Address.FindMany(
+		db.Address.UserID.Equals(userID),
+	).OrderBy(
+		db.Address.IsDefault.Order(db.SortOrderDesc),
+		db.Address.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des adresses: %w", err)
+	}
+
+	result := make([]dtos.AddressResponse, len(addresses))
+	for i := range addresses {
+		result[i] = *convertAddressToDTO(&addresses[i])
+	}
+
+	return result, nil
+}
+
+// CreateAddress ajoute une adresse au carnet d'un utilisateur
+// La première adresse devient automatiquement l'adresse par défaut
+func CreateAddress(client *db.PrismaClient, userID string, req dtos.AddressRequest) (*dtos.AddressResponse, error) {
+	ctx := context.Background()
+
+	country, err := validateAddressRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := client.Address.FindMany(
+		db.Address.UserID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des adresses: %w", err)
+	}
+	isDefault := req.IsDefault || len(existing) == 0
+
+	var txs []db.PrismaTransaction
+	if isDefault {
+		txs = append(txs, unsetDefaultAddressTx(client, userID))
+	}
+	createTx := client.Address.CreateOne(
+		db.Address.FullName.Set(strings.TrimSpace(req.FullName)),
+		db.Address.Line1.Set(strings.TrimSpace(req.Line1)),
+		db.Address.PostalCode.Set(strings.TrimSpace(req.PostalCode)),
+		db.Address.City.Set(strings.TrimSpace(req.City)),
+		db.Address.User.Link(db.User.ID.Equals(userID)),
+		append(addressOptionalParams(req, country), db.Address.IsDefault.Set(isDefault))...,
+	).Tx()
+	txs = append(txs, createTx)
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de l'adresse: %w", err)
+	}
+
+	return convertAddressToDTO(createTx.Result()), nil
+}
+
+// UpdateAddress met à jour une adresse du carnet d'un utilisateur
+// L'adresse par défaut ne peut pas perdre ce statut directement : il faut désigner une autre adresse par défaut
+func UpdateAddress(client *db.PrismaClient, userID, addressID string, req dtos.AddressRequest) (*dtos.AddressResponse, error) {
+	ctx := context.Background()
+
+	address, err := findUserAddress(client, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	country, err := validateAddressRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	params := append([]db.AddressSetParam{
+		db.Address.FullName.Set(strings.TrimSpace(req.FullName)),
+		db.Address.Line1.Set(strings.TrimSpace(req.Line1)),
+		db.Address.PostalCode.Set(strings.TrimSpace(req.PostalCode)),
+		db.Address.City.Set(strings.TrimSpace(req.City)),
+	}, addressOptionalParams(req, country)...)
+
+	var txs []db.PrismaTransaction
+	if req.IsDefault && !address.IsDefault {
+		txs = append(txs, unsetDefaultAddressTx(client, userID))
+		params = append(params, db.Address.IsDefault.Set(true))
+	}
+	updateTx := client.Address.FindUnique(
+		db.Address.ID.Equals(addressID),
+	).Update(params...).Tx()
+	txs = append(txs, updateTx)
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour de l'adresse: %w", err)
+	}
+
+	return convertAddressToDTO(updateTx.Result()), nil
+}
+
+// DeleteAddress supprime une adresse du carnet d'un utilisateur
+// Les commandes conservent leur copie de l'adresse ; si l'adresse par défaut est supprimée,
+// la plus ancienne des adresses restantes la remplace
+func DeleteAddress(client *db.PrismaClient, userID, addressID string) error {
+	ctx := context.Background()
+
+	address, err := findUserAddress(client, userID, addressID)
+	if err != nil {
+		return err
+	}
+
+	txs := []db.PrismaTransaction{
+		client.Address.FindUnique(
+			db.Address.ID.Equals(addressID),
+		).Delete().Tx(),
+	}
+	if address.IsDefault {
+		others, err := client.Address.FindMany(
+			db.Address.UserID.Equals(userID),
+			db.Address.ID.Not(addressID),
+		).OrderBy(
+			db.Address.CreatedAt.Order(db.SortOrderAsc),
+		).Take(1).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("erreur lors de la récupération des adresses: %w", err)
+		}
+		if len(others) > 0 {
+			txs = append(txs, client.Address.FindUnique(
+				db.Address.ID.Equals(others[0].ID),
+			).Update(
+				db.Address.IsDefault.Set(true),
+			).Tx())
+		}
+	}
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de la suppression de l'adresse: %w", err)
+	}
+
+	return nil
+}
+
+// findUserAddress récupère une adresse en vérifiant qu'elle appartient à l'utilisateur
+func findUserAddress(client *db.PrismaClient, userID, addressID string) (*db.AddressModel, error) {
+	ctx := context.Background()
+
+	address, err := client.Address.FindUnique(
+		db.Address.ID.Equals(addressID),
+	).Exec(ctx)
+	if err != nil || address == nil || address.UserID != userID {
+		return nil, fmt.Errorf("adresse non trouvée")
+	}
+
+	return address, nil
+}
+
+// validateAddressRequest vérifie les champs obligatoires et retourne le code pays normalisé
+func validateAddressRequest(req dtos.AddressRequest) (string, error) {
+	if strings.TrimSpace(req.FullName) == "" {
+		return "", fmt.Errorf("le destinataire est requis")
+	}
+	if strings.TrimSpace(req.Line1) == "" {
+		return "", fmt.Errorf("l'adresse est requise")
+	}
+	if strings.TrimSpace(req.PostalCode) == "" {
+		return "", fmt.Errorf("le code postal est requis")
+	}
+	if strings.TrimSpace(req.City) == "" {
+		return "", fmt.Errorf("la ville est requise")
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(req.Country))
+	if country == "" {
+		country = "FR"
+	}
+	if len(country) != 2 || strings.Trim(country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("code pays invalide: %s", req.Country)
+	}
+
+	return country, nil
+}
+
+// addressOptionalParams prépare les champs optionnels d'une adresse (une chaîne vide les supprime)
+func addressOptionalParams(req dtos.AddressRequest, country string) []db.AddressSetParam {
+	params := []db.AddressSetParam{
+		db.Address.Country.Set(country),
+	}
+	if label := strings.TrimSpace(req.Label); label != "" {
+		params = append(params, db.Address.Label.Set(label))
+	} else {
+		params = append(params, db.Address.Label.SetOptional(nil))
+	}
+	if line2 := strings.TrimSpace(req.Line2); line2 != "" {
+		params = append(params, db.Address.Line2.Set(line2))
+	} else {
+		params = append(params, db.Address.Line2.SetOptional(nil))
+	}
+	if phone := strings.TrimSpace(req.Phone); phone != "" {
+		params = append(params, db.Address.Phone.Set(phone))
+	} else {
+		params = append(params, db.Address.Phone.SetOptional(nil))
+	}
+	return params
+}
+
+// unsetDefaultAddressTx retire le statut par défaut des adresses d'un utilisateur
+func unsetDefaultAddressTx(client *db.PrismaClient, userID string) db.PrismaTransaction {
+	return client.Address.FindMany(
+		db.Address.UserID.Equals(userID),
+		db.Address.IsDefault.Equals(true),
+	).Update(
+		db.Address.IsDefault.Set(false),
+	).Tx()
+}
+
+// convertAddressToDTO convertit un AddressModel en AddressResponse
+func convertAddressToDTO(address *db.AddressModel) *dtos.AddressResponse {
+	label, _ := address.Label()
+	line2, _ := address.Line2()
+	phone, _ := address.Phone()
+
+	return &dtos.AddressResponse{
+		ID:         address.ID,
+		Label:      string(label),
+		FullName:   address.FullName,
+		Line1:      address.Line1,
+		Line2:      string(line2),
+		PostalCode: address.PostalCode,
+		City:       address.City,
+		Country:    address.Country,
+		Phone:      string(phone),
+		IsDefault:  address.IsDefault,
+		CreatedAt:  address.CreatedAt,
+		UpdatedAt:  address.UpdatedAt,
+	}
+}
@@ -3,8 +3,10 @@ package services
 import (
 	"api/internal/db"
 	"api/internal/dtos"
+	"api/internal/utils"
 	"context"
 	"fmt"
+	"sort"
 )
 
 // GetAllCategories récupère toutes les catégories
@@ -17,13 +19,8 @@ func GetAllCategories(client *db.PrismaClient) ([]dtos.CategoryResponse, error)
 	}
 
 	result := make([]dtos.CategoryResponse, len(categories))
-	for i, c := range categories {
-		result[i] = dtos.CategoryResponse{
-			ID:        c.ID,
-			Name:      c.Name,
-			CreatedAt: c.CreatedAt,
-			UpdatedAt: c.UpdatedAt,
-		}
+	for i := range categories {
+		result[i] = *convertCategoryToDTO(&categories[i])
 	}
 
 	return result, nil
@@ -44,18 +41,75 @@ func GetCategoryByID(client *db.PrismaClient, categoryID string) (*dtos.Category
 		return nil, nil
 	}
 
-	return &dtos.CategoryResponse{
-		ID:        category.ID,
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
-	}, nil
+	return convertCategoryToDTO(category), nil
+}
+
+// GetCategoryTree retourne l'arborescence publique des catégories
+// Le nombre de produits d'une catégorie inclut ceux de toutes ses sous-catégories.
+func GetCategoryTree(client *db.PrismaClient) ([]dtos.CategoryTreeResponse, error) {
+	ctx := context.Background()
+
+	categories, err := client.Category.FindMany().Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des catégories: %w", err)
+	}
+
+	var rows []struct {
+		CategoryID string    `json:"categoryID"`
+		Count      db.RawInt `json:"count"`
+	}
+	err = client.Prisma.QueryRaw(
+		`SELECT "categoryID", COUNT(*)::int AS "count"
+		FROM "Product"
+		WHERE "categoryID" IS NOT NULL
+		GROUP BY "categoryID"`,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du comptage des produits par catégorie: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = int(row.Count)
+	}
+
+	children := categoryChildren(categories)
+
+	var build func(parentID string) []dtos.CategoryTreeResponse
+	build = func(parentID string) []dtos.CategoryTreeResponse {
+		nodes := make([]dtos.CategoryTreeResponse, 0, len(children[parentID]))
+		for _, c := range children[parentID] {
+			node := dtos.CategoryTreeResponse{
+				ID:           c.ID,
+				Name:         c.Name,
+				Slug:         c.Slug,
+				SortOrder:    c.SortOrder,
+				ProductCount: counts[c.ID],
+				Children:     build(c.ID),
+			}
+			if v, ok := c.Description(); ok {
+				node.Description = &v
+			}
+			for _, child := range node.Children {
+				node.ProductCount += child.ProductCount
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	return build(""), nil
 }
 
 // CreateCategory crée une nouvelle catégorie
 func CreateCategory(client *db.PrismaClient, req dtos.CategoryRequest) (*dtos.CategoryResponse, error) {
 	ctx := context.Background()
 
+	if req.TaxRate != nil {
+		if err := validateTaxRate(*req.TaxRate); err != nil {
+			return nil, err
+		}
+	}
+
 	// Vérifier si le nom existe déjà
 	existingCategory, err := client.Category.FindUnique(
 		db.Category.Name.Equals(req.Name),
@@ -64,25 +118,46 @@ func CreateCategory(client *db.PrismaClient, req dtos.CategoryRequest) (*dtos.Ca
 		return nil, fmt.Errorf("une catégorie avec ce nom existe déjà")
 	}
 
+	slug, err := resolveCategorySlug(client, "", req.Slug, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	params := []db.CategorySetParam{
+		db.Category.Description.SetOptional(req.Description),
+		db.Category.SortOrder.Set(req.SortOrder),
+		db.Category.TaxRate.SetOptional(req.TaxRate),
+	}
+	if req.ParentID != nil && *req.ParentID != "" {
+		if err := validateCategoryParent(client, "", *req.ParentID); err != nil {
+			return nil, err
+		}
+		params = append(params, db.Category.Parent.Link(db.Category.ID.Equals(*req.ParentID)))
+	}
+
 	category, err := client.Category.CreateOne(
 		db.Category.Name.Set(req.Name),
+		db.Category.Slug.Set(slug),
+		params...,
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la création de la catégorie: %w", err)
 	}
 
-	return &dtos.CategoryResponse{
-		ID:        category.ID,
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
-	}, nil
+	return convertCategoryToDTO(category), nil
 }
 
 // UpdateCategory met à jour une catégorie
+// Sans taxRate, la catégorie revient au taux de TVA par défaut ; sans parentID, elle devient une catégorie racine
 func UpdateCategory(client *db.PrismaClient, categoryID string, req dtos.CategoryRequest) (*dtos.CategoryResponse, error) {
 	ctx := context.Background()
 
+	if req.TaxRate != nil {
+		if err := validateTaxRate(*req.TaxRate); err != nil {
+			return nil, err
+		}
+	}
+
 	// Vérifier que la catégorie existe
 	existingCategory, err := client.Category.FindUnique(
 		db.Category.ID.Equals(categoryID),
@@ -101,38 +176,155 @@ func UpdateCategory(client *db.PrismaClient, categoryID string, req dtos.Categor
 		}
 	}
 
+	// Sans slug, l'identifiant d'URL existant est conservé
+	slug := existingCategory.Slug
+	if req.Slug != "" && req.Slug != existingCategory.Slug {
+		if slug, err = resolveCategorySlug(client, categoryID, req.Slug, req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	params := []db.CategorySetParam{
+		db.Category.Name.Set(req.Name),
+		db.Category.Slug.Set(slug),
+		db.Category.Description.SetOptional(req.Description),
+		db.Category.SortOrder.Set(req.SortOrder),
+		db.Category.TaxRate.SetOptional(req.TaxRate),
+	}
+	if req.ParentID != nil && *req.ParentID != "" {
+		if err := validateCategoryParent(client, categoryID, *req.ParentID); err != nil {
+			return nil, err
+		}
+		params = append(params, db.Category.Parent.Link(db.Category.ID.Equals(*req.ParentID)))
+	} else {
+		params = append(params, db.Category.Parent.Unlink())
+	}
+
 	category, err := client.Category.FindUnique(
 		db.Category.ID.Equals(categoryID),
-	).Update(
-		db.Category.Name.Set(req.Name),
-	).Exec(ctx)
+	).Update(params...).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la mise à jour de la catégorie: %w", err)
 	}
 
-	return &dtos.CategoryResponse{
-		ID:        category.ID,
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
-	}, nil
+	return convertCategoryToDTO(category), nil
 }
 
-// DeleteCategory supprime une catégorie
-func DeleteCategory(client *db.PrismaClient, categoryID string) error {
+// DeleteCategory supprime une catégorie et retourne le nombre de produits qu'elle contient
+// Une catégorie contenant des sous-catégories ne peut pas être supprimée. Une catégorie contenant
+// des produits n'est supprimée que si reassignTo désigne la catégorie qui les reçoit, ou si detach
+// les laisse sans catégorie ; sinon l'erreur "la catégorie contient des produits" est retournée
+// avec le nombre de produits concernés. Les produits et la catégorie sont modifiés dans une même transaction.
+func DeleteCategory(client *db.PrismaClient, categoryID, reassignTo string, detach bool) (int, error) {
 	ctx := context.Background()
 
+	if reassignTo != "" && detach {
+		return 0, fmt.Errorf("reassignTo et detach ne peuvent pas être utilisés ensemble")
+	}
+	if reassignTo == categoryID {
+		return 0, fmt.Errorf("une catégorie ne peut pas être réaffectée à elle-même")
+	}
+
 	_, err := client.Category.FindUnique(
 		db.Category.ID.Equals(categoryID),
-	).Delete().Exec(ctx)
+	).Exec(ctx)
+	if err == db.ErrNotFound {
+		return 0, fmt.Errorf("catégorie non trouvée")
+	}
 	if err != nil {
-		return fmt.Errorf("erreur lors de la suppression de la catégorie: %w", err)
+		return 0, fmt.Errorf("erreur lors de la suppression de la catégorie: %w", err)
 	}
 
-	return nil
+	children, err := client.Category.FindMany(
+		db.Category.ParentID.Equals(categoryID),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la suppression de la catégorie: %w", err)
+	}
+	if len(children) > 0 {
+		return 0, fmt.Errorf("la catégorie contient des sous-catégories")
+	}
+
+	if reassignTo != "" {
+		target, err := client.Category.FindUnique(
+			db.Category.ID.Equals(reassignTo),
+		).Exec(ctx)
+		if err != nil || target == nil {
+			return 0, fmt.Errorf("catégorie de destination non trouvée")
+		}
+	}
+
+	productCount, err := countCategoryProducts(client, categoryID)
+	if err != nil {
+		return 0, err
+	}
+	if productCount > 0 && reassignTo == "" && !detach {
+		return productCount, fmt.Errorf("la catégorie contient des produits")
+	}
+
+	// La catégorie est verrouillée : aucun produit ne peut y être rattaché avant la fin de la transaction.
+	// Les produits ne sont déplacés ou détachés que si l'option correspondante est fournie.
+	txs := []db.PrismaTransaction{
+		client.Prisma.ExecuteRaw(`SELECT 1 FROM "Category" WHERE "id" = $1 FOR UPDATE`, categoryID).Tx(),
+	}
+	switch {
+	case reassignTo != "":
+		txs = append(txs, client.Prisma.ExecuteRaw(
+			`UPDATE "Product" SET "categoryID" = $2, "updatedAt" = CURRENT_TIMESTAMP WHERE "categoryID" = $1`,
+			categoryID, reassignTo,
+		).Tx())
+	case detach:
+		txs = append(txs, client.Prisma.ExecuteRaw(
+			`UPDATE "Product" SET "categoryID" = NULL, "updatedAt" = CURRENT_TIMESTAMP WHERE "categoryID" = $1`,
+			categoryID,
+		).Tx())
+	}
+	// Sans option, un produit rattaché depuis le comptage empêche la suppression au lieu d'être détaché
+	deleteTx := client.Prisma.ExecuteRaw(
+		`DELETE FROM "Category" WHERE "id" = $1 AND NOT EXISTS (SELECT 1 FROM "Product" WHERE "categoryID" = $1)`,
+		categoryID,
+	).Tx()
+	txs = append(txs, deleteTx)
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return 0, fmt.Errorf("erreur lors de la suppression de la catégorie: %w", err)
+	}
+	if deleteTx.Result().Count == 0 {
+		productCount, err := countCategoryProducts(client, categoryID)
+		if err != nil {
+			return 0, err
+		}
+		if productCount == 0 {
+			return 0, fmt.Errorf("catégorie non trouvée")
+		}
+		return productCount, fmt.Errorf("la catégorie contient des produits")
+	}
+
+	return productCount, nil
 }
 
-// PatchCategory met à jour partiellement une catégorie (seul le nom peut être mis à jour)
+// countCategoryProducts compte les produits rattachés directement à une catégorie
+func countCategoryProducts(client *db.PrismaClient, categoryID string) (int, error) {
+	ctx := context.Background()
+
+	var rows []struct {
+		Count db.RawInt `json:"count"`
+	}
+	err := client.Prisma.QueryRaw(
+		`SELECT COUNT(*)::int AS "count" FROM "Product" WHERE "categoryID" = $1`,
+		categoryID,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors du comptage des produits de la catégorie: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	return int(rows[0].Count), nil
+}
+
+// PatchCategory met à jour partiellement une catégorie
 func PatchCategory(client *db.PrismaClient, categoryID string, req dtos.PatchCategoryRequest) (*dtos.CategoryResponse, error) {
 	ctx := context.Background()
 
@@ -145,39 +337,253 @@ func PatchCategory(client *db.PrismaClient, categoryID string, req dtos.PatchCat
 	}
 
 	// Si aucun champ n'est fourni, retourner une erreur
-	if req.Name == nil {
+	if req.Name == nil && req.Slug == nil && req.Description == nil && req.SortOrder == nil && req.ParentID == nil && req.TaxRate == nil {
 		return nil, fmt.Errorf("au moins un champ doit être fourni pour la mise à jour")
 	}
 
-	// Vérifier si le nouveau nom est déjà utilisé par une autre catégorie
-	if *req.Name != existingCategory.Name {
-		nameExists, _ := client.Category.FindUnique(
-			db.Category.Name.Equals(*req.Name),
-		).Exec(ctx)
-		if nameExists != nil {
-			return nil, fmt.Errorf("une catégorie avec ce nom existe déjà")
+	var params []db.CategorySetParam
+
+	if req.Name != nil {
+		// Vérifier si le nouveau nom est déjà utilisé par une autre catégorie
+		if *req.Name != existingCategory.Name {
+			nameExists, _ := client.Category.FindUnique(
+				db.Category.Name.Equals(*req.Name),
+			).Exec(ctx)
+			if nameExists != nil {
+				return nil, fmt.Errorf("une catégorie avec ce nom existe déjà")
+			}
 		}
+
+		// Validation basique
+		if *req.Name == "" {
+			return nil, fmt.Errorf("le nom de la catégorie ne peut pas être vide")
+		}
+		params = append(params, db.Category.Name.Set(*req.Name))
+	}
+
+	if req.Slug != nil && *req.Slug != existingCategory.Slug {
+		if *req.Slug == "" {
+			return nil, fmt.Errorf("slug invalide: le slug ne peut pas être vide")
+		}
+		slug, err := resolveCategorySlug(client, categoryID, *req.Slug, existingCategory.Name)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.Category.Slug.Set(slug))
+	}
+
+	if req.Description != nil {
+		if *req.Description == "" {
+			params = append(params, db.Category.Description.SetOptional(nil))
+		} else {
+			params = append(params, db.Category.Description.Set(*req.Description))
+		}
+	}
+
+	if req.SortOrder != nil {
+		params = append(params, db.Category.SortOrder.Set(*req.SortOrder))
 	}
 
-	// Validation basique
-	if *req.Name == "" {
-		return nil, fmt.Errorf("le nom de la catégorie ne peut pas être vide")
+	if req.ParentID != nil {
+		// Une chaîne vide fait remonter la catégorie à la racine
+		if *req.ParentID == "" {
+			params = append(params, db.Category.Parent.Unlink())
+		} else {
+			if err := validateCategoryParent(client, categoryID, *req.ParentID); err != nil {
+				return nil, err
+			}
+			params = append(params, db.Category.Parent.Link(db.Category.ID.Equals(*req.ParentID)))
+		}
+	}
+
+	if req.TaxRate != nil {
+		if err := validateTaxRate(*req.TaxRate); err != nil {
+			return nil, err
+		}
+		params = append(params, db.Category.TaxRate.Set(*req.TaxRate))
 	}
 
 	// Mettre à jour la catégorie
 	category, err := client.Category.FindUnique(
 		db.Category.ID.Equals(categoryID),
-	).Update(
-		db.Category.Name.Set(*req.Name),
-	).Exec(ctx)
+	).Update(params...).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la mise à jour de la catégorie: %w", err)
 	}
 
+	return convertCategoryToDTO(category), nil
+}
+
+// convertCategoryToDTO convertit un CategoryModel en CategoryResponse
+func convertCategoryToDTO(category *db.CategoryModel) *dtos.CategoryResponse {
+	var taxRate *float64
+	if v, ok := category.TaxRate(); ok {
+		taxRate = &v
+	}
+
+	var description *string
+	if v, ok := category.Description(); ok {
+		description = &v
+	}
+
+	var parentID *string
+	if v, ok := category.ParentID(); ok {
+		parentID = &v
+	}
+
 	return &dtos.CategoryResponse{
-		ID:        category.ID,
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
-	}, nil
+		ID:          category.ID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: description,
+		SortOrder:   category.SortOrder,
+		ParentID:    parentID,
+		TaxRate:     taxRate,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+// categoryChildren regroupe les catégories par parent ("" pour les catégories racines),
+// triées par ordre d'affichage puis par nom
+func categoryChildren(categories []db.CategoryModel) map[string][]db.CategoryModel {
+	children := make(map[string][]db.CategoryModel)
+	for _, c := range categories {
+		parentID, _ := c.ParentID()
+		children[parentID] = append(children[parentID], c)
+	}
+	for _, list := range children {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].SortOrder != list[j].SortOrder {
+				return list[i].SortOrder < list[j].SortOrder
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+	return children
+}
+
+// categorySubtreeIDs retourne l'ID de la catégorie identifiée par son slug et ceux de toutes ses sous-catégories
+func categorySubtreeIDs(client *db.PrismaClient, slug string) ([]string, error) {
+	ctx := context.Background()
+
+	categories, err := client.Category.FindMany().Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des catégories: %w", err)
+	}
+
+	var rootID string
+	for _, c := range categories {
+		if c.Slug == slug {
+			rootID = c.ID
+			break
+		}
+	}
+	if rootID == "" {
+		return nil, fmt.Errorf("catégorie inconnue: %s", slug)
+	}
+
+	children := categoryChildren(categories)
+	ids := []string{rootID}
+	for i := 0; i < len(ids); i++ {
+		for _, c := range children[ids[i]] {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// validateCategoryParent vérifie que la catégorie parente existe et qu'elle n'est ni la catégorie
+// elle-même ni l'une de ses sous-catégories (categoryID vide à la création)
+func validateCategoryParent(client *db.PrismaClient, categoryID, parentID string) error {
+	ctx := context.Background()
+
+	if parentID == categoryID {
+		return fmt.Errorf("une catégorie ne peut pas être sa propre catégorie parente")
+	}
+
+	parent, err := client.Category.FindUnique(
+		db.Category.ID.Equals(parentID),
+	).Exec(ctx)
+	if err != nil || parent == nil {
+		return fmt.Errorf("catégorie parente non trouvée")
+	}
+	if categoryID == "" {
+		return nil
+	}
+
+	// Remonter les ancêtres du parent : la catégorie ne doit pas en faire partie
+	categories, err := client.Category.FindMany().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des catégories: %w", err)
+	}
+	parents := make(map[string]string, len(categories))
+	for _, c := range categories {
+		if p, ok := c.ParentID(); ok {
+			parents[c.ID] = p
+		}
+	}
+	visited := make(map[string]bool)
+	for id := parentID; id != "" && !visited[id]; id = parents[id] {
+		if id == categoryID {
+			return fmt.Errorf("une catégorie ne peut pas être déplacée dans l'une de ses sous-catégories")
+		}
+		visited[id] = true
+	}
+
+	return nil
+}
+
+// resolveCategorySlug valide le slug demandé, ou en dérive un du nom s'il est absent
+// Un slug fourni doit déjà être normalisé (minuscules, chiffres et tirets) ; un slug dérivé
+// reçoit un suffixe numérique (-2, -3, ...) s'il est déjà pris. categoryID est vide à la création.
+func resolveCategorySlug(client *db.PrismaClient, categoryID, requested, name string) (string, error) {
+	ctx := context.Background()
+
+	if requested != "" {
+		if utils.Slugify(requested) != requested {
+			return "", fmt.Errorf("slug invalide: utilisez uniquement des minuscules, des chiffres et des tirets (ex: %s)", utils.Slugify(requested))
+		}
+		existing, err := client.Category.FindUnique(
+			db.Category.Slug.Equals(requested),
+		).Exec(ctx)
+		if err == nil && existing != nil && existing.ID != categoryID {
+			return "", fmt.Errorf("une catégorie avec ce slug existe déjà")
+		}
+		return requested, nil
+	}
+
+	base := categorySlugBase(name)
+	similar, err := client.Category.FindMany(
+		db.Category.Slug.StartsWith(base),
+	).Exec(ctx)
+	if err != nil {
+		return "", fmt.Errorf("erreur lors de la vérification du slug: %w", err)
+	}
+	taken := make(map[string]bool, len(similar))
+	for _, c := range similar {
+		if c.ID != categoryID {
+			taken[c.Slug] = true
+		}
+	}
+
+	return availableCategorySlug(base, taken), nil
+}
+
+// categorySlugBase dérive du nom le slug d'une catégorie, avant dédoublonnage
+func categorySlugBase(name string) string {
+	if slug := utils.Slugify(name); slug != "" {
+		return slug
+	}
+	return "categorie"
+}
+
+// availableCategorySlug retourne base, ou base suffixé d'un numéro (-2, -3, ...) s'il est déjà pris
+func availableCategorySlug(base string, taken map[string]bool) string {
+	slug := base
+	for i := 2; taken[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+	return slug
 }
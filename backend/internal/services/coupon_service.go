@@ -0,0 +1,554 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Types de remise acceptés par l'API
+var couponTypeValues = map[string]db.CouponType{
+	"PERCENTAGE":    db.CouponTypePercentage,
+	"FIXED":         db.CouponTypeFixed,
+	"FREE_SHIPPING": db.CouponTypeFreeShipping,
+}
+
+// couponLine représente une ligne de commande soumise à un code promo
+type couponLine struct {
+	ProductID  string
+	CategoryID string
+	Total      float64 // Prix unitaire x quantité
+}
+
+// appliedCoupon est le résultat de l'application d'un code promo à une commande
+type appliedCoupon struct {
+	Coupon        *db.CouponModel
+	Discount      float64   // Remise totale
+	LineDiscounts []float64 // Remise par ligne (même ordre que les lignes fournies)
+	FreeShipping  bool
+}
+
+// normalizeCouponCode normalise un code promo (espaces retirés, majuscules)
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// roundAmount arrondit un montant au centime
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// GetAllCoupons récupère tous les codes promo (admin only)
+func GetAllCoupons(client *db.PrismaClient) ([]dtos.CouponResponse, error) {
+	ctx := context.Background()
+
+	coupons, err := client.Coupon.FindMany().OrderBy(
+		db.Coupon.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des codes promo: %w", err)
+	}
+
+	result := make([]dtos.CouponResponse, len(coupons))
+	for i := range coupons {
+		result[i] = *convertCouponToDTO(&coupons[i])
+	}
+
+	return result, nil
+}
+
+// GetCouponByID récupère un code promo par son ID (admin only)
+func GetCouponByID(client *db.PrismaClient, couponID string) (*dtos.CouponResponse, error) {
+	ctx := context.Background()
+
+	coupon, err := client.Coupon.FindUnique(
+		db.Coupon.ID.Equals(couponID),
+	).Exec(ctx)
+	if err != nil || coupon == nil {
+		return nil, fmt.Errorf("coupon non trouvé")
+	}
+
+	return convertCouponToDTO(coupon), nil
+}
+
+// CreateCoupon crée un code promo (admin only)
+func CreateCoupon(client *db.PrismaClient, req dtos.CouponRequest) (*dtos.CouponResponse, error) {
+	ctx := context.Background()
+
+	code := normalizeCouponCode(req.Code)
+	couponType, params, err := couponParams(client, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCouponCodeUnique(client, code, ""); err != nil {
+		return nil, err
+	}
+
+	if req.Active != nil {
+		params = append(params, db.Coupon.Active.Set(*req.Active))
+	}
+
+	coupon, err := client.Coupon.CreateOne(
+		db.Coupon.Code.Set(code),
+		db.Coupon.Type.Set(couponType),
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création du code promo: %w", err)
+	}
+
+	return convertCouponToDTO(coupon), nil
+}
+
+// UpdateCoupon remplace les paramètres d'un code promo (admin only)
+// Les commandes passées conservent la remise obtenue
+func UpdateCoupon(client *db.PrismaClient, couponID string, req dtos.CouponRequest) (*dtos.CouponResponse, error) {
+	ctx := context.Background()
+
+	existing, err := client.Coupon.FindUnique(
+		db.Coupon.ID.Equals(couponID),
+	).Exec(ctx)
+	if err != nil || existing == nil {
+		return nil, fmt.Errorf("coupon non trouvé")
+	}
+
+	code := normalizeCouponCode(req.Code)
+	couponType, params, err := couponParams(client, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCouponCodeUnique(client, code, couponID); err != nil {
+		return nil, err
+	}
+
+	params = append(params,
+		db.Coupon.Code.Set(code),
+		db.Coupon.Type.Set(couponType),
+	)
+	if req.Active != nil {
+		params = append(params, db.Coupon.Active.Set(*req.Active))
+	}
+
+	coupon, err := client.Coupon.FindUnique(
+		db.Coupon.ID.Equals(couponID),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour du code promo: %w", err)
+	}
+
+	return convertCouponToDTO(coupon), nil
+}
+
+// DeleteCoupon supprime un code promo (admin only)
+// Les commandes qui l'ont utilisé conservent le code et la remise
+func DeleteCoupon(client *db.PrismaClient, couponID string) error {
+	ctx := context.Background()
+
+	_, err := client.Coupon.FindUnique(
+		db.Coupon.ID.Equals(couponID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if err == db.ErrNotFound {
+			return fmt.Errorf("coupon non trouvé")
+		}
+		return fmt.Errorf("erreur lors de la suppression du code promo: %w", err)
+	}
+
+	return nil
+}
+
+// GetCouponStats calcule les statistiques d'utilisation d'un code promo (admin only)
+func GetCouponStats(client *db.PrismaClient, couponID string) (*dtos.CouponStatsResponse, error) {
+	ctx := context.Background()
+
+	coupon, err := client.Coupon.FindUnique(
+		db.Coupon.ID.Equals(couponID),
+	).Exec(ctx)
+	if err != nil || coupon == nil {
+		return nil, fmt.Errorf("coupon non trouvé")
+	}
+
+	var rows []struct {
+		UsageCount     db.RawInt   `json:"usageCount"`
+		CancelledCount db.RawInt   `json:"cancelledCount"`
+		UniqueUsers    db.RawInt   `json:"uniqueUsers"`
+		TotalDiscount  db.RawFloat `json:"totalDiscount"`
+		TotalRevenue   db.RawFloat `json:"totalRevenue"`
+	}
+	err = client.Prisma.QueryRaw(
+		`SELECT
+			COUNT(*) FILTER (WHERE "status" <> 'CANCELLED')::int AS "usageCount",
+			COUNT(*) FILTER (WHERE "status" = 'CANCELLED')::int AS "cancelledCount",
+			COUNT(DISTINCT "userID") FILTER (WHERE "status" <> 'CANCELLED')::int AS "uniqueUsers",
+			COALESCE(SUM("discountAmount") FILTER (WHERE "status" <> 'CANCELLED'), 0)::float8 AS "totalDiscount",
+			COALESCE(SUM("totalAmount" - "refundedAmount") FILTER (WHERE "status" <> 'CANCELLED'), 0)::float8 AS "totalRevenue"
+		FROM "Order"
+		WHERE "couponID" = $1`,
+		couponID,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du calcul des statistiques du code promo: %w", err)
+	}
+
+	stats := &dtos.CouponStatsResponse{
+		CouponID: coupon.ID,
+		Code:     coupon.Code,
+	}
+	if len(rows) > 0 {
+		stats.UsageCount = int(rows[0].UsageCount)
+		stats.CancelledCount = int(rows[0].CancelledCount)
+		stats.UniqueUsers = int(rows[0].UniqueUsers)
+		stats.TotalDiscount = roundAmount(float64(rows[0].TotalDiscount))
+		stats.TotalRevenue = roundAmount(float64(rows[0].TotalRevenue))
+	}
+	if stats.UsageCount > 0 {
+		stats.AverageDiscount = roundAmount(stats.TotalDiscount / float64(stats.UsageCount))
+	}
+	if maxUses, ok := coupon.MaxUses(); ok {
+		remaining := maxUses - stats.UsageCount
+		if remaining < 0 {
+			remaining = 0
+		}
+		stats.RemainingUses = &remaining
+	}
+
+	return stats, nil
+}
+
+// applyCoupon vérifie qu'un code promo est utilisable par l'utilisateur pour les lignes données
+// et calcule la remise, répartie sur les lignes éligibles
+func applyCoupon(client *db.PrismaClient, userID, code string, lines []couponLine) (*appliedCoupon, error) {
+	ctx := context.Background()
+
+	coupon, err := client.Coupon.FindUnique(
+		db.Coupon.Code.Equals(normalizeCouponCode(code)),
+	).Exec(ctx)
+	if err != nil || coupon == nil || !coupon.Active {
+		return nil, fmt.Errorf("code promo invalide")
+	}
+
+	// Période de validité
+	now := time.Now()
+	if startsAt, ok := coupon.StartsAt(); ok && now.Before(startsAt) {
+		return nil, fmt.Errorf("code promo pas encore valide")
+	}
+	if endsAt, ok := coupon.EndsAt(); ok && now.After(endsAt) {
+		return nil, fmt.Errorf("code promo expiré")
+	}
+
+	// Montant minimum (sur l'ensemble de la commande, avant remise)
+	var subtotal float64
+	for _, line := range lines {
+		subtotal += line.Total
+	}
+	if minAmount, ok := coupon.MinOrderAmount(); ok && subtotal < minAmount {
+		return nil, fmt.Errorf("code promo: montant minimum de commande de %.2f non atteint", minAmount)
+	}
+
+	// Limites d'utilisation (les commandes annulées ne comptent pas)
+	// Vérification anticipée : elles sont revérifiées sous verrou dans la transaction de la commande (claimCouponTx)
+	if maxUses, ok := coupon.MaxUses(); ok {
+		uses, err := countCouponUses(client, coupon.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		if uses >= maxUses {
+			return nil, fmt.Errorf("code promo épuisé")
+		}
+	}
+	if maxUsesPerUser, ok := coupon.MaxUsesPerUser(); ok {
+		uses, err := countCouponUses(client, coupon.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if uses >= maxUsesPerUser {
+			return nil, fmt.Errorf("code promo déjà utilisé le nombre maximal de fois")
+		}
+	}
+
+	// Lignes éligibles selon la restriction produits / catégories
+	eligible := make([]bool, len(lines))
+	var eligibleTotal float64
+	for i, line := range lines {
+		eligible[i] = couponAppliesTo(coupon, line)
+		if eligible[i] {
+			eligibleTotal += line.Total
+		}
+	}
+	if eligibleTotal == 0 {
+		return nil, fmt.Errorf("code promo non applicable aux produits de la commande")
+	}
+
+	result := &appliedCoupon{
+		Coupon:        coupon,
+		LineDiscounts: make([]float64, len(lines)),
+	}
+
+	switch coupon.Type {
+	case db.CouponTypeFreeShipping:
+		result.FreeShipping = true
+
+	case db.CouponTypePercentage:
+		for i, line := range lines {
+			if eligible[i] {
+				result.LineDiscounts[i] = roundAmount(line.Total * coupon.Value / 100)
+				result.Discount += result.LineDiscounts[i]
+			}
+		}
+
+	case db.CouponTypeFixed:
+		// Montant réparti au prorata des lignes éligibles, l'arrondi est porté par la dernière
+		discount := math.Min(coupon.Value, eligibleTotal)
+		remaining := roundAmount(discount)
+		last := -1
+		for i := range lines {
+			if eligible[i] {
+				last = i
+			}
+		}
+		for i, line := range lines {
+			if !eligible[i] {
+				continue
+			}
+			if i == last {
+				result.LineDiscounts[i] = roundAmount(remaining)
+			} else {
+				result.LineDiscounts[i] = roundAmount(discount * line.Total / eligibleTotal)
+				remaining -= result.LineDiscounts[i]
+			}
+		}
+		result.Discount = discount
+	}
+	result.Discount = roundAmount(result.Discount)
+
+	return result, nil
+}
+
+// couponAppliesTo indique si une ligne de commande est concernée par le code promo
+func couponAppliesTo(coupon *db.CouponModel, line couponLine) bool {
+	if len(coupon.ProductIDs) == 0 && len(coupon.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range coupon.ProductIDs {
+		if id == line.ProductID {
+			return true
+		}
+	}
+	for _, id := range coupon.CategoryIDs {
+		if line.CategoryID != "" && id == line.CategoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// countCouponUses compte les commandes non annulées ayant utilisé un code promo
+// (toutes les commandes si userID est vide, sinon celles de l'utilisateur)
+func countCouponUses(client *db.PrismaClient, couponID, userID string) (int, error) {
+	ctx := context.Background()
+
+	var rows []struct {
+		Count db.RawInt `json:"count"`
+	}
+	err := client.Prisma.QueryRaw(
+		`SELECT COUNT(*)::int AS "count"
+		FROM "Order"
+		WHERE "couponID" = $1 AND "status" <> 'CANCELLED' AND ($2 = '' OR "userID" = $2)`,
+		couponID, userID,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la vérification du code promo: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	return int(rows[0].Count), nil
+}
+
+// Erreurs levées par la fonction SQL claim_coupon (identiques à celles d'applyCoupon)
+var couponLimitErrors = []string{
+	"code promo épuisé",
+	"code promo déjà utilisé le nombre maximal de fois",
+}
+
+// claimCouponTx revérifie maxUses et maxUsesPerUser dans la transaction qui crée la commande
+// La fonction SQL claim_coupon verrouille le code promo jusqu'à la fin de la transaction : deux commandes
+// simultanées ne peuvent pas dépasser les limites. Elle doit précéder la création de la commande.
+func claimCouponTx(client *db.PrismaClient, couponID, userID string) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(
+		`SELECT "claim_coupon"($1, $2)`,
+		couponID, userID,
+	).Tx()
+}
+
+// couponLimitError retrouve, dans l'échec d'une transaction, la limite de code promo levée par claim_coupon
+func couponLimitError(err error) error {
+	for _, message := range couponLimitErrors {
+		if strings.Contains(err.Error(), message) {
+			return errors.New(message)
+		}
+	}
+	return nil
+}
+
+// couponParams valide un CouponRequest et prépare les champs modifiables (hors code, type et statut)
+func couponParams(client *db.PrismaClient, req dtos.CouponRequest) (db.CouponType, []db.CouponSetParam, error) {
+	ctx := context.Background()
+
+	if normalizeCouponCode(req.Code) == "" {
+		return "", nil, fmt.Errorf("le code promo est requis")
+	}
+	couponType, ok := couponTypeValues[strings.ToUpper(strings.TrimSpace(req.Type))]
+	if !ok {
+		return "", nil, fmt.Errorf("type de code promo invalide: %s", req.Type)
+	}
+
+	value := req.Value
+	switch couponType {
+	case db.CouponTypePercentage:
+		if value <= 0 || value > 100 {
+			return "", nil, fmt.Errorf("le pourcentage doit être compris entre 0 et 100")
+		}
+	case db.CouponTypeFixed:
+		if value <= 0 {
+			return "", nil, fmt.Errorf("le montant de la remise doit être supérieur à 0")
+		}
+	case db.CouponTypeFreeShipping:
+		value = 0
+	}
+
+	if req.MinOrderAmount < 0 {
+		return "", nil, fmt.Errorf("le montant minimum ne peut pas être négatif")
+	}
+	if req.MaxUses < 0 || req.MaxUsesPerUser < 0 {
+		return "", nil, fmt.Errorf("les limites d'utilisation ne peuvent pas être négatives")
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return "", nil, fmt.Errorf("la date de fin doit être postérieure à la date de début")
+	}
+
+	// Les produits et catégories ciblés doivent exister
+	productIDs := uniqueStrings(req.ProductIDs)
+	if len(productIDs) > 0 {
+		products, err := client.Product.FindMany(
+			db.Product.ID.In(productIDs),
+		).Exec(ctx)
+		if err != nil {
+			return "", nil, fmt.Errorf("erreur lors de la vérification des produits: %w", err)
+		}
+		if len(products) != len(productIDs) {
+			return "", nil, fmt.Errorf("produit ciblé non trouvé")
+		}
+	}
+	categoryIDs := uniqueStrings(req.CategoryIDs)
+	if len(categoryIDs) > 0 {
+		categories, err := client.Category.FindMany(
+			db.Category.ID.In(categoryIDs),
+		).Exec(ctx)
+		if err != nil {
+			return "", nil, fmt.Errorf("erreur lors de la vérification des catégories: %w", err)
+		}
+		if len(categories) != len(categoryIDs) {
+			return "", nil, fmt.Errorf("catégorie ciblée non trouvée")
+		}
+	}
+
+	params := []db.CouponSetParam{
+		db.Coupon.Value.Set(value),
+		db.Coupon.StartsAt.SetOptional(req.StartsAt),
+		db.Coupon.EndsAt.SetOptional(req.EndsAt),
+		db.Coupon.ProductIDs.Set(productIDs),
+		db.Coupon.CategoryIDs.Set(categoryIDs),
+	}
+	// Les valeurs vides / nulles suppriment le champ
+	if req.Description != "" {
+		params = append(params, db.Coupon.Description.Set(req.Description))
+	} else {
+		params = append(params, db.Coupon.Description.SetOptional(nil))
+	}
+	if req.MinOrderAmount > 0 {
+		params = append(params, db.Coupon.MinOrderAmount.Set(req.MinOrderAmount))
+	} else {
+		params = append(params, db.Coupon.MinOrderAmount.SetOptional(nil))
+	}
+	if req.MaxUses > 0 {
+		params = append(params, db.Coupon.MaxUses.Set(req.MaxUses))
+	} else {
+		params = append(params, db.Coupon.MaxUses.SetOptional(nil))
+	}
+	if req.MaxUsesPerUser > 0 {
+		params = append(params, db.Coupon.MaxUsesPerUser.Set(req.MaxUsesPerUser))
+	} else {
+		params = append(params, db.Coupon.MaxUsesPerUser.SetOptional(nil))
+	}
+
+	return couponType, params, nil
+}
+
+// checkCouponCodeUnique vérifie que le code n'est pas utilisé par un autre coupon
+func checkCouponCodeUnique(client *db.PrismaClient, code, couponID string) error {
+	ctx := context.Background()
+
+	existing, _ := client.Coupon.FindUnique(
+		db.Coupon.Code.Equals(code),
+	).Exec(ctx)
+	if existing != nil && existing.ID != couponID {
+		return fmt.Errorf("un coupon avec ce code existe déjà")
+	}
+	return nil
+}
+
+// uniqueStrings retire les valeurs vides et les doublons d'une liste
+func uniqueStrings(values []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// convertCouponToDTO convertit un CouponModel en CouponResponse
+func convertCouponToDTO(coupon *db.CouponModel) *dtos.CouponResponse {
+	description, _ := coupon.Description()
+	minOrderAmount, _ := coupon.MinOrderAmount()
+	maxUses, _ := coupon.MaxUses()
+	maxUsesPerUser, _ := coupon.MaxUsesPerUser()
+
+	var startsAt, endsAt *time.Time
+	if v, ok := coupon.StartsAt(); ok {
+		startsAt = &v
+	}
+	if v, ok := coupon.EndsAt(); ok {
+		endsAt = &v
+	}
+
+	return &dtos.CouponResponse{
+		ID:             coupon.ID,
+		Code:           coupon.Code,
+		Description:    string(description),
+		Type:           string(coupon.Type),
+		Value:          coupon.Value,
+		MinOrderAmount: minOrderAmount,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+		MaxUses:        maxUses,
+		MaxUsesPerUser: maxUsesPerUser,
+		Active:         coupon.Active,
+		ProductIDs:     coupon.ProductIDs,
+		CategoryIDs:    coupon.CategoryIDs,
+		CreatedAt:      coupon.CreatedAt,
+		UpdatedAt:      coupon.UpdatedAt,
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"context"
+	"fmt"
+)
+
+// GetUserFavorites récupère les produits favoris d'un utilisateur (les plus récents en premier)
+func GetUserFavorites(client *db.PrismaClient, userID string) ([]dtos.FavoriteResponse, error) {
+	ctx := context.Background()
+
+	favorites, err := client.Favorite.FindMany(
+		db.Favorite.UserID.Equals(userID),
+	).With(
+		db.Favorite.Product.Fetch().With(
+			productWith()...,
+		),
+	).OrderBy(
+		db.Favorite.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des favoris: %w", err)
+	}
+
+	result := make([]dtos.FavoriteResponse, len(favorites))
+	for i, favorite := range favorites {
+		product := convertProductToDTO(favorite.Product())
+		product.IsFavorite = true
+		result[i] = dtos.FavoriteResponse{
+			ProductID: favorite.ProductID,
+			Product:   *product,
+			CreatedAt: favorite.CreatedAt,
+		}
+	}
+
+	return result, nil
+}
+
+// AddFavorite ajoute un produit aux favoris d'un utilisateur
+// Le booléen retourné indique si le favori vient d'être créé (false s'il existait déjà)
+func AddFavorite(client *db.PrismaClient, userID, productID string) (*dtos.FavoriteResponse, bool, error) {
+	ctx := context.Background()
+
+	product, err := client.Product.FindUnique(
+		db.Product.ID.Equals(productID),
+	).With(
+		productWith()...,
+	).Exec(ctx)
+	if err != nil || product == nil {
+		return nil, false, fmt.Errorf("produit non trouvé")
+	}
+
+	created := true
+	favorite, err := client.Favorite.FindFirst(
+		db.Favorite.UserID.Equals(userID),
+		db.Favorite.ProductID.Equals(productID),
+	).Exec(ctx)
+	if err != nil {
+		favorite, err = client.Favorite.CreateOne(
+			db.Favorite.User.Link(db.User.ID.Equals(userID)),
+			db.Favorite.Product.Link(db.Product.ID.Equals(productID)),
+		).Exec(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("erreur lors de l'ajout aux favoris: %w", err)
+		}
+	} else {
+		created = false
+	}
+
+	response := convertProductToDTO(product)
+	response.IsFavorite = true
+
+	return &dtos.FavoriteResponse{
+		ProductID: productID,
+		Product:   *response,
+		CreatedAt: favorite.CreatedAt,
+	}, created, nil
+}
+
+// RemoveFavorite retire un produit des favoris d'un utilisateur
+func RemoveFavorite(client *db.PrismaClient, userID, productID string) error {
+	ctx := context.Background()
+
+	result, err := client.Favorite.FindMany(
+		db.Favorite.UserID.Equals(userID),
+		db.Favorite.ProductID.Equals(productID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression du favori: %w", err)
+	}
+	if result.Count == 0 {
+		return fmt.Errorf("favori non trouvé")
+	}
+
+	return nil
+}
+
+// MarkFavorites renseigne IsFavorite sur une liste de produits pour l'utilisateur connecté
+func MarkFavorites(client *db.PrismaClient, userID string, products []dtos.ProductResponse) error {
+	ctx := context.Background()
+
+	if userID == "" || len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+
+	favorites, err := client.Favorite.FindMany(
+		db.Favorite.UserID.Equals(userID),
+		db.Favorite.ProductID.In(ids),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des favoris: %w", err)
+	}
+
+	favorite := make(map[string]bool, len(favorites))
+	for _, f := range favorites {
+		favorite[f.ProductID] = true
+	}
+	for i := range products {
+		products[i].IsFavorite = favorite[products[i].ID]
+	}
+
+	return nil
+}
+
+// IsFavorite indique si un produit est dans les favoris d'un utilisateur
+func IsFavorite(client *db.PrismaClient, userID, productID string) (bool, error) {
+	ctx := context.Background()
+
+	favorites, err := client.Favorite.FindMany(
+		db.Favorite.UserID.Equals(userID),
+		db.Favorite.ProductID.Equals(productID),
+	).Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de la récupération des favoris: %w", err)
+	}
+
+	return len(favorites) > 0, nil
+}
+
+// GetMostWishedProducts retourne les produits les plus ajoutés aux favoris (rapport admin)
+func GetMostWishedProducts(client *db.PrismaClient, limit int) ([]dtos.MostWishedProductResponse, error) {
+	ctx := context.Background()
+
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// Agrégation faite par la base pour ne pas charger tous les favoris
+	var rows []struct {
+		ProductID db.RawString `json:"productID"`
+		Count     db.RawInt    `json:"count"`
+	}
+	err := client.Prisma.QueryRaw(
+		`SELECT "productID", COUNT(*)::int AS "count"
+		FROM "Favorite"
+		GROUP BY "productID"
+		ORDER BY "count" DESC, "productID"
+		LIMIT $1`,
+		limit,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du calcul des produits les plus souhaités: %w", err)
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = string(row.ProductID)
+	}
+	products, err := client.Product.FindMany(
+		db.Product.ID.In(ids),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des produits: %w", err)
+	}
+	byID := make(map[string]*db.ProductModel, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	result := make([]dtos.MostWishedProductResponse, 0, len(rows))
+	for _, row := range rows {
+		product, ok := byID[string(row.ProductID)]
+		if !ok {
+			continue
+		}
+		result = append(result, dtos.MostWishedProductResponse{
+			ProductID:     product.ID,
+			Name:          product.Name,
+			Price:         product.Price,
+			Stock:         product.Stock,
+			FavoriteCount: int(row.Count),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"api/internal/db"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// IdempotentResponse est la réponse enregistrée pour une clé d'idempotence
+type IdempotentResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// IdempotencyKeyTTL retourne la durée de conservation des clés d'idempotence (IDEMPOTENCY_KEY_TTL_HOURS, défaut: 24h)
+func IdempotencyKeyTTL() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("IDEMPOTENCY_KEY_TTL_HOURS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// ReserveIdempotencyKey réserve une clé avant le traitement d'une requête
+// Retourne nil si la requête doit être traitée, ou la réponse enregistrée si elle a déjà été traitée.
+// Une clé réutilisée avec une autre requête, ou dont la requête est encore en cours, est refusée.
+func ReserveIdempotencyKey(client *db.PrismaClient, userID, key, method, path, requestHash string) (*IdempotentResponse, error) {
+	ctx := context.Background()
+
+	_, err := client.IdempotencyKey.CreateOne(
+		db.IdempotencyKey.Key.Set(key),
+		db.IdempotencyKey.Method.Set(method),
+		db.IdempotencyKey.Path.Set(path),
+		db.IdempotencyKey.RequestHash.Set(requestHash),
+		db.IdempotencyKey.ExpiresAt.Set(time.Now().Add(IdempotencyKeyTTL())),
+		db.IdempotencyKey.User.Link(db.User.ID.Equals(userID)),
+	).Exec(ctx)
+	if err == nil {
+		return nil, nil
+	}
+	if _, ok := db.IsErrUniqueConstraint(err); !ok {
+		return nil, fmt.Errorf("erreur lors de l'enregistrement de la clé d'idempotence: %w", err)
+	}
+
+	existing, err := client.IdempotencyKey.FindUnique(
+		db.IdempotencyKey.UserIDKey(
+			db.IdempotencyKey.UserID.Equals(userID),
+			db.IdempotencyKey.Key.Equals(key),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de la clé d'idempotence: %w", err)
+	}
+
+	// Clé expirée : elle est libérée puis réservée pour cette requête
+	if existing.ExpiresAt.Before(time.Now()) {
+		_, err := client.IdempotencyKey.FindMany(
+			db.IdempotencyKey.ID.Equals(existing.ID),
+		).Delete().Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("erreur lors de la suppression de la clé d'idempotence: %w", err)
+		}
+		return ReserveIdempotencyKey(client, userID, key, method, path, requestHash)
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, fmt.Errorf("clé d'idempotence déjà utilisée pour une requête différente")
+	}
+	if existing.StatusCode == 0 {
+		return nil, fmt.Errorf("requête avec cette clé d'idempotence en cours de traitement")
+	}
+
+	return &IdempotentResponse{
+		StatusCode: existing.StatusCode,
+		Body:       []byte(existing.ResponseBody),
+	}, nil
+}
+
+// CompleteIdempotencyKey enregistre la réponse d'une requête traitée
+func CompleteIdempotencyKey(client *db.PrismaClient, userID, key string, response IdempotentResponse) error {
+	ctx := context.Background()
+
+	_, err := client.IdempotencyKey.FindUnique(
+		db.IdempotencyKey.UserIDKey(
+			db.IdempotencyKey.UserID.Equals(userID),
+			db.IdempotencyKey.Key.Equals(key),
+		),
+	).Update(
+		db.IdempotencyKey.StatusCode.Set(response.StatusCode),
+		db.IdempotencyKey.ResponseBody.Set(string(response.Body)),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'enregistrement de la réponse idempotente: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey libère une clé dont la requête a échoué côté serveur, pour permettre une nouvelle tentative
+func ReleaseIdempotencyKey(client *db.PrismaClient, userID, key string) error {
+	ctx := context.Background()
+
+	_, err := client.IdempotencyKey.FindMany(
+		db.IdempotencyKey.UserID.Equals(userID),
+		db.IdempotencyKey.Key.Equals(key),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la clé d'idempotence: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpiredIdempotencyKeys supprime les clés d'idempotence expirées
+func PurgeExpiredIdempotencyKeys(client *db.PrismaClient) (int, error) {
+	ctx := context.Background()
+
+	result, err := client.IdempotencyKey.FindMany(
+		db.IdempotencyKey.ExpiresAt.Lt(time.Now()),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la purge des clés d'idempotence: %w", err)
+	}
+
+	return result.Count, nil
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/invoice"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// allocateInvoiceNumberTx attribue le prochain numéro de facture à une commande qui n'en a pas encore.
+// Le compteur n'est incrémenté que si la commande est effectivement numérotée, dans la même requête :
+// un échec annule les deux et la numérotation reste continue. Sans effet si la commande est déjà numérotée.
+func allocateInvoiceNumberTx(client *db.PrismaClient, orderID string) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(
+		`WITH target AS (
+			SELECT "id" FROM "Order" WHERE "id" = $1 AND "invoiceNumber" IS NULL FOR UPDATE
+		), counter AS (
+			UPDATE "InvoiceCounter" SET "lastNumber" = "lastNumber" + 1
+			WHERE "id" = 1 AND EXISTS (SELECT 1 FROM target)
+			RETURNING "lastNumber"
+		)
+		UPDATE "Order" SET "invoiceNumber" = counter."lastNumber", "invoicedAt" = CURRENT_TIMESTAMP
+		FROM counter
+		WHERE "Order"."id" = $1`,
+		orderID,
+	).Tx()
+}
+
+// GetOrderInvoice génère la facture PDF d'une commande (propriétaire ou admin)
+// Retourne le numéro de facture et le contenu du PDF
+func GetOrderInvoice(client *db.PrismaClient, orderID, userID string, isAdmin bool) (string, []byte, error) {
+	ctx := context.Background()
+
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).Exec(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("commande non trouvée")
+	}
+
+	// Vérifier que l'utilisateur peut voir cette commande
+	if !isAdmin && order.UserID != userID {
+		return "", nil, fmt.Errorf("accès non autorisé à cette commande")
+	}
+
+	// La facture n'existe qu'une fois la commande payée ou expédiée ; le numéro est normalement
+	// attribué à ce moment-là, il l'est ici si besoin (commande expédiée avant la facturation)
+	if _, ok := order.InvoiceNumber(); !ok {
+		_, paid := order.PaidAt()
+		shipped := order.Status == db.OrderStatusShipped || order.Status == db.OrderStatusDelivered
+		if !paid && !shipped {
+			return "", nil, fmt.Errorf("facture non disponible: la commande n'est ni payée ni expédiée")
+		}
+		if err := client.Prisma.Transaction(allocateInvoiceNumberTx(client, order.ID)).Exec(ctx); err != nil {
+			return "", nil, fmt.Errorf("erreur lors de l'attribution du numéro de facture: %w", err)
+		}
+	}
+
+	order, err = client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).With(
+		db.Order.OrderItems.Fetch().With(
+			db.OrderItem.Product.Fetch(),
+		),
+		db.Order.User.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("erreur lors de la récupération de la commande: %w", err)
+	}
+
+	inv := buildInvoice(order)
+	return inv.Number, invoice.Render(inv), nil
+}
+
+// shippingAddressLines retourne les lignes de l'adresse de livraison copiée dans la commande (sans le destinataire)
+func shippingAddressLines(order *db.OrderModel) []string {
+	line1, _ := order.ShippingLine1()
+	line2, _ := order.ShippingLine2()
+	postalCode, _ := order.ShippingPostalCode()
+	city, _ := order.ShippingCity()
+	country, _ := order.ShippingCountry()
+
+	var lines []string
+	for _, line := range []string{string(line1), string(line2), strings.TrimSpace(string(postalCode) + " " + string(city)), string(country)} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// buildInvoice prépare les données de la facture d'une commande numérotée
+func buildInvoice(order *db.OrderModel) invoice.Invoice {
+	number, _ := order.InvoiceNumber()
+	invoicedAt, ok := order.InvoicedAt()
+	if !ok {
+		invoicedAt = order.UpdatedAt
+	}
+
+	inv := invoice.Invoice{
+		Number:         invoice.FormatNumber(number),
+		Date:           invoicedAt,
+		OrderID:        order.ID,
+		OrderDate:      order.OrderDate,
+		Seller:         invoice.SellerFromEnv(),
+		CustomerEmail:  order.User().Email,
+		SubtotalAmount: order.SubtotalAmount,
+		DiscountAmount: order.DiscountAmount,
+		ShippingAmount: order.ShippingAmount,
+		NetAmount:      order.NetAmount,
+		TaxAmount:      order.TaxAmount,
+		TotalAmount:    order.TotalAmount,
+	}
+	if paidAt, ok := order.PaidAt(); ok {
+		inv.PaidAt = &paidAt
+	}
+	if code, ok := order.CouponCode(); ok {
+		inv.CouponCode = string(code)
+	}
+
+	// Client : adresse copiée à la commande, à défaut l'e-mail du compte
+	if fullName, ok := order.ShippingFullName(); ok {
+		inv.CustomerName = string(fullName)
+		inv.CustomerLines = shippingAddressLines(order)
+	} else {
+		inv.CustomerName = order.User().Email
+	}
+
+	for _, item := range order.OrderItems() {
+		description := item.Product().Name
+		if label, ok := item.VariantLabel(); ok && label != "" {
+			description += " (" + string(label) + ")"
+		}
+		inv.Lines = append(inv.Lines, invoice.Line{
+			Description: description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			Discount:    item.DiscountAmount,
+			TaxRate:     item.TaxRate,
+			NetAmount:   item.NetAmount,
+			GrossAmount: item.GrossAmount,
+		})
+	}
+	if order.ShippingAmount > 0 {
+		description := "Livraison"
+		if method, ok := order.ShippingMethodName(); ok {
+			description += " - " + string(method)
+		}
+		net, _ := splitGrossAmount(order.ShippingAmount, order.ShippingTaxRate)
+		inv.Lines = append(inv.Lines, invoice.Line{
+			Description: description,
+			Quantity:    1,
+			UnitPrice:   order.ShippingAmount,
+			TaxRate:     order.ShippingTaxRate,
+			NetAmount:   net,
+			GrossAmount: order.ShippingAmount,
+		})
+	}
+
+	for _, line := range taxBreakdownToDTO(order) {
+		inv.TaxLines = append(inv.TaxLines, invoice.TaxLine{
+			Rate:        line.Rate,
+			NetAmount:   line.NetAmount,
+			TaxAmount:   line.TaxAmount,
+			GrossAmount: line.GrossAmount,
+		})
+	}
+
+	return inv
+}
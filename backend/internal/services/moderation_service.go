@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"api/internal/db"
+)
+
+// moderationPage borne la pagination des files de modération (défaut: 20, max: 100)
+func moderationPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// requireModerationReason nettoie le motif d'une décision de modération, obligatoire pour un refus ou une suppression
+// action complète le message d'erreur (« du refus », « de la suppression »).
+func requireModerationReason(reason, action string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", fmt.Errorf("le motif %s est requis", action)
+	}
+	return reason, nil
+}
+
+// countModerationQueue compte les contenus d'une file de modération (table Review, Question ou Answer),
+// filtrés par statut (déjà validé) et par produit s'ils sont fournis
+func countModerationQueue(client *db.PrismaClient, table, status, productID string) (int, error) {
+	ctx := context.Background()
+
+	query := `SELECT COUNT(*)::int AS "count" FROM "` + table + `" WHERE ($1 = '' OR "status"::text = $1)`
+	args := []any{strings.ToUpper(status)}
+	if productID != "" {
+		query += ` AND "productID" = $2`
+		args = append(args, productID)
+	}
+
+	var rows []struct {
+		Count db.RawInt `json:"count"`
+	}
+	if err := client.Prisma.QueryRaw(query, args...).Exec(ctx, &rows); err != nil {
+		return 0, fmt.Errorf("erreur lors du comptage de la file de modération: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	return int(rows[0].Count), nil
+}
+
+// Types de contenus du journal de modération (ModerationLog.entityType)
+const (
+	moderationEntityReview   = "review"
+	moderationEntityQuestion = "question"
+	moderationEntityAnswer   = "answer"
+)
+
+// moderationLog décrit la suppression d'un contenu par un administrateur
+type moderationLog struct {
+	EntityType string
+	EntityID   string
+	ProductID  string
+	AuthorID   string
+	AdminID    string
+	Reason     string
+	Content    string // Texte supprimé, vide s'il n'y en avait pas
+}
+
+// moderationLogTx prépare l'écriture d'une suppression dans le journal de modération,
+// à exécuter dans la transaction de la suppression
+func moderationLogTx(client *db.PrismaClient, entry moderationLog) db.PrismaTransaction {
+	var params []db.ModerationLogSetParam
+	if entry.Content != "" {
+		params = append(params, db.ModerationLog.Content.Set(entry.Content))
+	}
+	return client.ModerationLog.CreateOne(
+		db.ModerationLog.EntityType.Set(entry.EntityType),
+		db.ModerationLog.EntityID.Set(entry.EntityID),
+		db.ModerationLog.ProductID.Set(entry.ProductID),
+		db.ModerationLog.AuthorID.Set(entry.AuthorID),
+		db.ModerationLog.AdminID.Set(entry.AdminID),
+		db.ModerationLog.Reason.Set(entry.Reason),
+		params...,
+	).Tx()
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/events"
+	"api/internal/invoice"
+	"api/internal/mail"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SubscribeOrderNotifications abonne aux événements de commande l'envoi des e-mails au client :
+// confirmation à la création, puis expédition, livraison, annulation et remboursements
+func SubscribeOrderNotifications(bus *events.Bus, client *db.PrismaClient, mailer *mail.Queue) {
+	bus.Subscribe(events.OrderCreated, "mail", func(ctx context.Context, event events.Event) error {
+		var payload events.OrderCreatedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return sendOrderEmail(ctx, client, mailer, payload.OrderID, mail.TemplateOrderConfirmed, 0)
+	})
+
+	bus.Subscribe(events.OrderStatusChanged, "mail", func(ctx context.Context, event events.Event) error {
+		var payload events.OrderStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		templates := map[string]string{
+			string(db.OrderStatusShipped):   mail.TemplateOrderShipped,
+			string(db.OrderStatusDelivered): mail.TemplateOrderDelivered,
+			string(db.OrderStatusCancelled): mail.TemplateOrderCancelled,
+		}
+		template, ok := templates[payload.To]
+		if !ok {
+			return nil
+		}
+		return sendOrderEmail(ctx, client, mailer, payload.OrderID, template, 0)
+	})
+
+	bus.Subscribe(events.OrderRefunded, "mail", func(ctx context.Context, event events.Event) error {
+		var payload events.OrderRefundedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return sendOrderEmail(ctx, client, mailer, payload.OrderID, mail.TemplateOrderRefunded, payload.Amount)
+	})
+}
+
+// sendOrderEmail prépare un e-mail de commande dans la langue de la commande et le confie à la file d'envoi
+func sendOrderEmail(ctx context.Context, client *db.PrismaClient, mailer *mail.Queue, orderID, template string, refundAmount float64) error {
+	if mailer == nil {
+		return nil
+	}
+
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).With(
+		db.Order.OrderItems.Fetch().With(
+			db.OrderItem.Product.Fetch(),
+		),
+		db.Order.User.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("commande %s introuvable pour l'e-mail %s: %w", orderID, template, err)
+	}
+
+	msg, err := mail.Render(template, orderEmailData(order, refundAmount))
+	if err != nil {
+		return err
+	}
+	mailer.Enqueue(msg)
+	return nil
+}
+
+// orderEmailData prépare les données d'une commande (chargée avec ses lignes et son client) pour les modèles d'e-mails
+func orderEmailData(order *db.OrderModel, refundAmount float64) mail.OrderEmail {
+	data := mail.OrderEmail{
+		To:             order.User().Email,
+		Locale:         order.Locale,
+		OrderID:        order.ID,
+		OrderNumber:    strings.ToUpper(order.ID[:8]),
+		DiscountAmount: order.DiscountAmount,
+		ShippingAmount: order.ShippingAmount,
+		TotalAmount:    order.TotalAmount,
+		RefundAmount:   refundAmount,
+	}
+	if method, ok := order.ShippingMethodName(); ok {
+		data.ShippingMethod = string(method)
+	}
+	if number, ok := order.InvoiceNumber(); ok {
+		data.InvoiceNumber = invoice.FormatNumber(number)
+	}
+
+	if fullName, ok := order.ShippingFullName(); ok {
+		data.AddressLines = append([]string{string(fullName)}, shippingAddressLines(order)...)
+	}
+
+	for _, item := range order.OrderItems() {
+		name := item.Product().Name
+		if label, ok := item.VariantLabel(); ok && label != "" {
+			name += " (" + string(label) + ")"
+		}
+		data.Items = append(data.Items, mail.OrderEmailItem{
+			Name:     name,
+			Quantity: item.Quantity,
+			Amount:   item.GrossAmount,
+		})
+	}
+
+	return data
+}
+
+// SubscribeReviewNotifications abonne aux événements d'avis l'envoi d'un e-mail à l'auteur d'un avis
+// quand la marque y répond publiquement
+func SubscribeReviewNotifications(bus *events.Bus, client *db.PrismaClient, mailer *mail.Queue) {
+	bus.Subscribe(events.ReviewReplied, "mail", func(ctx context.Context, event events.Event) error {
+		var payload events.ReviewRepliedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return sendReviewReplyEmail(ctx, client, mailer, payload.ReviewID)
+	})
+}
+
+// sendReviewReplyEmail prépare l'e-mail de réponse à un avis et le confie à la file d'envoi.
+// La langue est celle de la dernière commande du client ; rien n'est envoyé si la réponse a été retirée entre-temps.
+func sendReviewReplyEmail(ctx context.Context, client *db.PrismaClient, mailer *mail.Queue, reviewID string) error {
+	if mailer == nil {
+		return nil
+	}
+
+	review, err := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).With(
+		db.Review.User.Fetch(),
+		db.Review.Product.Fetch(),
+		db.Review.Reply.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if err == db.ErrNotFound {
+			return nil // Avis supprimé entre-temps
+		}
+		return fmt.Errorf("avis %s introuvable pour l'e-mail %s: %w", reviewID, mail.TemplateReviewReplied, err)
+	}
+	reply, ok := review.Reply()
+	if !ok || reply == nil {
+		return nil
+	}
+
+	locale := mail.DefaultLocale
+	orders, err := client.Order.FindMany(
+		db.Order.UserID.Equals(review.UserID),
+	).OrderBy(
+		db.Order.CreatedAt.Order(db.SortOrderDesc),
+	).Take(1).Exec(ctx)
+	if err == nil && len(orders) > 0 {
+		locale = orders[0].Locale
+	}
+
+	data := mail.ReviewReplyEmail{
+		To:          review.User().Email,
+		Locale:      locale,
+		ProductName: review.Product().Name,
+		Rating:      review.Rating,
+		Reply:       reply.Body,
+	}
+	if comment, ok := review.Comment(); ok {
+		data.Comment = string(comment)
+	}
+
+	msg, err := mail.RenderReviewReply(data)
+	if err != nil {
+		return err
+	}
+	mailer.Enqueue(msg)
+	return nil
+}
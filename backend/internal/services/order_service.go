@@ -3,113 +3,258 @@ package services
 import (
 	"api/internal/db"
 	"api/internal/dtos"
+	"api/internal/events"
+	"api/internal/invoice"
+	"api/internal/mail"
 	"context"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // CreateOrder crée une nouvelle commande avec ses items
-func CreateOrder(client *db.PrismaClient, userID string, req dtos.CreateOrderRequest) (*dtos.OrderResponse, error) {
+// Les e-mails de suivi sont envoyés dans la langue du client (locale)
+func CreateOrder(client *db.PrismaClient, userID, locale string, req dtos.CreateOrderRequest) (*dtos.OrderResponse, error) {
 	ctx := context.Background()
 
 	if len(req.Items) == 0 {
 		return nil, fmt.Errorf("une commande doit contenir au moins un produit")
 	}
 
-	// Calculer le montant total et vérifier le stock
+	// Adresse (du carnet de l'utilisateur) et mode de livraison
+	if req.AddressID == "" {
+		return nil, fmt.Errorf("adresse de livraison requise")
+	}
+	if req.ShippingMethodID == "" {
+		return nil, fmt.Errorf("mode de livraison requis")
+	}
+	address, err := findUserAddress(client, userID, req.AddressID)
+	if err != nil {
+		return nil, fmt.Errorf("adresse de livraison non trouvée")
+	}
+	shippingMethod, err := client.ShippingMethod.FindUnique(
+		db.ShippingMethod.ID.Equals(req.ShippingMethodID),
+	).Exec(ctx)
+	if err != nil || shippingMethod == nil || !shippingMethod.Active {
+		return nil, fmt.Errorf("mode de livraison non trouvé")
+	}
+
+	// Calculer le montant total et vérifier le stock (au niveau de la variante)
 	var totalAmount float64
 	type itemData struct {
-		ProductID string
-		Quantity  int
-		Price     float64
-		Stock     int
+		ProductID    string
+		CategoryID   string
+		TaxRate      float64
+		VariantID    string
+		VariantLabel string
+		Quantity     int
+		Price        float64
 	}
 	var itemsData []itemData
+	requested := make(map[string]int) // Quantité totale demandée par variante
+	var weightGrams int               // Poids total expédié
 
 	for _, item := range req.Items {
-		// Récupérer le produit
+		// Récupérer le produit avec ses variantes et sa catégorie (taux de TVA)
 		product, err := client.Product.FindUnique(
 			db.Product.ID.Equals(item.ProductID),
+		).With(
+			db.Product.Variants.Fetch(),
+			db.Product.Category.Fetch(),
 		).Exec(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("produit avec l'ID %s non trouvé", item.ProductID)
 		}
 
-		// Vérifier le stock
-		if product.Stock < item.Quantity {
-			return nil, fmt.Errorf("stock insuffisant pour le produit %s (stock disponible: %d)", product.Name, product.Stock)
+		// Sans variantID, la variante par défaut est commandée
+		var variant *db.ProductVariantModel
+		if item.VariantID == "" {
+			variant = defaultVariant(product)
+		} else {
+			for i, v := range product.Variants() {
+				if v.ID == item.VariantID {
+					variant = &product.Variants()[i]
+				}
+			}
+		}
+		if variant == nil {
+			return nil, fmt.Errorf("variante avec l'ID %s non trouvée pour le produit %s", item.VariantID, product.Name)
+		}
+
+		// Vérification anticipée du stock (une même variante peut apparaître sur plusieurs lignes),
+		// revérifiée à l'écriture par takeStockTx
+		requested[variant.ID] += item.Quantity
+		if variant.Stock < requested[variant.ID] {
+			return nil, fmt.Errorf("stock insuffisant pour le produit %s (%s) (stock disponible: %d)", product.Name, variant.Label, variant.Stock)
 		}
 
 		// Calculer le prix total pour cet item
-		itemTotal := product.Price * float64(item.Quantity)
+		itemTotal := variant.Price * float64(item.Quantity)
 		totalAmount += itemTotal
+		weightGrams += variant.WeightGrams * item.Quantity
 
+		categoryID, _ := product.CategoryID()
 		itemsData = append(itemsData, itemData{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-			Stock:     product.Stock,
+			ProductID:    item.ProductID,
+			CategoryID:   string(categoryID),
+			TaxRate:      productTaxRate(product),
+			VariantID:    variant.ID,
+			VariantLabel: variant.Label,
+			Quantity:     item.Quantity,
+			Price:        variant.Price,
 		})
 	}
 
-	// Créer la commande - TotalAmount doit être en premier
-	order, err := client.Order.CreateOne(
-		db.Order.TotalAmount.Set(totalAmount),
-		db.Order.User.Link(db.User.ID.Equals(userID)),
-	).Exec(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de la commande: %w", err)
+	// Appliquer le code promo éventuel
+	subtotalAmount := totalAmount
+	lineDiscounts := make([]float64, len(itemsData))
+	freeShipping := false
+	var couponID string
+	orderParams := []db.OrderSetParam{
+		db.Order.SubtotalAmount.Set(subtotalAmount),
+	}
+	if req.CouponCode != "" {
+		lines := make([]couponLine, len(itemsData))
+		for i, data := range itemsData {
+			lines[i] = couponLine{
+				ProductID:  data.ProductID,
+				CategoryID: data.CategoryID,
+				Total:      data.Price * float64(data.Quantity),
+			}
+		}
+		applied, err := applyCoupon(client, userID, req.CouponCode, lines)
+		if err != nil {
+			return nil, err
+		}
+		lineDiscounts = applied.LineDiscounts
+		freeShipping = applied.FreeShipping
+		couponID = applied.Coupon.ID
+		totalAmount = roundAmount(subtotalAmount - applied.Discount)
+		orderParams = append(orderParams,
+			db.Order.DiscountAmount.Set(applied.Discount),
+			db.Order.FreeShipping.Set(applied.FreeShipping),
+			db.Order.CouponCode.Set(applied.Coupon.Code),
+			db.Order.Coupon.Link(db.Coupon.ID.Equals(applied.Coupon.ID)),
+		)
 	}
 
-	// Mettre à jour le statut
-	order, err = client.Order.FindUnique(
-		db.Order.ID.Equals(order.ID),
-	).Update(
-		db.Order.Status.Set(db.OrderStatus("PENDING")),
-	).Exec(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de la commande: %w", err)
+	// Décomposer chaque ligne (TTC, remise déduite) en HT et TVA
+	type lineTax struct {
+		Net, Tax, Gross float64
+	}
+	lineTaxes := make([]lineTax, len(itemsData))
+	var netAmount, taxAmount float64
+	for i, data := range itemsData {
+		gross := roundAmount(data.Price*float64(data.Quantity) - lineDiscounts[i])
+		net, tax := splitGrossAmount(gross, data.TaxRate)
+		lineTaxes[i] = lineTax{Net: net, Tax: tax, Gross: gross}
+		netAmount += net
+		taxAmount += tax
 	}
 
+	// Frais de port (TTC, taux de TVA par défaut), offerts par certains codes promo
+	var shippingAmount float64
+	if !freeShipping {
+		shippingAmount = shippingCost(shippingMethod, totalAmount, weightGrams)
+	}
+	shippingTaxRate := defaultTaxRate()
+	shippingNet, shippingTax := splitGrossAmount(shippingAmount, shippingTaxRate)
+	netAmount += shippingNet
+	taxAmount += shippingTax
+	totalAmount = roundAmount(totalAmount + shippingAmount)
+
+	var line2, phone *string
+	if v, ok := address.Line2(); ok {
+		value := string(v)
+		line2 = &value
+	}
+	if v, ok := address.Phone(); ok {
+		value := string(v)
+		phone = &value
+	}
+	orderParams = append(orderParams,
+		db.Order.NetAmount.Set(roundAmount(netAmount)),
+		db.Order.TaxAmount.Set(roundAmount(taxAmount)),
+		db.Order.ShippingFullName.Set(address.FullName),
+		db.Order.ShippingLine1.Set(address.Line1),
+		db.Order.ShippingLine2.SetOptional(line2),
+		db.Order.ShippingPostalCode.Set(address.PostalCode),
+		db.Order.ShippingCity.Set(address.City),
+		db.Order.ShippingCountry.Set(address.Country),
+		db.Order.ShippingPhone.SetOptional(phone),
+		db.Order.ShippingMethodName.Set(shippingMethod.Name),
+		db.Order.ShippingAmount.Set(shippingAmount),
+		db.Order.ShippingTaxRate.Set(shippingTaxRate),
+		db.Order.ShippingMethod.Link(db.ShippingMethod.ID.Equals(shippingMethod.ID)),
+		db.Order.Locale.Set(mail.NormalizeLocale(locale)),
+	)
+
+	// La commande, ses items, le stock et les événements sont écrits dans une seule transaction.
+	// L'identifiant est généré ici pour lier les items et les événements à la commande.
+	orderID := newID()
+	orderParams = append(orderParams,
+		db.Order.ID.Set(orderID),
+		db.Order.Status.Set(db.OrderStatusPending),
+	)
+
+	// Les limites du code promo sont revérifiées sous verrou avant la création de la commande
+	var txs []db.PrismaTransaction
+	if couponID != "" {
+		txs = append(txs, claimCouponTx(client, couponID, userID))
+	}
+
+	// Créer la commande - TotalAmount doit être en premier
+	txs = append(txs, client.Order.CreateOne(
+		db.Order.TotalAmount.Set(totalAmount),
+		db.Order.User.Link(db.User.ID.Equals(userID)),
+		orderParams...,
+	).Tx())
+
 	// Créer les items de commande et mettre à jour le stock
-	for _, itemData := range itemsData {
+	for i, itemData := range itemsData {
 		// Créer l'item de commande - ordre: Quantity, Price, Order, Product
-		_, err := client.OrderItem.CreateOne(
+		txs = append(txs, client.OrderItem.CreateOne(
 			db.OrderItem.Quantity.Set(itemData.Quantity),
 			db.OrderItem.Price.Set(itemData.Price),
-			db.OrderItem.Order.Link(db.Order.ID.Equals(order.ID)),
+			db.OrderItem.Order.Link(db.Order.ID.Equals(orderID)),
 			db.OrderItem.Product.Link(db.Product.ID.Equals(itemData.ProductID)),
-		).Exec(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("erreur lors de la création de l'item de commande: %w", err)
-		}
+			db.OrderItem.Variant.Link(db.ProductVariant.ID.Equals(itemData.VariantID)),
+			db.OrderItem.VariantLabel.Set(itemData.VariantLabel),
+			db.OrderItem.DiscountAmount.Set(lineDiscounts[i]),
+			db.OrderItem.TaxRate.Set(itemData.TaxRate),
+			db.OrderItem.NetAmount.Set(lineTaxes[i].Net),
+			db.OrderItem.TaxAmount.Set(lineTaxes[i].Tax),
+			db.OrderItem.GrossAmount.Set(lineTaxes[i].Gross),
+		).Tx())
+
+		// Le stock du produit (somme des variantes) est décrémenté avec celui de la variante,
+		// sous réserve que le stock de la variante suffise au moment de l'écriture
+		txs = append(txs,
+			takeStockTx(client, itemData.VariantID, itemData.ProductID, itemData.Quantity),
+			stockChangedTx(client, itemData.ProductID, itemData.VariantID, -itemData.Quantity, events.StockReasonOrder),
+		)
+	}
 
-		// Mettre à jour le stock - récupérer le stock actuel pour éviter les problèmes de concurrence
-		product, err := client.Product.FindUnique(
-			db.Product.ID.Equals(itemData.ProductID),
-		).Exec(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("erreur lors de la récupération du produit pour mise à jour du stock: %w", err)
+	txs = append(txs, outboxEventTx(client, events.OrderCreated, orderID, events.OrderCreatedPayload{
+		OrderID:     orderID,
+		UserID:      userID,
+		TotalAmount: totalAmount,
+	}))
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		if limitErr := couponLimitError(err); limitErr != nil {
+			return nil, limitErr
 		}
-
-		// Vérifier à nouveau le stock avant de mettre à jour (protection contre la concurrence)
-		if product.Stock < itemData.Quantity {
-			return nil, fmt.Errorf("stock insuffisant pour le produit %s (stock disponible: %d, demandé: %d)", product.Name, product.Stock, itemData.Quantity)
-		}
-
-		newStock := product.Stock - itemData.Quantity
-		_, err = client.Product.FindUnique(
-			db.Product.ID.Equals(itemData.ProductID),
-		).Update(
-			db.Product.Stock.Set(newStock),
-		).Exec(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("erreur lors de la mise à jour du stock: %w", err)
+		for _, data := range itemsData {
+			if strings.Contains(err.Error(), "stock insuffisant pour la variante "+data.VariantID) {
+				return nil, fmt.Errorf("stock insuffisant pour la variante %s", data.VariantLabel)
+			}
 		}
+		return nil, fmt.Errorf("erreur lors de la création de la commande: %w", err)
 	}
 
 	// Récupérer la commande complète avec les items
-	order, err = client.Order.FindUnique(
-		db.Order.ID.Equals(order.ID),
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
 	).With(
 		db.Order.OrderItems.Fetch().With(
 			db.OrderItem.Product.Fetch(),
@@ -122,6 +267,15 @@ func CreateOrder(client *db.PrismaClient, userID string, req dtos.CreateOrderReq
 	return convertOrderToDTO(order), nil
 }
 
+// takeStockTx décrémente le stock d'une variante et de son produit dans la transaction qui crée la commande
+// La fonction SQL take_stock échoue, et annule la transaction, si le stock de la variante ne suffit plus.
+func takeStockTx(client *db.PrismaClient, variantID, productID string, quantity int) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(
+		`SELECT "take_stock"($1, $2, $3)`,
+		variantID, productID, quantity,
+	).Tx()
+}
+
 // GetAllOrders récupère toutes les commandes (admin only)
 func GetAllOrders(client *db.PrismaClient) ([]dtos.OrderResponse, error) {
 	ctx := context.Background()
@@ -190,17 +344,46 @@ func GetOrderByID(client *db.PrismaClient, orderID string, userID string, isAdmi
 }
 
 // UpdateOrderStatus met à jour le statut d'une commande (admin only)
+// Un changement effectif de statut publie l'événement OrderStatusChanged
 func UpdateOrderStatus(client *db.PrismaClient, orderID string, status db.OrderStatus) (*dtos.OrderResponse, error) {
 	ctx := context.Background()
 
-	_, err := client.Order.FindUnique(
+	current, err := client.Order.FindUnique(
 		db.Order.ID.Equals(orderID),
-	).Update(
-		db.Order.Status.Set(status),
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la mise à jour du statut de la commande: %w", err)
 	}
+	changed := current.Status != status
+
+	params := []db.OrderSetParam{db.Order.Status.Set(status)}
+	if status == db.OrderStatusDelivered && changed {
+		// Le délai de retour court à partir de la livraison
+		params = append(params, db.Order.DeliveredAt.Set(time.Now()))
+	}
+
+	txs := []db.PrismaTransaction{
+		client.Order.FindUnique(
+			db.Order.ID.Equals(orderID),
+		).Update(params...).Tx(),
+	}
+	if status == db.OrderStatusShipped || status == db.OrderStatusDelivered {
+		// La facture est numérotée à l'expédition si elle ne l'a pas été au paiement
+		txs = append(txs, allocateInvoiceNumberTx(client, orderID))
+	}
+	if changed {
+		txs = append(txs, outboxEventTx(client, events.OrderStatusChanged, orderID, events.OrderStatusChangedPayload{
+			OrderID: orderID,
+			UserID:  current.UserID,
+			From:    string(current.Status),
+			To:      string(status),
+		}))
+	}
+
+	err = client.Prisma.Transaction(txs...).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour du statut de la commande: %w", err)
+	}
 
 	// Récupérer la commande mise à jour avec les items
 	order, err := client.Order.FindUnique(
@@ -222,10 +405,24 @@ func convertOrderToDTO(order *db.OrderModel) *dtos.OrderResponse {
 	orderItems := make([]dtos.OrderItemResponse, len(order.OrderItems()))
 	for i, item := range order.OrderItems() {
 		product := item.Product()
+		var variantID *string
+		if id, ok := item.VariantID(); ok {
+			v := string(id)
+			variantID = &v
+		}
+		variantLabel, _ := item.VariantLabel()
+		ratingAverage, ratingHistogram := productRatingToDTO(product)
 		orderItems[i] = dtos.OrderItemResponse{
-			ID:       item.ID,
-			Quantity: item.Quantity,
-			Price:    item.Price,
+			ID:             item.ID,
+			Quantity:       item.Quantity,
+			Price:          item.Price,
+			VariantID:      variantID,
+			VariantLabel:   string(variantLabel),
+			DiscountAmount: item.DiscountAmount,
+			TaxRate:        item.TaxRate,
+			NetAmount:      item.NetAmount,
+			TaxAmount:      item.TaxAmount,
+			GrossAmount:    item.GrossAmount,
 			Product: dtos.ProductResponse{
 				ID:   product.ID,
 				Name: product.Name,
@@ -245,21 +442,73 @@ func convertOrderToDTO(order *db.OrderModel) *dtos.OrderResponse {
 					}
 					return ""
 				}(),
-				CreatedAt: product.CreatedAt,
-				UpdatedAt: product.UpdatedAt,
+				CreatedAt:       product.CreatedAt,
+				UpdatedAt:       product.UpdatedAt,
+				RatingAverage:   ratingAverage,
+				RatingCount:     product.RatingCount,
+				RatingHistogram: ratingHistogram,
 			},
 		}
 	}
 
+	couponCode, _ := order.CouponCode()
+	var paidAt *time.Time
+	if v, ok := order.PaidAt(); ok {
+		paidAt = &v
+	}
+	var invoiceNumber string
+	if v, ok := order.InvoiceNumber(); ok {
+		invoiceNumber = invoice.FormatNumber(v)
+	}
+	var deliveredAt *time.Time
+	if v, ok := order.DeliveredAt(); ok {
+		deliveredAt = &v
+	}
+	shippingMethod, _ := order.ShippingMethodName()
+
+	// Adresse de livraison copiée à la commande (absente sur les commandes antérieures)
+	var shippingAddress *dtos.ShippingAddressResponse
+	if line1, ok := order.ShippingLine1(); ok {
+		fullName, _ := order.ShippingFullName()
+		line2, _ := order.ShippingLine2()
+		postalCode, _ := order.ShippingPostalCode()
+		city, _ := order.ShippingCity()
+		country, _ := order.ShippingCountry()
+		phone, _ := order.ShippingPhone()
+		shippingAddress = &dtos.ShippingAddressResponse{
+			FullName:   string(fullName),
+			Line1:      string(line1),
+			Line2:      string(line2),
+			PostalCode: string(postalCode),
+			City:       string(city),
+			Country:    string(country),
+			Phone:      string(phone),
+		}
+	}
+
 	return &dtos.OrderResponse{
-		ID:          order.ID,
-		OrderDate:   order.OrderDate,
-		TotalAmount: order.TotalAmount,
-		Status:      string(order.Status),
-		UserID:      order.UserID,
-		OrderItems:  orderItems,
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:              order.ID,
+		OrderDate:       order.OrderDate,
+		SubtotalAmount:  order.SubtotalAmount,
+		DiscountAmount:  order.DiscountAmount,
+		TotalAmount:     order.TotalAmount,
+		NetAmount:       order.NetAmount,
+		TaxAmount:       order.TaxAmount,
+		TaxBreakdown:    taxBreakdownToDTO(order),
+		CouponCode:      string(couponCode),
+		FreeShipping:    order.FreeShipping,
+		ShippingMethod:  string(shippingMethod),
+		ShippingAmount:  order.ShippingAmount,
+		ShippingAddress: shippingAddress,
+		PaidAt:          paidAt,
+		DeliveredAt:     deliveredAt,
+		RefundedAmount:  order.RefundedAmount,
+		InvoiceNumber:   invoiceNumber,
+		Status:          string(order.Status),
+		UserID:          order.UserID,
+		OrderItems:      orderItems,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
 	}
 }
 
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"api/internal/realtime"
+	"context"
+	"encoding/json"
+)
+
+// SubscribeOrderStream abonne le flux temps réel des commandes (SSE) aux événements de commande
+func SubscribeOrderStream(bus *events.Bus, hub *realtime.Hub) {
+	bus.Subscribe(events.OrderCreated, "order-stream", func(ctx context.Context, event events.Event) error {
+		var payload events.OrderCreatedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return publishOrderStreamEvent(hub, event, payload.UserID, dtos.OrderStreamEvent{
+			OrderID:     payload.OrderID,
+			UserID:      payload.UserID,
+			Status:      string(db.OrderStatusPending),
+			TotalAmount: payload.TotalAmount,
+			OccurredAt:  event.OccurredAt,
+		})
+	})
+
+	bus.Subscribe(events.OrderStatusChanged, "order-stream", func(ctx context.Context, event events.Event) error {
+		var payload events.OrderStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		return publishOrderStreamEvent(hub, event, payload.UserID, dtos.OrderStreamEvent{
+			OrderID:        payload.OrderID,
+			UserID:         payload.UserID,
+			Status:         payload.To,
+			PreviousStatus: payload.From,
+			OccurredAt:     event.OccurredAt,
+		})
+	})
+}
+
+// publishOrderStreamEvent diffuse un événement de commande ; l'identifiant de l'événement évite les doublons
+func publishOrderStreamEvent(hub *realtime.Hub, event events.Event, userID string, data dtos.OrderStreamEvent) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	hub.Publish(event.ID, event.Type, userID, body)
+	return nil
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/events"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// outboxBatchSize est le nombre d'événements réservés à chaque passage du dispatcher
+const outboxBatchSize = 100
+
+// outboxLease est la durée de réservation d'un événement en cours de distribution :
+// si l'instance s'arrête avant la fin, l'événement est redistribué à l'expiration du bail
+const outboxLease = 5 * time.Minute
+
+// OutboxPollInterval retourne l'intervalle entre deux passages du dispatcher (OUTBOX_POLL_INTERVAL_MS, défaut: 1000)
+func OutboxPollInterval() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("OUTBOX_POLL_INTERVAL_MS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Millisecond
+	}
+	return time.Second
+}
+
+// outboxMaxAttempts retourne le nombre de tentatives avant abandon d'un événement (OUTBOX_MAX_ATTEMPTS, défaut: 10)
+func outboxMaxAttempts() int {
+	if v, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS")); err == nil && v > 0 {
+		return v
+	}
+	return 10
+}
+
+// outboxRetentionDays retourne la durée de conservation des événements distribués (OUTBOX_RETENTION_DAYS, défaut: 7)
+func outboxRetentionDays() int {
+	if v, err := strconv.Atoi(os.Getenv("OUTBOX_RETENTION_DAYS")); err == nil && v > 0 {
+		return v
+	}
+	return 7
+}
+
+// outboxEventTx prépare l'écriture d'un événement dans l'outbox, à exécuter dans la transaction du changement métier :
+// l'événement existe si et seulement si le changement a été validé.
+// payload est un des *Payload du paquet events, dont l'encodage JSON ne peut pas échouer.
+func outboxEventTx(client *db.PrismaClient, eventType, aggregateID string, payload any) db.PrismaTransaction {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic(fmt.Sprintf("charge utile d'événement %s invalide: %v", eventType, err))
+	}
+	return client.OutboxEvent.CreateOne(
+		db.OutboxEvent.Type.Set(eventType),
+		db.OutboxEvent.AggregateID.Set(aggregateID),
+		db.OutboxEvent.Payload.Set(db.JSON(data)),
+	).Tx()
+}
+
+// stockChangedTx prépare un événement ProductStockChanged pour une variante
+func stockChangedTx(client *db.PrismaClient, productID, variantID string, delta int, reason string) db.PrismaTransaction {
+	return outboxEventTx(client, events.ProductStockChanged, productID, events.ProductStockChangedPayload{
+		ProductID: productID,
+		VariantID: variantID,
+		Delta:     delta,
+		Reason:    reason,
+	})
+}
+
+// newID génère un UUID v4, pour connaître l'identifiant d'une entité avant sa création
+// (et l'inclure dans l'événement écrit dans la même transaction)
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// DispatchOutboxEvents distribue aux abonnés du bus les événements en attente, jusqu'à vider l'outbox.
+// Les événements sont réservés (FOR UPDATE SKIP LOCKED + bail) : plusieurs instances peuvent tourner en parallèle.
+// Un événement dont un abonné échoue est redistribué à cet abonné seul avec un délai exponentiel, puis abandonné
+// après OUTBOX_MAX_ATTEMPTS tentatives. Retourne le nombre d'événements distribués avec succès.
+func DispatchOutboxEvents(client *db.PrismaClient, bus *events.Bus) (int, error) {
+	ctx := context.Background()
+
+	delivered := 0
+	for {
+		var rows []struct {
+			ID          db.RawString   `json:"id"`
+			Type        db.RawString   `json:"type"`
+			AggregateID db.RawString   `json:"aggregateID"`
+			Payload     db.RawString   `json:"payload"`
+			Attempts    db.RawInt      `json:"attempts"`
+			DeliveredTo db.RawString   `json:"deliveredTo"`
+			CreatedAt   db.RawDateTime `json:"createdAt"`
+		}
+		err := client.Prisma.QueryRaw(
+			`UPDATE "OutboxEvent"
+			SET "attempts" = "attempts" + 1, "nextAttemptAt" = NOW() + ($2 * INTERVAL '1 second')
+			WHERE "id" IN (
+				SELECT "id" FROM "OutboxEvent"
+				WHERE "processedAt" IS NULL AND "failedAt" IS NULL AND "nextAttemptAt" <= NOW()
+				ORDER BY "createdAt"
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING "id", "type", "aggregateID", "payload"::text AS "payload", "attempts",
+				COALESCE(array_to_json("deliveredTo"), '[]'::json)::text AS "deliveredTo", "createdAt"`,
+			outboxBatchSize, int(outboxLease.Seconds()),
+		).Exec(ctx, &rows)
+		if err != nil {
+			return delivered, fmt.Errorf("erreur lors de la réservation des événements: %w", err)
+		}
+
+		// RETURNING ne garantit pas l'ordre : distribution dans l'ordre de création
+		sort.Slice(rows, func(i, j int) bool {
+			return time.Time(rows[i].CreatedAt).Before(time.Time(rows[j].CreatedAt))
+		})
+
+		for _, row := range rows {
+			event := events.Event{
+				ID:          string(row.ID),
+				Type:        string(row.Type),
+				AggregateID: string(row.AggregateID),
+				Payload:     json.RawMessage(row.Payload),
+				OccurredAt:  time.Time(row.CreatedAt),
+				Attempt:     int(row.Attempts),
+			}
+			if err := json.Unmarshal([]byte(row.DeliveredTo), &event.Delivered); err != nil {
+				return delivered, fmt.Errorf("abonnés de l'événement %s illisibles: %w", event.ID, err)
+			}
+			ok, err := dispatchOutboxEvent(ctx, client, bus, event)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+
+		if len(rows) < outboxBatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// dispatchOutboxEvent distribue un événement réservé, enregistre le résultat et indique si tous les abonnés ont réussi
+// Les abonnés qui ont réussi sont enregistrés : une nouvelle tentative ne rappelle que ceux qui ont échoué.
+// L'erreur retournée concerne uniquement l'enregistrement du résultat ; l'échec d'un abonné est journalisé.
+func dispatchOutboxEvent(ctx context.Context, client *db.PrismaClient, bus *events.Bus, event events.Event) (bool, error) {
+	handlerCtx, cancel := context.WithTimeout(ctx, outboxLease)
+	succeeded, publishErr := bus.Publish(handlerCtx, event)
+	cancel()
+
+	var params []db.OutboxEventSetParam
+	if len(succeeded) > 0 {
+		params = append(params, db.OutboxEvent.DeliveredTo.Set(append(event.Delivered, succeeded...)))
+	}
+	switch {
+	case publishErr == nil:
+		params = append(params, db.OutboxEvent.ProcessedAt.Set(time.Now()))
+	case event.Attempt >= outboxMaxAttempts():
+		log.Printf("outbox: événement %s (%s) abandonné après %d tentatives: %v", event.ID, event.Type, event.Attempt, publishErr)
+		params = append(params,
+			db.OutboxEvent.FailedAt.Set(time.Now()),
+			db.OutboxEvent.LastError.Set(publishErr.Error()),
+		)
+	default:
+		log.Printf("outbox: échec de la tentative %d de l'événement %s (%s): %v", event.Attempt, event.ID, event.Type, publishErr)
+		params = append(params,
+			db.OutboxEvent.NextAttemptAt.Set(time.Now().Add(outboxBackoff(event.Attempt))),
+			db.OutboxEvent.LastError.Set(publishErr.Error()),
+		)
+	}
+
+	_, err := client.OutboxEvent.FindUnique(
+		db.OutboxEvent.ID.Equals(event.ID),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de la mise à jour de l'événement %s: %w", event.ID, err)
+	}
+	return publishErr == nil, nil
+}
+
+// outboxBackoff retourne le délai avant la tentative suivante : 2s, 4s, 8s, ... plafonné à 1h
+func outboxBackoff(attempt int) time.Duration {
+	delay := 2 * time.Second
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Hour)
+}
+
+// PurgeProcessedOutboxEvents supprime les événements distribués depuis plus de OUTBOX_RETENTION_DAYS jours
+// Les événements abandonnés sont conservés pour analyse.
+func PurgeProcessedOutboxEvents(client *db.PrismaClient) (int, error) {
+	ctx := context.Background()
+
+	result, err := client.OutboxEvent.FindMany(
+		db.OutboxEvent.ProcessedAt.Lt(time.Now().AddDate(0, 0, -outboxRetentionDays())),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la purge des événements distribués: %w", err)
+	}
+	return result.Count, nil
+}
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"api/internal/payments"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+)
+
+// PayOrder initie le paiement d'une commande auprès d'un prestataire
+// Tant qu'un paiement en attente existe pour ce prestataire, la même tentative (et le même client secret) est retournée
+func PayOrder(client *db.PrismaClient, registry *payments.Registry, orderID, userID, providerName string) (*dtos.PaymentResponse, error) {
+	ctx := context.Background()
+
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).With(
+		db.Order.Payments.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("commande non trouvée")
+	}
+	if order.UserID != userID {
+		return nil, fmt.Errorf("accès non autorisé à cette commande")
+	}
+	if order.Status == db.OrderStatusCancelled {
+		return nil, fmt.Errorf("commande annulée")
+	}
+	if _, paid := order.PaidAt(); paid {
+		return nil, fmt.Errorf("commande déjà payée")
+	}
+
+	provider, ok := registry.Get(providerName)
+	if !ok {
+		return nil, fmt.Errorf("prestataire de paiement inconnu: %s", providerName)
+	}
+
+	// Une nouvelle tentative n'est créée qu'après l'échec des précédentes
+	attempt := 0
+	var pending *db.PaymentModel
+	for i, p := range order.RelationsOrder.Payments {
+		if p.Status == db.PaymentStatusFailed {
+			attempt++
+		} else if p.Provider == provider.Name() && (p.Status == db.PaymentStatusPending || p.Status == db.PaymentStatusAuthorized) {
+			pending = &order.RelationsOrder.Payments[i]
+		}
+	}
+
+	intent, err := provider.CreateIntent(ctx, payments.IntentRequest{
+		OrderID:   order.ID,
+		Amount:    order.TotalAmount,
+		Currency:  payments.Currency(),
+		Reference: fmt.Sprintf("%s:%d", order.ID, attempt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erreur du prestataire de paiement: %w", err)
+	}
+
+	payment := pending
+	if payment == nil || payment.ProviderPaymentID != intent.ID {
+		payment, err = client.Payment.CreateOne(
+			db.Payment.Provider.Set(provider.Name()),
+			db.Payment.ProviderPaymentID.Set(intent.ID),
+			db.Payment.Amount.Set(order.TotalAmount),
+			db.Payment.Order.Link(db.Order.ID.Equals(order.ID)),
+			db.Payment.Currency.Set(payments.Currency()),
+		).Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("erreur lors de l'enregistrement du paiement: %w", err)
+		}
+	}
+
+	response := convertPaymentToDTO(payment)
+	response.ClientSecret = intent.ClientSecret
+	return response, nil
+}
+
+// GetOrderPayments récupère les paiements d'une commande (propriétaire ou admin)
+func GetOrderPayments(client *db.PrismaClient, orderID, userID string, isAdmin bool) ([]dtos.PaymentResponse, error) {
+	ctx := context.Background()
+
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).With(
+		db.Order.Payments.Fetch().OrderBy(
+			db.Payment.CreatedAt.Order(db.SortOrderAsc),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("commande non trouvée")
+	}
+	if !isAdmin && order.UserID != userID {
+		return nil, fmt.Errorf("accès non autorisé à cette commande")
+	}
+
+	result := make([]dtos.PaymentResponse, len(order.RelationsOrder.Payments))
+	for i := range order.RelationsOrder.Payments {
+		result[i] = *convertPaymentToDTO(&order.RelationsOrder.Payments[i])
+	}
+
+	return result, nil
+}
+
+// HandlePaymentWebhook vérifie et applique un webhook de prestataire
+// Les événements déjà traités et les transitions de statut incohérentes (ex: échec après capture) sont ignorés
+func HandlePaymentWebhook(client *db.PrismaClient, provider payments.PaymentProvider, payload []byte, header http.Header) error {
+	ctx := context.Background()
+
+	event, err := provider.VerifyWebhook(payload, header)
+	if err != nil {
+		return err
+	}
+
+	// Déduplication
+	existing, err := client.PaymentWebhookEvent.FindFirst(
+		db.PaymentWebhookEvent.Provider.Equals(provider.Name()),
+		db.PaymentWebhookEvent.EventID.Equals(event.ID),
+	).Exec(ctx)
+	if err == nil && existing != nil {
+		return nil
+	}
+
+	payment, err := client.Payment.FindUnique(
+		db.Payment.ProviderPaymentID.Equals(event.PaymentID),
+	).Exec(ctx)
+	if err != nil || payment == nil || payment.Provider != provider.Name() {
+		return fmt.Errorf("paiement non trouvé")
+	}
+
+	var params []db.PaymentSetParam
+	var txs []db.PrismaTransaction
+
+	switch event.Type {
+	case payments.EventAuthorized:
+		if payment.Status == db.PaymentStatusPending {
+			// Capture automatique : le statut SUCCEEDED est confirmé par l'événement suivant
+			if err := provider.Capture(ctx, payment.ProviderPaymentID, payment.Amount); err != nil {
+				return fmt.Errorf("erreur lors de la capture du paiement: %w", err)
+			}
+			params = append(params, db.Payment.Status.Set(db.PaymentStatusAuthorized))
+		}
+
+	case payments.EventSucceeded:
+		// Le montant capturé doit être celui du paiement créé pour la commande
+		if roundAmount(event.Amount) != roundAmount(payment.Amount) {
+			return fmt.Errorf("montant du webhook incohérent: %.2f reçu pour un paiement de %.2f", event.Amount, payment.Amount)
+		}
+		if payment.Status == db.PaymentStatusPending || payment.Status == db.PaymentStatusAuthorized {
+			params = append(params, db.Payment.Status.Set(db.PaymentStatusSucceeded))
+			// Seul le premier paiement capturé date la commande
+			txs = append(txs, client.Order.FindMany(
+				db.Order.ID.Equals(payment.OrderID),
+				db.Order.PaidAt.IsNull(),
+			).Update(
+				db.Order.PaidAt.Set(time.Now()),
+			).Tx())
+			// La facture est numérotée au paiement
+			txs = append(txs, allocateInvoiceNumberTx(client, payment.OrderID))
+		}
+
+	case payments.EventFailed:
+		if payment.Status == db.PaymentStatusPending || payment.Status == db.PaymentStatusAuthorized {
+			params = append(params, db.Payment.Status.Set(db.PaymentStatusFailed))
+			if event.Reason != "" {
+				params = append(params, db.Payment.FailureReason.Set(event.Reason))
+			}
+		}
+
+	case payments.EventRefunded:
+		// Le montant de l'événement est le total remboursé : rejouer l'événement ne rembourse pas deux fois
+		if payment.Status == db.PaymentStatusSucceeded || payment.Status == db.PaymentStatusPartiallyRefunded {
+			refunded := math.Min(math.Max(payment.AmountRefunded, event.Amount), payment.Amount)
+			params = append(params,
+				db.Payment.AmountRefunded.Set(refunded),
+				db.Payment.Status.Set(refundStatus(payment.Amount, refunded)),
+			)
+		}
+	}
+
+	if len(params) > 0 {
+		txs = append(txs, client.Payment.FindUnique(
+			db.Payment.ID.Equals(payment.ID),
+		).Update(params...).Tx())
+	}
+	txs = append(txs, client.PaymentWebhookEvent.CreateOne(
+		db.PaymentWebhookEvent.Provider.Set(provider.Name()),
+		db.PaymentWebhookEvent.EventID.Set(event.ID),
+		db.PaymentWebhookEvent.Type.Set(event.Type),
+	).Tx())
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors du traitement du webhook: %w", err)
+	}
+
+	return nil
+}
+
+// RefundPayment rembourse tout ou partie d'un paiement capturé (admin only)
+// Un montant nul rembourse le solde restant. Le remboursement est reporté sur le total remboursé de la commande.
+func RefundPayment(client *db.PrismaClient, registry *payments.Registry, paymentID string, amount float64) (*dtos.PaymentResponse, error) {
+	ctx := context.Background()
+
+	payment, err := client.Payment.FindUnique(
+		db.Payment.ID.Equals(paymentID),
+	).Exec(ctx)
+	if err != nil || payment == nil {
+		return nil, fmt.Errorf("paiement non trouvé")
+	}
+	if payment.Status != db.PaymentStatusSucceeded && payment.Status != db.PaymentStatusPartiallyRefunded {
+		return nil, fmt.Errorf("seul un paiement capturé peut être remboursé")
+	}
+
+	if amount == 0 {
+		amount = roundAmount(payment.Amount - payment.AmountRefunded)
+	}
+
+	txs, err := issueRefund(ctx, client, registry, payment.OrderID, payment, amount, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("erreur lors de l'enregistrement du remboursement: %w", err)
+	}
+
+	payment, err = client.Payment.FindUnique(
+		db.Payment.ID.Equals(paymentID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération du paiement: %w", err)
+	}
+
+	return convertPaymentToDTO(payment), nil
+}
+
+// issueRefund rembourse un montant auprès du prestataire du paiement et retourne les écritures correspondantes
+// (paiement, total remboursé de la commande, Refund), à exécuter dans une même transaction.
+// Sans paiement (nil), le remboursement est enregistré comme manuel. Si la transaction échoue après le remboursement
+// chez le prestataire, le montant reste réservé sur le paiement et son webhook "payment.refunded" en met le statut à jour.
+func issueRefund(ctx context.Context, client *db.PrismaClient, registry *payments.Registry, orderID string, payment *db.PaymentModel, amount float64, returnRequestID string) ([]db.PrismaTransaction, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("le montant du remboursement doit être positif")
+	}
+
+	var txs []db.PrismaTransaction
+	var refundParams []db.RefundSetParam
+
+	if payment != nil {
+		remaining := roundAmount(payment.Amount - payment.AmountRefunded)
+		if amount > remaining {
+			return nil, fmt.Errorf("le montant du remboursement dépasse le solde restant (%.2f)", remaining)
+		}
+
+		provider, ok := registry.Get(payment.Provider)
+		if !ok {
+			return nil, fmt.Errorf("prestataire de paiement inconnu: %s", payment.Provider)
+		}
+
+		// Le montant est réservé sur le paiement avant l'appel au prestataire, et libéré s'il échoue
+		reserved, err := reserveRefund(ctx, client, payment.ID, amount)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			return nil, fmt.Errorf("le montant du remboursement dépasse le solde restant du paiement")
+		}
+		if _, err := provider.Refund(ctx, payment.ProviderPaymentID, amount); err != nil {
+			releaseRefund(ctx, client, payment.ID, amount)
+			return nil, fmt.Errorf("erreur du prestataire de paiement: %w", err)
+		}
+
+		// Le statut est déduit du montant remboursé à l'écriture, qui inclut les remboursements concurrents
+		txs = append(txs, client.Prisma.ExecuteRaw(
+			`UPDATE "Payment" SET
+				"status" = (CASE WHEN "amountRefunded" >= "amount" THEN 'REFUNDED' ELSE 'PARTIALLY_REFUNDED' END)::"PaymentStatus",
+				"updatedAt" = CURRENT_TIMESTAMP
+			WHERE "id" = $1`,
+			payment.ID,
+		).Tx())
+		refundParams = append(refundParams, db.Refund.Payment.Link(db.Payment.ID.Equals(payment.ID)))
+	}
+
+	if returnRequestID != "" {
+		refundParams = append(refundParams, db.Refund.ReturnRequest.Link(db.ReturnRequest.ID.Equals(returnRequestID)))
+	}
+
+	refundID := newID()
+	refundParams = append(refundParams, db.Refund.ID.Set(refundID))
+
+	txs = append(txs,
+		client.Refund.CreateOne(
+			db.Refund.Amount.Set(amount),
+			db.Refund.Order.Link(db.Order.ID.Equals(orderID)),
+			refundParams...,
+		).Tx(),
+		client.Order.FindUnique(
+			db.Order.ID.Equals(orderID),
+		).Update(
+			db.Order.RefundedAmount.Increment(amount),
+		).Tx(),
+		// L'e-mail de remboursement part de l'outbox : il n'est jamais perdu si le processus s'arrête après la transaction
+		outboxEventTx(client, events.OrderRefunded, orderID, events.OrderRefundedPayload{
+			OrderID:  orderID,
+			RefundID: refundID,
+			Amount:   amount,
+		}),
+	)
+
+	return txs, nil
+}
+
+// reserveRefund ajoute amount au montant remboursé d'un paiement si son solde restant le couvre
+// La vérification et la réservation sont une seule requête : deux remboursements simultanés du même paiement
+// ne peuvent pas dépasser son montant. Retourne false si le solde restant est insuffisant.
+func reserveRefund(ctx context.Context, client *db.PrismaClient, paymentID string, amount float64) (bool, error) {
+	result, err := client.Prisma.ExecuteRaw(
+		`UPDATE "Payment" SET "amountRefunded" = ROUND(("amountRefunded" + $2)::numeric, 2)
+		WHERE "id" = $1 AND ROUND(("amount" - "amountRefunded")::numeric, 2) >= ROUND($2::numeric, 2)`,
+		paymentID, amount,
+	).Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de la réservation du remboursement: %w", err)
+	}
+	return result.Count > 0, nil
+}
+
+// releaseRefund libère un montant réservé par reserveRefund dont le remboursement a échoué chez le prestataire
+func releaseRefund(ctx context.Context, client *db.PrismaClient, paymentID string, amount float64) {
+	_, err := client.Prisma.ExecuteRaw(
+		`UPDATE "Payment" SET "amountRefunded" = ROUND(("amountRefunded" - $2)::numeric, 2) WHERE "id" = $1`,
+		paymentID, amount,
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("paiement %s: impossible de libérer le remboursement réservé de %.2f: %v", paymentID, amount, err)
+	}
+}
+
+// BuildFakeWebhook construit un webhook signé du faux prestataire pour un paiement (tests en local)
+func BuildFakeWebhook(client *db.PrismaClient, fake *payments.FakeProvider, paymentID string, req dtos.SimulatePaymentWebhookRequest) (*dtos.SignedWebhookResponse, error) {
+	ctx := context.Background()
+
+	payment, err := client.Payment.FindUnique(
+		db.Payment.ID.Equals(paymentID),
+	).Exec(ctx)
+	if err != nil || payment == nil || payment.Provider != fake.Name() {
+		return nil, fmt.Errorf("paiement non trouvé")
+	}
+
+	amount := payment.Amount
+	switch req.Event {
+	case payments.EventAuthorized, payments.EventSucceeded, payments.EventFailed:
+	case payments.EventRefunded:
+		amount = payment.AmountRefunded
+		if amount == 0 {
+			amount = payment.Amount
+		}
+	default:
+		return nil, fmt.Errorf("type d'événement invalide: %s", req.Event)
+	}
+
+	payload, signature, err := fake.SignedWebhook(req.Event, payment.ProviderPaymentID, amount, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la construction du webhook: %w", err)
+	}
+
+	return &dtos.SignedWebhookResponse{
+		URL:             "/webhooks/payments/" + fake.Name(),
+		SignatureHeader: payments.FakeSignatureHeader,
+		Signature:       signature,
+		Payload:         string(payload),
+	}, nil
+}
+
+// refundStatus retourne le statut d'un paiement selon le montant remboursé
+func refundStatus(amount, refunded float64) db.PaymentStatus {
+	if refunded >= amount {
+		return db.PaymentStatusRefunded
+	}
+	return db.PaymentStatusPartiallyRefunded
+}
+
+// convertPaymentToDTO convertit un PaymentModel en PaymentResponse
+func convertPaymentToDTO(payment *db.PaymentModel) *dtos.PaymentResponse {
+	failureReason, _ := payment.FailureReason()
+
+	return &dtos.PaymentResponse{
+		ID:                payment.ID,
+		OrderID:           payment.OrderID,
+		Provider:          payment.Provider,
+		ProviderPaymentID: payment.ProviderPaymentID,
+		Status:            string(payment.Status),
+		Amount:            payment.Amount,
+		AmountRefunded:    payment.AmountRefunded,
+		Currency:          payment.Currency,
+		FailureReason:     string(failureReason),
+		CreatedAt:         payment.CreatedAt,
+		UpdatedAt:         payment.UpdatedAt,
+	}
+}
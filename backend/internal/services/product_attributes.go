@@ -0,0 +1,260 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"fmt"
+	"strings"
+)
+
+// Valeurs acceptées par l'API (en minuscules) pour les types de peau et préoccupations
+var (
+	skinTypeValues = map[string]db.SkinType{
+		"dry":         db.SkinTypeDry,
+		"oily":        db.SkinTypeOily,
+		"combination": db.SkinTypeCombination,
+		"sensitive":   db.SkinTypeSensitive,
+	}
+	skinConcernValues = map[string]db.SkinConcern{
+		"acne":              db.SkinConcernAcne,
+		"aging":             db.SkinConcernAging,
+		"hyperpigmentation": db.SkinConcernHyperpigmentation,
+		"dehydration":       db.SkinConcernDehydration,
+		"redness":           db.SkinConcernRedness,
+		"pores":             db.SkinConcernPores,
+		"dullness":          db.SkinConcernDullness,
+	}
+)
+
+// normalizeIngredient normalise un nom INCI (espaces superflus retirés, majuscules)
+func normalizeIngredient(name string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizeIngredients normalise une liste INCI en retirant les entrées vides et les doublons
+func normalizeIngredients(ingredients []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, ing := range ingredients {
+		name := normalizeIngredient(ing)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// parseSkinType convertit une valeur de l'API (ex: "sensitive") en db.SkinType
+func parseSkinType(value string) (db.SkinType, error) {
+	skinType, ok := skinTypeValues[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("type de peau invalide: %s", value)
+	}
+	return skinType, nil
+}
+
+// parseSkinConcern convertit une valeur de l'API (ex: "acne") en db.SkinConcern
+func parseSkinConcern(value string) (db.SkinConcern, error) {
+	concern, ok := skinConcernValues[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("préoccupation invalide: %s", value)
+	}
+	return concern, nil
+}
+
+// parseSkinTypes convertit une liste de types de peau en supprimant les doublons
+func parseSkinTypes(values []string) ([]db.SkinType, error) {
+	result := []db.SkinType{}
+	seen := make(map[db.SkinType]bool)
+	for _, v := range values {
+		skinType, err := parseSkinType(v)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[skinType] {
+			seen[skinType] = true
+			result = append(result, skinType)
+		}
+	}
+	return result, nil
+}
+
+// parseSkinConcerns convertit une liste de préoccupations en supprimant les doublons
+func parseSkinConcerns(values []string) ([]db.SkinConcern, error) {
+	result := []db.SkinConcern{}
+	seen := make(map[db.SkinConcern]bool)
+	for _, v := range values {
+		concern, err := parseSkinConcern(v)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[concern] {
+			seen[concern] = true
+			result = append(result, concern)
+		}
+	}
+	return result, nil
+}
+
+// validateProductMeasures vérifie la contenance et la PAO (0 = non renseignée)
+func validateProductMeasures(volumeML, paoMonths int) error {
+	if volumeML < 0 {
+		return fmt.Errorf("la contenance ne peut pas être négative")
+	}
+	if paoMonths < 0 {
+		return fmt.Errorf("la PAO ne peut pas être négative")
+	}
+	return nil
+}
+
+// productAttributeParams prépare les attributs skincare d'un ProductRequest (création ou remplacement complet)
+func productAttributeParams(req dtos.ProductRequest) ([]db.ProductSetParam, error) {
+	skinTypes, err := parseSkinTypes(req.SkinTypes)
+	if err != nil {
+		return nil, err
+	}
+	concerns, err := parseSkinConcerns(req.Concerns)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateProductMeasures(req.VolumeML, req.PAOMonths); err != nil {
+		return nil, err
+	}
+
+	params := []db.ProductSetParam{
+		db.Product.Ingredients.Set(normalizeIngredients(req.Ingredients)),
+		db.Product.SkinTypes.Set(skinTypes),
+		db.Product.Concerns.Set(concerns),
+		volumeParam(req.VolumeML),
+		paoParam(req.PAOMonths),
+	}
+	return params, nil
+}
+
+// patchProductAttributeParams prépare les attributs skincare fournis dans un PatchProductRequest
+func patchProductAttributeParams(req dtos.PatchProductRequest) ([]db.ProductSetParam, error) {
+	var params []db.ProductSetParam
+
+	if req.Ingredients != nil {
+		params = append(params, db.Product.Ingredients.Set(normalizeIngredients(*req.Ingredients)))
+	}
+	if req.SkinTypes != nil {
+		skinTypes, err := parseSkinTypes(*req.SkinTypes)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.Product.SkinTypes.Set(skinTypes))
+	}
+	if req.Concerns != nil {
+		concerns, err := parseSkinConcerns(*req.Concerns)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.Product.Concerns.Set(concerns))
+	}
+	if req.VolumeML != nil {
+		if err := validateProductMeasures(*req.VolumeML, 0); err != nil {
+			return nil, err
+		}
+		params = append(params, volumeParam(*req.VolumeML))
+	}
+	if req.PAOMonths != nil {
+		if err := validateProductMeasures(0, *req.PAOMonths); err != nil {
+			return nil, err
+		}
+		params = append(params, paoParam(*req.PAOMonths))
+	}
+
+	return params, nil
+}
+
+// volumeParam renseigne la contenance, 0 effaçant la valeur
+func volumeParam(volumeML int) db.ProductSetParam {
+	if volumeML == 0 {
+		return db.Product.VolumeML.SetOptional(nil)
+	}
+	return db.Product.VolumeML.Set(volumeML)
+}
+
+// paoParam renseigne la PAO, 0 effaçant la valeur
+func paoParam(paoMonths int) db.ProductSetParam {
+	if paoMonths == 0 {
+		return db.Product.PaoMonths.SetOptional(nil)
+	}
+	return db.Product.PaoMonths.Set(paoMonths)
+}
+
+// productFilterParams convertit les filtres de GET /products en conditions Prisma
+func productFilterParams(client *db.PrismaClient, filters dtos.ProductFilters) ([]db.ProductWhereParam, error) {
+	var where []db.ProductWhereParam
+
+	// Une catégorie inclut les produits de toutes ses sous-catégories
+	if filters.Category != "" {
+		categoryIDs, err := categorySubtreeIDs(client, filters.Category)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.Product.CategoryID.In(categoryIDs))
+	}
+
+	if filters.SkinType != "" {
+		skinType, err := parseSkinType(filters.SkinType)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.Product.SkinTypes.Has(skinType))
+	}
+
+	if filters.Concern != "" {
+		concern, err := parseSkinConcern(filters.Concern)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.Product.Concerns.Has(concern))
+	}
+
+	// Les produits sans liste d'ingrédients renseignée ne sont pas exclus
+	if excluded := normalizeIngredients(filters.ExcludeIngredients); len(excluded) > 0 {
+		where = append(where, db.Product.Not(
+			db.Product.Ingredients.HasSome(excluded),
+		))
+	}
+
+	return where, nil
+}
+
+// productOrderParams convertit le tri de GET /products en ordre Prisma (aucun ordre imposé par défaut)
+func productOrderParams(filters dtos.ProductFilters) ([]db.ProductOrderByParam, error) {
+	switch filters.Sort {
+	case "":
+		return nil, nil
+	case "rating":
+		// Meilleures notes d'abord ; à note égale, le produit le plus noté passe devant
+		return []db.ProductOrderByParam{
+			db.Product.RatingAverage.Order(db.SortOrderDesc),
+			db.Product.RatingCount.Order(db.SortOrderDesc),
+			db.Product.CreatedAt.Order(db.SortOrderAsc),
+		}, nil
+	}
+	return nil, fmt.Errorf("tri invalide: %s (valeur acceptée: rating)", filters.Sort)
+}
+
+// skinTypesToDTO convertit les types de peau en valeurs de l'API
+func skinTypesToDTO(values []db.SkinType) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = strings.ToLower(string(v))
+	}
+	return result
+}
+
+// skinConcernsToDTO convertit les préoccupations en valeurs de l'API
+func skinConcernsToDTO(values []db.SkinConcern) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = strings.ToLower(string(v))
+	}
+	return result
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/storage"
+	"api/internal/utils"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+)
+
+// MaxProductImageSize est la taille maximale d'une image uploadée (5 Mo)
+const MaxProductImageSize = 5 << 20
+
+// MaxProductImagePixels est la définition maximale d'une image uploadée (40 mégapixels)
+// Une image compressée de quelques Ko peut déclarer des dimensions énormes : la limite est
+// vérifiée sur l'en-tête, avant de décoder et d'allouer les pixels.
+const MaxProductImagePixels = 40_000_000
+
+// Tailles maximales (côté le plus long, en pixels) des versions redimensionnées
+const (
+	thumbnailSize = 200
+	mediumSize    = 800
+)
+
+// allowedImageTypes associe les types MIME acceptés à l'extension des fichiers stockés
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// UploadProductImage valide, redimensionne et enregistre une nouvelle image pour un produit
+// L'image est ajoutée à la fin de la liste (position = nombre d'images existantes)
+func UploadProductImage(client *db.PrismaClient, store storage.BlobStore, productID string, data []byte) (*dtos.ProductImageResponse, error) {
+	ctx := context.Background()
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("image invalide: le fichier est vide")
+	}
+	if len(data) > MaxProductImageSize {
+		return nil, fmt.Errorf("image trop volumineuse (maximum %d Mo)", MaxProductImageSize>>20)
+	}
+
+	// Le type est déterminé à partir du contenu, pas du nom ou de l'en-tête envoyé par le client
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedImageTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("type d'image non supporté (formats acceptés: JPEG, PNG)")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("image invalide: %w", err)
+	}
+	if int64(config.Width)*int64(config.Height) > MaxProductImagePixels {
+		return nil, fmt.Errorf("image trop volumineuse: %dx%d pixels (maximum %d mégapixels)",
+			config.Width, config.Height, MaxProductImagePixels/1_000_000)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("image invalide: %w", err)
+	}
+
+	// Vérifier que le produit existe
+	product, err := client.Product.FindUnique(
+		db.Product.ID.Equals(productID),
+	).With(
+		db.Product.Images.Fetch(),
+	).Exec(ctx)
+	if err != nil || product == nil {
+		return nil, fmt.Errorf("produit non trouvé")
+	}
+
+	position := 0
+	for _, existing := range product.Images() {
+		if existing.Position >= position {
+			position = existing.Position + 1
+		}
+	}
+
+	// Générer les versions redimensionnées
+	thumbnail, err := encodeImage(utils.ResizeImage(img, thumbnailSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+	medium, err := encodeImage(utils.ResizeImage(img, mediumSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	base := fmt.Sprintf("products/%s/%s", productID, storage.NewKeyToken())
+	originalKey := base + ext
+	thumbnailKey := base + "_thumb" + ext
+	mediumKey := base + "_medium" + ext
+
+	files := []struct {
+		key  string
+		data []byte
+	}{
+		{originalKey, data},
+		{thumbnailKey, thumbnail},
+		{mediumKey, medium},
+	}
+
+	var stored []string
+	for _, f := range files {
+		if err := store.Put(f.key, bytes.NewReader(f.data), contentType); err != nil {
+			deleteBlobs(store, stored)
+			return nil, fmt.Errorf("erreur lors de l'enregistrement de l'image: %w", err)
+		}
+		stored = append(stored, f.key)
+	}
+
+	bounds := img.Bounds()
+	created, err := client.ProductImage.CreateOne(
+		db.ProductImage.URL.Set(store.URL(originalKey)),
+		db.ProductImage.ThumbnailURL.Set(store.URL(thumbnailKey)),
+		db.ProductImage.MediumURL.Set(store.URL(mediumKey)),
+		db.ProductImage.ContentType.Set(contentType),
+		db.ProductImage.Width.Set(bounds.Dx()),
+		db.ProductImage.Height.Set(bounds.Dy()),
+		db.ProductImage.Size.Set(len(data)),
+		db.ProductImage.Product.Link(db.Product.ID.Equals(productID)),
+		db.ProductImage.StorageKeys.Set(stored),
+		db.ProductImage.Position.Set(position),
+	).Exec(ctx)
+	if err != nil {
+		deleteBlobs(store, stored)
+		return nil, fmt.Errorf("erreur lors de la création de l'image: %w", err)
+	}
+
+	return convertProductImageToDTO(created), nil
+}
+
+// DeleteProductImage supprime une image d'un produit ainsi que ses fichiers
+// Les images suivantes sont décalées pour garder des positions continues
+func DeleteProductImage(client *db.PrismaClient, store storage.BlobStore, productID, imageID string) error {
+	ctx := context.Background()
+
+	img, err := client.ProductImage.FindUnique(
+		db.ProductImage.ID.Equals(imageID),
+	).Exec(ctx)
+	if err != nil || img == nil || img.ProductID != productID {
+		return fmt.Errorf("image non trouvée")
+	}
+
+	deleteImage := client.ProductImage.FindUnique(
+		db.ProductImage.ID.Equals(imageID),
+	).Delete().Tx()
+	shiftImages := client.ProductImage.FindMany(
+		db.ProductImage.ProductID.Equals(productID),
+		db.ProductImage.Position.Gt(img.Position),
+	).Update(
+		db.ProductImage.Position.Decrement(1),
+	).Tx()
+
+	if err := client.Prisma.Transaction(deleteImage, shiftImages).Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de la suppression de l'image: %w", err)
+	}
+
+	// Les fichiers ne sont supprimés qu'une fois la ligne effacée : au pire, des fichiers orphelins
+	deleteBlobs(store, img.StorageKeys)
+
+	return nil
+}
+
+// ReorderProductImages réordonne les images d'un produit
+// imageIDs doit contenir exactement toutes les images du produit, la première devenant l'image principale
+func ReorderProductImages(client *db.PrismaClient, productID string, imageIDs []string) ([]dtos.ProductImageResponse, error) {
+	ctx := context.Background()
+
+	product, err := client.Product.FindUnique(
+		db.Product.ID.Equals(productID),
+	).With(
+		db.Product.Images.Fetch(),
+	).Exec(ctx)
+	if err != nil || product == nil {
+		return nil, fmt.Errorf("produit non trouvé")
+	}
+
+	existing := make(map[string]bool)
+	for _, img := range product.Images() {
+		existing[img.ID] = true
+	}
+
+	if len(imageIDs) != len(existing) {
+		return nil, fmt.Errorf("la liste doit contenir toutes les images du produit")
+	}
+	seen := make(map[string]bool)
+	for _, id := range imageIDs {
+		if !existing[id] || seen[id] {
+			return nil, fmt.Errorf("la liste doit contenir toutes les images du produit")
+		}
+		seen[id] = true
+	}
+
+	txs := make([]db.PrismaTransaction, len(imageIDs))
+	for i, id := range imageIDs {
+		txs[i] = client.ProductImage.FindUnique(
+			db.ProductImage.ID.Equals(id),
+		).Update(
+			db.ProductImage.Position.Set(i),
+		).Tx()
+	}
+	if len(txs) > 0 {
+		if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("erreur lors du réordonnancement des images: %w", err)
+		}
+	}
+
+	images, err := client.ProductImage.FindMany(
+		db.ProductImage.ProductID.Equals(productID),
+	).OrderBy(
+		db.ProductImage.Position.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des images: %w", err)
+	}
+
+	result := make([]dtos.ProductImageResponse, len(images))
+	for i, img := range images {
+		result[i] = *convertProductImageToDTO(&img)
+	}
+
+	return result, nil
+}
+
+// encodeImage encode une image dans le même format que l'original
+func encodeImage(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du redimensionnement de l'image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// deleteBlobs supprime des fichiers du store sans interrompre le traitement en cas d'échec
+func deleteBlobs(store storage.BlobStore, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			log.Printf("suppression du fichier %s impossible: %v", key, err)
+		}
+	}
+}
+
+// convertProductImageToDTO convertit un ProductImageModel en ProductImageResponse
+func convertProductImageToDTO(img *db.ProductImageModel) *dtos.ProductImageResponse {
+	return &dtos.ProductImageResponse{
+		ID:           img.ID,
+		URL:          img.URL,
+		ThumbnailURL: img.ThumbnailURL,
+		MediumURL:    img.MediumURL,
+		ContentType:  img.ContentType,
+		Width:        img.Width,
+		Height:       img.Height,
+		Size:         img.Size,
+		Position:     img.Position,
+		CreatedAt:    img.CreatedAt,
+	}
+}
@@ -0,0 +1,477 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Statuts possibles d'une ligne d'import
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusSkipped = "skipped"
+	ImportStatusError   = "error"
+)
+
+// importRow est une ligne lue depuis le fichier, avec son numéro et une éventuelle erreur de lecture
+type importRow struct {
+	line     int
+	data     dtos.ImportProductRow
+	parseErr string
+}
+
+// ImportProductsCSV importe des produits depuis un fichier CSV
+// La première ligne doit contenir les en-têtes: name, sku, description, price, stock, imageURL, category,
+// et optionnellement ingredients, skinTypes, concerns (valeurs séparées par |), volumeML, paoMonths
+func ImportProductsCSV(client *db.PrismaClient, r io.Reader, dryRun bool) (*dtos.ImportProductsResponse, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("fichier d'import invalide: le fichier est vide")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fichier d'import invalide: %w", err)
+	}
+
+	// Associer chaque colonne à son index (insensible à la casse)
+	columns := make(map[string]int)
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("fichier d'import invalide: colonne obligatoire manquante: name")
+	}
+	if _, ok := columns["price"]; !ok {
+		return nil, fmt.Errorf("fichier d'import invalide: colonne obligatoire manquante: price")
+	}
+
+	get := func(record []string, column string) string {
+		i, ok := columns[strings.ToLower(column)]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []importRow
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("fichier d'import invalide: %w", err)
+		}
+
+		row := importRow{
+			line: line,
+			data: dtos.ImportProductRow{
+				Name:        get(record, "name"),
+				SKU:         get(record, "sku"),
+				Description: get(record, "description"),
+				ImageURL:    get(record, "imageURL"),
+				Category:    get(record, "category"),
+				Ingredients: splitImportList(get(record, "ingredients")),
+				SkinTypes:   splitImportList(get(record, "skinTypes")),
+				Concerns:    splitImportList(get(record, "concerns")),
+			},
+		}
+
+		if priceStr := get(record, "price"); priceStr != "" {
+			price, err := strconv.ParseFloat(strings.Replace(priceStr, ",", ".", 1), 64)
+			if err != nil {
+				row.parseErr = fmt.Sprintf("prix invalide: %s", priceStr)
+			}
+			row.data.Price = price
+		}
+		if stockStr := get(record, "stock"); stockStr != "" && row.parseErr == "" {
+			stock, err := strconv.Atoi(stockStr)
+			if err != nil {
+				row.parseErr = fmt.Sprintf("stock invalide: %s", stockStr)
+			}
+			row.data.Stock = stock
+		}
+		if volumeStr := get(record, "volumeML"); volumeStr != "" && row.parseErr == "" {
+			volume, err := strconv.Atoi(volumeStr)
+			if err != nil {
+				row.parseErr = fmt.Sprintf("contenance invalide: %s", volumeStr)
+			}
+			row.data.VolumeML = volume
+		}
+		if paoStr := get(record, "paoMonths"); paoStr != "" && row.parseErr == "" {
+			pao, err := strconv.Atoi(paoStr)
+			if err != nil {
+				row.parseErr = fmt.Sprintf("PAO invalide: %s", paoStr)
+			}
+			row.data.PAOMonths = pao
+		}
+
+		rows = append(rows, row)
+	}
+
+	return importProducts(client, rows, dryRun)
+}
+
+// ImportProductsJSON importe des produits depuis un tableau JSON de dtos.ImportProductRow
+func ImportProductsJSON(client *db.PrismaClient, r io.Reader, dryRun bool) (*dtos.ImportProductsResponse, error) {
+	var data []dtos.ImportProductRow
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("fichier d'import invalide: %w", err)
+	}
+
+	rows := make([]importRow, len(data))
+	for i, d := range data {
+		d.Name = strings.TrimSpace(d.Name)
+		d.SKU = strings.TrimSpace(d.SKU)
+		d.Category = strings.TrimSpace(d.Category)
+		rows[i] = importRow{line: i + 1, data: d}
+	}
+
+	return importProducts(client, rows, dryRun)
+}
+
+// splitImportList découpe une cellule CSV contenant plusieurs valeurs séparées par |
+// (les noms INCI pouvant eux-mêmes contenir des virgules, ex: 1,2-HEXANEDIOL)
+func splitImportList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, "|") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// importProductRequest convertit une ligne d'import en ProductRequest, pour appliquer les mêmes règles qu'à la création
+func importProductRequest(row dtos.ImportProductRow) dtos.ProductRequest {
+	return dtos.ProductRequest{
+		Name:        row.Name,
+		SKU:         row.SKU,
+		Description: row.Description,
+		Price:       row.Price,
+		Stock:       row.Stock,
+		ImageURL:    row.ImageURL,
+		Ingredients: row.Ingredients,
+		SkinTypes:   row.SkinTypes,
+		Concerns:    row.Concerns,
+		VolumeML:    row.VolumeML,
+		PAOMonths:   row.PAOMonths,
+	}
+}
+
+// importAttributeParams prépare les attributs skincare renseignés dans la ligne qui diffèrent du produit existant
+// Comme pour les autres champs, une valeur absente de la ligne ne modifie pas le produit.
+func importAttributeParams(row dtos.ImportProductRow, existing *db.ProductModel) ([]db.ProductSetParam, error) {
+	var params []db.ProductSetParam
+
+	if ingredients := normalizeIngredients(row.Ingredients); len(ingredients) > 0 && !slices.Equal(ingredients, existing.Ingredients) {
+		params = append(params, db.Product.Ingredients.Set(ingredients))
+	}
+	if len(row.SkinTypes) > 0 {
+		skinTypes, err := parseSkinTypes(row.SkinTypes)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Equal(skinTypes, existing.SkinTypes) {
+			params = append(params, db.Product.SkinTypes.Set(skinTypes))
+		}
+	}
+	if len(row.Concerns) > 0 {
+		concerns, err := parseSkinConcerns(row.Concerns)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Equal(concerns, existing.Concerns) {
+			params = append(params, db.Product.Concerns.Set(concerns))
+		}
+	}
+	if volume, _ := existing.VolumeML(); row.VolumeML != 0 && volume != row.VolumeML {
+		params = append(params, volumeParam(row.VolumeML))
+	}
+	if pao, _ := existing.PaoMonths(); row.PAOMonths != 0 && pao != row.PAOMonths {
+		params = append(params, paoParam(row.PAOMonths))
+	}
+
+	return params, nil
+}
+
+// importProducts calcule le résultat de chaque ligne puis, si aucune ligne n'est en erreur
+// et que ce n'est pas une simulation, applique toutes les écritures dans une seule transaction
+func importProducts(client *db.PrismaClient, rows []importRow, dryRun bool) (*dtos.ImportProductsResponse, error) {
+	ctx := context.Background()
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("fichier d'import invalide: aucun produit à importer")
+	}
+
+	// Charger les catégories et produits existants une seule fois
+	categories, err := client.Category.FindMany().Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des catégories: %w", err)
+	}
+	categoriesByName := make(map[string]string)
+	categorySlugs := make(map[string]bool)
+	for _, c := range categories {
+		categoriesByName[strings.ToLower(c.Name)] = c.Name
+		categorySlugs[c.Slug] = true
+	}
+
+	products, err := client.Product.FindMany().With(
+		db.Product.Variants.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des produits: %w", err)
+	}
+	productsByName := make(map[string]*db.ProductModel)
+	productsBySKU := make(map[string]*db.ProductModel)
+	for i := range products {
+		p := &products[i]
+		productsByName[p.Name] = p
+		if sku, ok := p.Sku(); ok && sku != "" {
+			productsBySKU[string(sku)] = p
+		}
+	}
+
+	response := &dtos.ImportProductsResponse{
+		DryRun:            dryRun,
+		CategoriesCreated: []string{},
+		Rows:              make([]dtos.ImportRowResult, len(rows)),
+	}
+
+	var categoryTxs []db.PrismaTransaction
+	var productTxs []db.PrismaTransaction
+	createdTxs := make(map[int]db.ProductUniqueTxResult)
+	seenNames := make(map[string]int)
+	seenSKUs := make(map[string]int)
+
+	for i, row := range rows {
+		req := row.data
+		result := dtos.ImportRowResult{
+			Row:  row.line,
+			Name: req.Name,
+			SKU:  req.SKU,
+		}
+
+		fail := func(status, reason string) {
+			result.Status = status
+			result.Reason = reason
+			response.Rows[i] = result
+			if status == ImportStatusError {
+				response.Errors++
+			} else {
+				response.Skipped++
+			}
+		}
+
+		if row.parseErr != "" {
+			fail(ImportStatusError, row.parseErr)
+			continue
+		}
+
+		if err := ValidateProductRequest(importProductRequest(req)); err != nil {
+			fail(ImportStatusError, err.Error())
+			continue
+		}
+
+		// Une même ligne ne doit apparaître qu'une fois dans le fichier
+		if first, ok := seenNames[req.Name]; ok {
+			fail(ImportStatusSkipped, fmt.Sprintf("produit déjà présent dans le fichier (ligne %d)", first))
+			continue
+		}
+		if first, ok := seenSKUs[req.SKU]; ok && req.SKU != "" {
+			fail(ImportStatusSkipped, fmt.Sprintf("SKU déjà présent dans le fichier (ligne %d)", first))
+			continue
+		}
+		seenNames[req.Name] = row.line
+		if req.SKU != "" {
+			seenSKUs[req.SKU] = row.line
+		}
+
+		// Rechercher le produit existant : par SKU en priorité, sinon par nom
+		var existing *db.ProductModel
+		if req.SKU != "" {
+			existing = productsBySKU[req.SKU]
+		}
+		if existing == nil {
+			existing = productsByName[req.Name]
+		}
+		if existing != nil {
+			if other, ok := productsByName[req.Name]; ok && other.ID != existing.ID {
+				fail(ImportStatusError, "un produit avec ce nom existe déjà")
+				continue
+			}
+			if other, ok := productsBySKU[req.SKU]; ok && req.SKU != "" && other.ID != existing.ID {
+				fail(ImportStatusError, "un produit avec ce SKU existe déjà")
+				continue
+			}
+		}
+
+		// Résoudre la catégorie par son nom (insensible à la casse), la créer si besoin
+		var categoryName string
+		if req.Category != "" {
+			name, ok := categoriesByName[strings.ToLower(req.Category)]
+			if !ok {
+				name = req.Category
+				categoriesByName[strings.ToLower(name)] = name
+				response.CategoriesCreated = append(response.CategoriesCreated, name)
+				slug := availableCategorySlug(categorySlugBase(name), categorySlugs)
+				categorySlugs[slug] = true
+				categoryTxs = append(categoryTxs, client.Category.CreateOne(
+					db.Category.Name.Set(name),
+					db.Category.Slug.Set(slug),
+				).Tx())
+			}
+			categoryName = name
+		}
+
+		if existing == nil {
+			params := []db.ProductSetParam{
+				db.Product.Stock.Set(req.Stock),
+			}
+			if req.SKU != "" {
+				params = append(params, db.Product.Sku.Set(req.SKU))
+			}
+			if req.Description != "" {
+				params = append(params, db.Product.Description.Set(req.Description))
+			}
+			if req.ImageURL != "" {
+				params = append(params, db.Product.ImageURL.Set(req.ImageURL))
+			}
+			if categoryName != "" {
+				params = append(params, db.Product.Category.Link(db.Category.Name.Equals(categoryName)))
+			}
+			attributeParams, err := productAttributeParams(importProductRequest(req))
+			if err != nil {
+				fail(ImportStatusError, err.Error())
+				continue
+			}
+			params = append(params, attributeParams...)
+
+			tx := client.Product.CreateOne(
+				db.Product.Name.Set(req.Name),
+				db.Product.Price.Set(req.Price),
+				params...,
+			).Tx()
+			productTxs = append(productTxs, tx, client.ProductVariant.CreateOne(
+				db.ProductVariant.Label.Set(DefaultVariantLabel),
+				db.ProductVariant.Price.Set(req.Price),
+				db.ProductVariant.Product.Link(db.Product.Name.Equals(req.Name)),
+				db.ProductVariant.Stock.Set(req.Stock),
+				db.ProductVariant.IsDefault.Set(true),
+			).Tx())
+			createdTxs[i] = tx
+
+			result.Status = ImportStatusCreated
+			response.Rows[i] = result
+			response.Created++
+			continue
+		}
+
+		// Produit existant : ne mettre à jour que ce qui change
+		// Le prix et le stock importés s'appliquent à la variante par défaut
+		var params []db.ProductSetParam
+		var variantParams []db.ProductVariantSetParam
+		if existing.Name != req.Name {
+			params = append(params, db.Product.Name.Set(req.Name))
+		}
+		currentPrice, currentStock := existing.Price, existing.Stock
+		if variant := defaultVariant(existing); variant != nil {
+			currentPrice, currentStock = variant.Price, variant.Stock
+		}
+		if currentPrice != req.Price {
+			variantParams = append(variantParams, db.ProductVariant.Price.Set(req.Price))
+		}
+		if currentStock != req.Stock {
+			variantParams = append(variantParams, db.ProductVariant.Stock.Set(req.Stock))
+		}
+		if sku, _ := existing.Sku(); req.SKU != "" && string(sku) != req.SKU {
+			params = append(params, db.Product.Sku.Set(req.SKU))
+		}
+		if desc, _ := existing.Description(); req.Description != "" && string(desc) != req.Description {
+			params = append(params, db.Product.Description.Set(req.Description))
+		}
+		if img, _ := existing.ImageURL(); req.ImageURL != "" && string(img) != req.ImageURL {
+			params = append(params, db.Product.ImageURL.Set(req.ImageURL))
+		}
+		if categoryName != "" {
+			currentID, _ := existing.CategoryID()
+			currentName := ""
+			for _, c := range categories {
+				if c.ID == currentID {
+					currentName = c.Name
+				}
+			}
+			if currentName != categoryName {
+				params = append(params, db.Product.Category.Link(db.Category.Name.Equals(categoryName)))
+			}
+		}
+
+		attributeParams, err := importAttributeParams(req, existing)
+		if err != nil {
+			fail(ImportStatusError, err.Error())
+			continue
+		}
+		params = append(params, attributeParams...)
+
+		result.ProductID = existing.ID
+		if len(params) == 0 && len(variantParams) == 0 {
+			fail(ImportStatusSkipped, "aucune modification")
+			continue
+		}
+
+		if len(params) > 0 {
+			productTxs = append(productTxs, client.Product.FindUnique(
+				db.Product.ID.Equals(existing.ID),
+			).Update(params...).Tx())
+		}
+		if len(variantParams) > 0 {
+			productTxs = append(productTxs, updateDefaultVariantTxs(client, existing.ID, variantParams...)...)
+		}
+		if currentStock != req.Stock {
+			var variantID string
+			if variant := defaultVariant(existing); variant != nil {
+				variantID = variant.ID
+			}
+			productTxs = append(productTxs, stockChangedTx(client, existing.ID, variantID, req.Stock-currentStock, events.StockReasonImport))
+		}
+
+		result.Status = ImportStatusUpdated
+		response.Rows[i] = result
+		response.Updated++
+	}
+
+	// Une seule ligne en erreur suffit à annuler l'import : rien n'est écrit
+	if dryRun || response.Errors > 0 {
+		return response, nil
+	}
+
+	txs := append(categoryTxs, productTxs...)
+	if len(txs) > 0 {
+		if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("erreur lors de l'import des produits: %w", err)
+		}
+	}
+
+	for i, tx := range createdTxs {
+		if product := tx.Result(); product != nil {
+			response.Rows[i].ProductID = product.ID
+		}
+	}
+	response.Applied = true
+
+	return response, nil
+}
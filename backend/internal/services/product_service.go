@@ -3,6 +3,7 @@ package services
 import (
 	"api/internal/db"
 	"api/internal/dtos"
+	"api/internal/events"
 	"context"
 	"fmt"
 	"math"
@@ -10,55 +11,34 @@ import (
 
 // GetAllProducts récupère tous les produits (sans pagination - pour compatibilité)
 // NOTE: Vous devez d'abord exécuter: npx prisma generate pour régénérer le client
-func GetAllProducts(client *db.PrismaClient) ([]dtos.ProductResponse, error) {
+func GetAllProducts(client *db.PrismaClient, filters dtos.ProductFilters) ([]dtos.ProductResponse, error) {
 	ctx := context.Background()
 
-	products, err := client.Product.FindMany().With(
-		db.Product.Category.Fetch(),
-	).Exec(ctx)
+	where, err := productFilterParams(client, filters)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération des produits: %w", err)
+		return nil, err
 	}
 
-	result := make([]dtos.ProductResponse, len(products))
-	for i, p := range products {
-		var description string
-		if desc, ok := p.Description(); ok {
-			description = string(desc)
-		}
+	order, err := productOrderParams(filters)
+	if err != nil {
+		return nil, err
+	}
 
-		var imageURL string
-		if img, ok := p.ImageURL(); ok {
-			imageURL = string(img)
-		}
+	query := client.Product.FindMany(where...).With(
+		productWith()...,
+	)
+	if len(order) > 0 {
+		query = query.OrderBy(order...)
+	}
 
-		var categoryID *string
-		var category *dtos.CategoryResponse
-
-		if catID, ok := p.CategoryID(); ok {
-			categoryID = &catID
-			if cat, ok := p.Category(); ok && cat != nil {
-				category = &dtos.CategoryResponse{
-					ID:        cat.ID,
-					Name:      cat.Name,
-					CreatedAt: cat.CreatedAt,
-					UpdatedAt: cat.UpdatedAt,
-				}
-			}
-		}
+	products, err := query.Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des produits: %w", err)
+	}
 
-		result[i] = dtos.ProductResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: description,
-			Price:       p.Price,
-			Stock:       p.Stock,
-			ImageURL:    imageURL,
-			CategoryID:  categoryID,
-			Category:    category,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
-		}
+	result := make([]dtos.ProductResponse, len(products))
+	for i, p := range products {
+		result[i] = *convertProductToDTO(&p)
 	}
 
 	return result, nil
@@ -67,7 +47,8 @@ func GetAllProducts(client *db.PrismaClient) ([]dtos.ProductResponse, error) {
 // GetProductsPaginated récupère les produits avec pagination
 // page: numéro de page (commence à 1)
 // limit: nombre d'éléments par page (défaut: 10, max: 100)
-func GetProductsPaginated(client *db.PrismaClient, page, limit int) (*dtos.PaginatedProductsResponse, error) {
+// filters: filtres skincare optionnels (type de peau, préoccupation, ingrédients exclus)
+func GetProductsPaginated(client *db.PrismaClient, page, limit int, filters dtos.ProductFilters) (*dtos.PaginatedProductsResponse, error) {
 	ctx := context.Background()
 
 	// Valider et ajuster les paramètres
@@ -84,8 +65,17 @@ func GetProductsPaginated(client *db.PrismaClient, page, limit int) (*dtos.Pagin
 	// Calculer le skip
 	skip := (page - 1) * limit
 
+	where, err := productFilterParams(client, filters)
+	if err != nil {
+		return nil, err
+	}
+	order, err := productOrderParams(filters)
+	if err != nil {
+		return nil, err
+	}
+
 	// Compter le total de produits
-	total, err := client.Product.FindMany().Exec(ctx)
+	total, err := client.Product.FindMany(where...).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors du comptage des produits: %w", err)
 	}
@@ -93,7 +83,7 @@ func GetProductsPaginated(client *db.PrismaClient, page, limit int) (*dtos.Pagin
 
 	// Récupérer les produits paginés
 	// Note: Prisma Go utilise Take() et Skip() comme méthodes de chaînage
-	query := client.Product.FindMany()
+	query := client.Product.FindMany(where...)
 
 	// Appliquer Take et Skip
 	if limit > 0 {
@@ -103,8 +93,12 @@ func GetProductsPaginated(client *db.PrismaClient, page, limit int) (*dtos.Pagin
 		query = query.Skip(skip)
 	}
 
+	if len(order) > 0 {
+		query = query.OrderBy(order...)
+	}
+
 	products, err := query.With(
-		db.Product.Category.Fetch(),
+		productWith()...,
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la récupération des produits: %w", err)
@@ -113,43 +107,7 @@ func GetProductsPaginated(client *db.PrismaClient, page, limit int) (*dtos.Pagin
 	// Convertir en DTO
 	result := make([]dtos.ProductResponse, len(products))
 	for i, p := range products {
-		var description string
-		if desc, ok := p.Description(); ok {
-			description = string(desc)
-		}
-
-		var imageURL string
-		if img, ok := p.ImageURL(); ok {
-			imageURL = string(img)
-		}
-
-		var categoryID *string
-		var category *dtos.CategoryResponse
-
-		if catID, ok := p.CategoryID(); ok {
-			categoryID = &catID
-			if cat, ok := p.Category(); ok && cat != nil {
-				category = &dtos.CategoryResponse{
-					ID:        cat.ID,
-					Name:      cat.Name,
-					CreatedAt: cat.CreatedAt,
-					UpdatedAt: cat.UpdatedAt,
-				}
-			}
-		}
-
-		result[i] = dtos.ProductResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: description,
-			Price:       p.Price,
-			Stock:       p.Stock,
-			ImageURL:    imageURL,
-			CategoryID:  categoryID,
-			Category:    category,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
-		}
+		result[i] = *convertProductToDTO(&p)
 	}
 
 	// Calculer les métadonnées
@@ -176,7 +134,7 @@ func GetProductByID(client *db.PrismaClient, productID string) (*dtos.ProductRes
 	product, err := client.Product.FindUnique(
 		db.Product.ID.Equals(productID),
 	).With(
-		db.Product.Category.Fetch(),
+		productWith()...,
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la récupération du produit: %w", err)
@@ -186,43 +144,15 @@ func GetProductByID(client *db.PrismaClient, productID string) (*dtos.ProductRes
 		return nil, nil
 	}
 
-	var description string
-	if desc, ok := product.Description(); ok {
-		description = string(desc)
-	}
-
-	var imageURL string
-	if img, ok := product.ImageURL(); ok {
-		imageURL = string(img)
-	}
-
-	var categoryID *string
-	var category *dtos.CategoryResponse
-
-	if catID, ok := product.CategoryID(); ok {
-		categoryID = &catID
-		if cat, ok := product.Category(); ok && cat != nil {
-			category = &dtos.CategoryResponse{
-				ID:        cat.ID,
-				Name:      cat.Name,
-				CreatedAt: cat.CreatedAt,
-				UpdatedAt: cat.UpdatedAt,
-			}
-		}
+	response := convertProductToDTO(product)
+	withAnswer := true
+	answered, err := countPublishedQuestions(client, productID, &withAnswer)
+	if err != nil {
+		return nil, err
 	}
+	response.AnsweredQuestionCount = &answered
 
-	return &dtos.ProductResponse{
-		ID:          product.ID,
-		Name:        product.Name,
-		Description: description,
-		Price:       product.Price,
-		Stock:       product.Stock,
-		ImageURL:    imageURL,
-		CategoryID:  categoryID,
-		Category:    category,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-	}, nil
+	return response, nil
 }
 
 // CreateProduct crée un nouveau produit
@@ -237,6 +167,16 @@ func CreateProduct(client *db.PrismaClient, req dtos.ProductRequest) (*dtos.Prod
 		return nil, fmt.Errorf("un produit avec ce nom existe déjà")
 	}
 
+	// Vérifier si le SKU est déjà utilisé
+	if req.SKU != "" {
+		skuExists, _ := client.Product.FindUnique(
+			db.Product.Sku.Equals(req.SKU),
+		).Exec(ctx)
+		if skuExists != nil {
+			return nil, fmt.Errorf("un produit avec ce SKU existe déjà")
+		}
+	}
+
 	// Préparer les options de création
 	var createParams []db.ProductSetParam
 	createParams = append(createParams,
@@ -245,6 +185,9 @@ func CreateProduct(client *db.PrismaClient, req dtos.ProductRequest) (*dtos.Prod
 		db.Product.Stock.Set(req.Stock),
 	)
 
+	if req.SKU != "" {
+		createParams = append(createParams, db.Product.Sku.Set(req.SKU))
+	}
 	if req.Description != "" {
 		createParams = append(createParams, db.Product.Description.Set(req.Description))
 	}
@@ -262,62 +205,63 @@ func CreateProduct(client *db.PrismaClient, req dtos.ProductRequest) (*dtos.Prod
 		createParams = append(createParams, db.Product.Category.Link(db.Category.ID.Equals(req.CategoryID)))
 	}
 
-	product, err := client.Product.CreateOne(
+	// Attributs skincare
+	attributeParams, err := productAttributeParams(req)
+	if err != nil {
+		return nil, err
+	}
+	createParams = append(createParams, attributeParams...)
+
+	// Le produit est créé avec sa variante par défaut, qui porte le prix et le stock
+	createTx := client.Product.CreateOne(
 		db.Product.Name.Set(req.Name),
 		db.Product.Price.Set(req.Price),
 		createParams[2:]...,
-	).Exec(ctx)
-	if err != nil {
+	).Tx()
+	variantTx := client.ProductVariant.CreateOne(
+		db.ProductVariant.Label.Set(DefaultVariantLabel),
+		db.ProductVariant.Price.Set(req.Price),
+		db.ProductVariant.Product.Link(db.Product.Name.Equals(req.Name)),
+		db.ProductVariant.Stock.Set(req.Stock),
+		db.ProductVariant.IsDefault.Set(true),
+	).Tx()
+	if err := client.Prisma.Transaction(createTx, variantTx).Exec(ctx); err != nil {
 		return nil, fmt.Errorf("erreur lors de la création du produit: %w", err)
 	}
+	product := createTx.Result()
 
 	// Récupérer le produit avec la catégorie
 	product, err = client.Product.FindUnique(
 		db.Product.ID.Equals(product.ID),
 	).With(
-		db.Product.Category.Fetch(),
+		productWith()...,
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la récupération du produit: %w", err)
 	}
 
-	var description string
-	if desc, ok := product.Description(); ok {
-		description = string(desc)
-	}
+	return convertProductToDTO(product), nil
+}
 
-	var imageURL string
-	if img, ok := product.ImageURL(); ok {
-		imageURL = string(img)
+// ValidateProductRequest applique les règles de validation d'un produit
+// Source unique des règles pour la création, la mise à jour et l'import en masse
+func ValidateProductRequest(req dtos.ProductRequest) error {
+	if req.Name == "" {
+		return fmt.Errorf("le nom du produit est requis")
 	}
-
-	var categoryID *string
-	var category *dtos.CategoryResponse
-
-	if catID, ok := product.CategoryID(); ok {
-		categoryID = &catID
-		if cat, ok := product.Category(); ok && cat != nil {
-			category = &dtos.CategoryResponse{
-				ID:        cat.ID,
-				Name:      cat.Name,
-				CreatedAt: cat.CreatedAt,
-				UpdatedAt: cat.UpdatedAt,
-			}
-		}
+	if req.Price <= 0 {
+		return fmt.Errorf("le prix doit être supérieur à 0")
 	}
-
-	return &dtos.ProductResponse{
-		ID:          product.ID,
-		Name:        product.Name,
-		Description: description,
-		Price:       product.Price,
-		Stock:       product.Stock,
-		ImageURL:    imageURL,
-		CategoryID:  categoryID,
-		Category:    category,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-	}, nil
+	if req.Stock < 0 {
+		return fmt.Errorf("le stock ne peut pas être négatif")
+	}
+	if _, err := parseSkinTypes(req.SkinTypes); err != nil {
+		return err
+	}
+	if _, err := parseSkinConcerns(req.Concerns); err != nil {
+		return err
+	}
+	return validateProductMeasures(req.VolumeML, req.PAOMonths)
 }
 
 // UpdateProduct met à jour un produit
@@ -327,6 +271,8 @@ func UpdateProduct(client *db.PrismaClient, productID string, req dtos.ProductRe
 	// Vérifier que le produit existe
 	existingProduct, err := client.Product.FindUnique(
 		db.Product.ID.Equals(productID),
+	).With(
+		db.Product.Variants.Fetch(),
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("produit non trouvé")
@@ -342,14 +288,26 @@ func UpdateProduct(client *db.PrismaClient, productID string, req dtos.ProductRe
 		}
 	}
 
+	// Vérifier si le SKU est déjà utilisé par un autre produit
+	if req.SKU != "" {
+		skuExists, _ := client.Product.FindUnique(
+			db.Product.Sku.Equals(req.SKU),
+		).Exec(ctx)
+		if skuExists != nil && skuExists.ID != productID {
+			return nil, fmt.Errorf("un produit avec ce SKU existe déjà")
+		}
+	}
+
 	// Préparer les options de mise à jour
 	var updateOptions []db.ProductSetParam
 	updateOptions = append(updateOptions,
 		db.Product.Name.Set(req.Name),
-		db.Product.Price.Set(req.Price),
-		db.Product.Stock.Set(req.Stock),
 	)
 
+	if req.SKU != "" {
+		updateOptions = append(updateOptions, db.Product.Sku.Set(req.SKU))
+	}
+
 	if req.Description != "" {
 		updateOptions = append(updateOptions, db.Product.Description.Set(req.Description))
 	}
@@ -372,10 +330,27 @@ func UpdateProduct(client *db.PrismaClient, productID string, req dtos.ProductRe
 		updateOptions = append(updateOptions, db.Product.Category.Unlink())
 	}
 
-	_, err = client.Product.FindUnique(
-		db.Product.ID.Equals(productID),
-	).Update(updateOptions...).Exec(ctx)
+	// Attributs skincare (remplacés entièrement)
+	attributeParams, err := productAttributeParams(req)
 	if err != nil {
+		return nil, err
+	}
+	updateOptions = append(updateOptions, attributeParams...)
+
+	// Le prix et le stock s'appliquent à la variante par défaut
+	txs := []db.PrismaTransaction{
+		client.Product.FindUnique(
+			db.Product.ID.Equals(productID),
+		).Update(updateOptions...).Tx(),
+	}
+	txs = append(txs, updateDefaultVariantTxs(client, productID,
+		db.ProductVariant.Price.Set(req.Price),
+		db.ProductVariant.Stock.Set(req.Stock),
+	)...)
+	if variant := defaultVariant(existingProduct); variant != nil && variant.Stock != req.Stock {
+		txs = append(txs, stockChangedTx(client, productID, variant.ID, req.Stock-variant.Stock, events.StockReasonAdmin))
+	}
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
 		return nil, fmt.Errorf("erreur lors de la mise à jour du produit: %w", err)
 	}
 
@@ -383,49 +358,13 @@ func UpdateProduct(client *db.PrismaClient, productID string, req dtos.ProductRe
 	product, err := client.Product.FindUnique(
 		db.Product.ID.Equals(productID),
 	).With(
-		db.Product.Category.Fetch(),
+		productWith()...,
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la récupération du produit: %w", err)
 	}
 
-	var description string
-	if desc, ok := product.Description(); ok {
-		description = string(desc)
-	}
-
-	var imageURL string
-	if img, ok := product.ImageURL(); ok {
-		imageURL = string(img)
-	}
-
-	var categoryID *string
-	var category *dtos.CategoryResponse
-
-	if catID, ok := product.CategoryID(); ok {
-		categoryID = &catID
-		if cat, ok := product.Category(); ok && cat != nil {
-			category = &dtos.CategoryResponse{
-				ID:        cat.ID,
-				Name:      cat.Name,
-				CreatedAt: cat.CreatedAt,
-				UpdatedAt: cat.UpdatedAt,
-			}
-		}
-	}
-
-	return &dtos.ProductResponse{
-		ID:          product.ID,
-		Name:        product.Name,
-		Description: description,
-		Price:       product.Price,
-		Stock:       product.Stock,
-		ImageURL:    imageURL,
-		CategoryID:  categoryID,
-		Category:    category,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-	}, nil
+	return convertProductToDTO(product), nil
 }
 
 // DeleteProduct supprime un produit
@@ -449,6 +388,8 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 	// Vérifier que le produit existe
 	existingProduct, err := client.Product.FindUnique(
 		db.Product.ID.Equals(productID),
+	).With(
+		db.Product.Variants.Fetch(),
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("produit non trouvé")
@@ -456,6 +397,8 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 
 	// Préparer les options de mise à jour (seulement les champs fournis)
 	updateParams := []db.ProductSetParam{}
+	// Le prix et le stock s'appliquent à la variante par défaut
+	variantParams := []db.ProductVariantSetParam{}
 
 	// Nom (si fourni)
 	if req.Name != nil {
@@ -471,6 +414,21 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 		updateParams = append(updateParams, db.Product.Name.Set(*req.Name))
 	}
 
+	// SKU (si fourni) - une chaîne vide supprime la référence
+	if req.SKU != nil {
+		if *req.SKU == "" {
+			updateParams = append(updateParams, db.Product.Sku.SetOptional(nil))
+		} else {
+			skuExists, _ := client.Product.FindUnique(
+				db.Product.Sku.Equals(*req.SKU),
+			).Exec(ctx)
+			if skuExists != nil && skuExists.ID != productID {
+				return nil, fmt.Errorf("un produit avec ce SKU existe déjà")
+			}
+			updateParams = append(updateParams, db.Product.Sku.Set(*req.SKU))
+		}
+	}
+
 	// Description (si fournie)
 	if req.Description != nil {
 		updateParams = append(updateParams, db.Product.Description.Set(*req.Description))
@@ -481,7 +439,7 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 		if *req.Price <= 0 {
 			return nil, fmt.Errorf("le prix doit être supérieur à 0")
 		}
-		updateParams = append(updateParams, db.Product.Price.Set(*req.Price))
+		variantParams = append(variantParams, db.ProductVariant.Price.Set(*req.Price))
 	}
 
 	// Stock (si fourni)
@@ -489,7 +447,7 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 		if *req.Stock < 0 {
 			return nil, fmt.Errorf("le stock ne peut pas être négatif")
 		}
-		updateParams = append(updateParams, db.Product.Stock.Set(*req.Stock))
+		variantParams = append(variantParams, db.ProductVariant.Stock.Set(*req.Stock))
 	}
 
 	// ImageURL (si fournie)
@@ -514,30 +472,64 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 		}
 	}
 
+	// Attributs skincare (si fournis)
+	attributeParams, err := patchProductAttributeParams(req)
+	if err != nil {
+		return nil, err
+	}
+	updateParams = append(updateParams, attributeParams...)
+
 	// Si aucun champ n'est fourni, retourner une erreur
-	if len(updateParams) == 0 {
+	if len(updateParams) == 0 && len(variantParams) == 0 {
 		return nil, fmt.Errorf("au moins un champ doit être fourni pour la mise à jour")
 	}
 
-	// Mettre à jour le produit
-	product, err := client.Product.FindUnique(
-		db.Product.ID.Equals(productID),
-	).Update(updateParams...).Exec(ctx)
-	if err != nil {
+	// Mettre à jour le produit et sa variante par défaut
+	var txs []db.PrismaTransaction
+	if len(updateParams) > 0 {
+		txs = append(txs, client.Product.FindUnique(
+			db.Product.ID.Equals(productID),
+		).Update(updateParams...).Tx())
+	}
+	if len(variantParams) > 0 {
+		txs = append(txs, updateDefaultVariantTxs(client, productID, variantParams...)...)
+	}
+	if variant := defaultVariant(existingProduct); req.Stock != nil && variant != nil && variant.Stock != *req.Stock {
+		txs = append(txs, stockChangedTx(client, productID, variant.ID, *req.Stock-variant.Stock, events.StockReasonAdmin))
+	}
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
 		return nil, fmt.Errorf("erreur lors de la mise à jour du produit: %w", err)
 	}
 
 	// Récupérer le produit avec la catégorie
-	product, err = client.Product.FindUnique(
+	product, err := client.Product.FindUnique(
 		db.Product.ID.Equals(productID),
 	).With(
-		db.Product.Category.Fetch(),
+		productWith()...,
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la récupération du produit: %w", err)
 	}
 
-	// Convertir en DTO
+	return convertProductToDTO(product), nil
+}
+
+// productWith liste les relations chargées avec chaque produit retourné par l'API
+func productWith() []db.ProductRelationWith {
+	return []db.ProductRelationWith{
+		db.Product.Category.Fetch(),
+		db.Product.Images.Fetch().OrderBy(
+			db.ProductImage.Position.Order(db.SortOrderAsc),
+		),
+		db.Product.Variants.Fetch().OrderBy(
+			db.ProductVariant.CreatedAt.Order(db.SortOrderAsc),
+		),
+	}
+}
+
+// convertProductToDTO convertit un ProductModel en ProductResponse
+// La catégorie, les images et les variantes ne sont renseignées que si elles ont été chargées (voir productWith)
+func convertProductToDTO(product *db.ProductModel) *dtos.ProductResponse {
 	var description string
 	if desc, ok := product.Description(); ok {
 		description = string(desc)
@@ -548,6 +540,11 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 		imageURL = string(img)
 	}
 
+	var sku string
+	if s, ok := product.Sku(); ok {
+		sku = string(s)
+	}
+
 	var categoryID *string
 	var category *dtos.CategoryResponse
 
@@ -557,22 +554,61 @@ func PatchProduct(client *db.PrismaClient, productID string, req dtos.PatchProdu
 			category = &dtos.CategoryResponse{
 				ID:        cat.ID,
 				Name:      cat.Name,
+				Slug:      cat.Slug,
+				SortOrder: cat.SortOrder,
 				CreatedAt: cat.CreatedAt,
 				UpdatedAt: cat.UpdatedAt,
 			}
 		}
 	}
 
+	images := make([]dtos.ProductImageResponse, len(product.RelationsProduct.Images))
+	for i, img := range product.RelationsProduct.Images {
+		images[i] = *convertProductImageToDTO(&img)
+	}
+
+	variants := make([]dtos.ProductVariantResponse, len(product.RelationsProduct.Variants))
+	for i, v := range product.RelationsProduct.Variants {
+		variants[i] = *convertProductVariantToDTO(&v)
+	}
+
+	var volumeML, paoMonths *int
+	if v, ok := product.VolumeML(); ok {
+		volumeML = &v
+	}
+	if v, ok := product.PaoMonths(); ok {
+		paoMonths = &v
+	}
+
+	// Sans URL saisie manuellement, l'image principale uploadée sert d'image du produit
+	if imageURL == "" && len(images) > 0 {
+		imageURL = images[0].MediumURL
+	}
+
+	ratingAverage, ratingHistogram := productRatingToDTO(product)
+
 	return &dtos.ProductResponse{
 		ID:          product.ID,
 		Name:        product.Name,
+		SKU:         sku,
 		Description: description,
 		Price:       product.Price,
 		Stock:       product.Stock,
 		ImageURL:    imageURL,
+		Images:      images,
+		Variants:    variants,
+		Ingredients: append([]string{}, product.Ingredients...),
+		SkinTypes:   skinTypesToDTO(product.SkinTypes),
+		Concerns:    skinConcernsToDTO(product.Concerns),
+		VolumeML:    volumeML,
+		PAOMonths:   paoMonths,
 		CategoryID:  categoryID,
 		Category:    category,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
-	}, nil
+
+		RatingAverage:   ratingAverage,
+		RatingCount:     product.RatingCount,
+		RatingHistogram: ratingHistogram,
+	}
 }
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"context"
+	"fmt"
+)
+
+// DefaultVariantLabel est le libellé de la variante créée automatiquement avec un produit
+const DefaultVariantLabel = "Standard"
+
+// CreateProductVariant ajoute une variante à un produit
+// La première variante d'un produit devient automatiquement la variante par défaut
+func CreateProductVariant(client *db.PrismaClient, productID string, req dtos.ProductVariantRequest) (*dtos.ProductVariantResponse, error) {
+	ctx := context.Background()
+
+	if err := validateProductVariantRequest(req); err != nil {
+		return nil, err
+	}
+
+	product, err := client.Product.FindUnique(
+		db.Product.ID.Equals(productID),
+	).With(
+		db.Product.Variants.Fetch(),
+	).Exec(ctx)
+	if err != nil || product == nil {
+		return nil, fmt.Errorf("produit non trouvé")
+	}
+
+	if err := checkVariantUnique(client, req, ""); err != nil {
+		return nil, err
+	}
+
+	isDefault := req.IsDefault || len(product.Variants()) == 0
+
+	params := []db.ProductVariantSetParam{
+		db.ProductVariant.Stock.Set(req.Stock),
+		db.ProductVariant.IsDefault.Set(isDefault),
+		db.ProductVariant.WeightGrams.Set(req.WeightGrams),
+	}
+	if req.SKU != "" {
+		params = append(params, db.ProductVariant.Sku.Set(req.SKU))
+	}
+	if req.Barcode != "" {
+		params = append(params, db.ProductVariant.Barcode.Set(req.Barcode))
+	}
+
+	// Identifiant généré ici pour l'événement de stock écrit dans la même transaction
+	variantID := newID()
+	params = append(params, db.ProductVariant.ID.Set(variantID))
+
+	var txs []db.PrismaTransaction
+	if isDefault {
+		txs = append(txs, unsetDefaultVariantTx(client, productID))
+	}
+	createTx := client.ProductVariant.CreateOne(
+		db.ProductVariant.Label.Set(req.Label),
+		db.ProductVariant.Price.Set(req.Price),
+		db.ProductVariant.Product.Link(db.Product.ID.Equals(productID)),
+		params...,
+	).Tx()
+	txs = append(txs, createTx, syncProductFromVariantsTx(client, productID))
+	if req.Stock > 0 {
+		txs = append(txs, stockChangedTx(client, productID, variantID, req.Stock, events.StockReasonAdmin))
+	}
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la variante: %w", err)
+	}
+
+	return convertProductVariantToDTO(createTx.Result()), nil
+}
+
+// UpdateProductVariant met à jour une variante d'un produit
+// Une variante par défaut ne peut pas perdre ce statut directement : il faut désigner une autre variante par défaut
+func UpdateProductVariant(client *db.PrismaClient, productID, variantID string, req dtos.ProductVariantRequest) (*dtos.ProductVariantResponse, error) {
+	ctx := context.Background()
+
+	if err := validateProductVariantRequest(req); err != nil {
+		return nil, err
+	}
+
+	variant, err := client.ProductVariant.FindUnique(
+		db.ProductVariant.ID.Equals(variantID),
+	).Exec(ctx)
+	if err != nil || variant == nil || variant.ProductID != productID {
+		return nil, fmt.Errorf("variante non trouvée")
+	}
+
+	if err := checkVariantUnique(client, req, variantID); err != nil {
+		return nil, err
+	}
+
+	params := []db.ProductVariantSetParam{
+		db.ProductVariant.Label.Set(req.Label),
+		db.ProductVariant.Price.Set(req.Price),
+		db.ProductVariant.Stock.Set(req.Stock),
+		db.ProductVariant.WeightGrams.Set(req.WeightGrams),
+	}
+	// Une chaîne vide supprime la référence / le code-barres
+	if req.SKU != "" {
+		params = append(params, db.ProductVariant.Sku.Set(req.SKU))
+	} else {
+		params = append(params, db.ProductVariant.Sku.SetOptional(nil))
+	}
+	if req.Barcode != "" {
+		params = append(params, db.ProductVariant.Barcode.Set(req.Barcode))
+	} else {
+		params = append(params, db.ProductVariant.Barcode.SetOptional(nil))
+	}
+
+	var txs []db.PrismaTransaction
+	if req.IsDefault && !variant.IsDefault {
+		txs = append(txs, unsetDefaultVariantTx(client, productID))
+		params = append(params, db.ProductVariant.IsDefault.Set(true))
+	}
+	updateTx := client.ProductVariant.FindUnique(
+		db.ProductVariant.ID.Equals(variantID),
+	).Update(params...).Tx()
+	txs = append(txs, updateTx, syncProductFromVariantsTx(client, productID))
+	if req.Stock != variant.Stock {
+		txs = append(txs, stockChangedTx(client, productID, variantID, req.Stock-variant.Stock, events.StockReasonAdmin))
+	}
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour de la variante: %w", err)
+	}
+
+	return convertProductVariantToDTO(updateTx.Result()), nil
+}
+
+// DeleteProductVariant supprime une variante d'un produit
+// Un produit conserve toujours au moins une variante ; si la variante par défaut est supprimée,
+// la plus ancienne des variantes restantes la remplace
+func DeleteProductVariant(client *db.PrismaClient, productID, variantID string) error {
+	ctx := context.Background()
+
+	variant, err := client.ProductVariant.FindUnique(
+		db.ProductVariant.ID.Equals(variantID),
+	).Exec(ctx)
+	if err != nil || variant == nil || variant.ProductID != productID {
+		return fmt.Errorf("variante non trouvée")
+	}
+
+	others, err := client.ProductVariant.FindMany(
+		db.ProductVariant.ProductID.Equals(productID),
+		db.ProductVariant.ID.Not(variantID),
+	).OrderBy(
+		db.ProductVariant.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des variantes: %w", err)
+	}
+	if len(others) == 0 {
+		return fmt.Errorf("un produit doit conserver au moins une variante")
+	}
+
+	txs := []db.PrismaTransaction{
+		client.ProductVariant.FindUnique(
+			db.ProductVariant.ID.Equals(variantID),
+		).Delete().Tx(),
+	}
+	if variant.IsDefault {
+		txs = append(txs, client.ProductVariant.FindUnique(
+			db.ProductVariant.ID.Equals(others[0].ID),
+		).Update(
+			db.ProductVariant.IsDefault.Set(true),
+		).Tx())
+	}
+	txs = append(txs, syncProductFromVariantsTx(client, productID))
+	if variant.Stock > 0 {
+		txs = append(txs, stockChangedTx(client, productID, variantID, -variant.Stock, events.StockReasonAdmin))
+	}
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la variante: %w", err)
+	}
+
+	return nil
+}
+
+// validateProductVariantRequest applique les règles de validation d'une variante
+func validateProductVariantRequest(req dtos.ProductVariantRequest) error {
+	if req.Label == "" {
+		return fmt.Errorf("le libellé de la variante est requis")
+	}
+	if req.Price <= 0 {
+		return fmt.Errorf("le prix doit être supérieur à 0")
+	}
+	if req.Stock < 0 {
+		return fmt.Errorf("le stock ne peut pas être négatif")
+	}
+	if req.WeightGrams < 0 {
+		return fmt.Errorf("le poids ne peut pas être négatif")
+	}
+	return nil
+}
+
+// checkVariantUnique vérifie que le SKU et le code-barres ne sont pas utilisés par une autre variante
+func checkVariantUnique(client *db.PrismaClient, req dtos.ProductVariantRequest, variantID string) error {
+	ctx := context.Background()
+
+	if req.SKU != "" {
+		existing, _ := client.ProductVariant.FindUnique(
+			db.ProductVariant.Sku.Equals(req.SKU),
+		).Exec(ctx)
+		if existing != nil && existing.ID != variantID {
+			return fmt.Errorf("une variante avec ce SKU existe déjà")
+		}
+	}
+	if req.Barcode != "" {
+		existing, _ := client.ProductVariant.FindUnique(
+			db.ProductVariant.Barcode.Equals(req.Barcode),
+		).Exec(ctx)
+		if existing != nil && existing.ID != variantID {
+			return fmt.Errorf("une variante avec ce code-barres existe déjà")
+		}
+	}
+	return nil
+}
+
+// defaultVariant retourne la variante par défaut d'un produit chargé avec ses variantes (nil si absente)
+func defaultVariant(product *db.ProductModel) *db.ProductVariantModel {
+	for i := range product.RelationsProduct.Variants {
+		if product.RelationsProduct.Variants[i].IsDefault {
+			return &product.RelationsProduct.Variants[i]
+		}
+	}
+	return nil
+}
+
+// unsetDefaultVariantTx retire le statut par défaut des variantes d'un produit
+func unsetDefaultVariantTx(client *db.PrismaClient, productID string) db.PrismaTransaction {
+	return client.ProductVariant.FindMany(
+		db.ProductVariant.ProductID.Equals(productID),
+		db.ProductVariant.IsDefault.Equals(true),
+	).Update(
+		db.ProductVariant.IsDefault.Set(false),
+	).Tx()
+}
+
+// updateDefaultVariantTxs met à jour la variante par défaut d'un produit puis resynchronise le produit
+func updateDefaultVariantTxs(client *db.PrismaClient, productID string, params ...db.ProductVariantSetParam) []db.PrismaTransaction {
+	return []db.PrismaTransaction{
+		client.ProductVariant.FindMany(
+			db.ProductVariant.ProductID.Equals(productID),
+			db.ProductVariant.IsDefault.Equals(true),
+		).Update(params...).Tx(),
+		syncProductFromVariantsTx(client, productID),
+	}
+}
+
+// syncProductFromVariantsTx recalcule le prix (variante par défaut) et le stock (somme des variantes) du produit
+// Ces champs sont conservés sur Product pour le listing et les filtres
+func syncProductFromVariantsTx(client *db.PrismaClient, productID string) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(
+		`UPDATE "Product" SET
+			"stock" = COALESCE((SELECT SUM("stock") FROM "ProductVariant" WHERE "productID" = $1), 0),
+			"price" = COALESCE((SELECT "price" FROM "ProductVariant" WHERE "productID" = $1 AND "isDefault" = true LIMIT 1), "price"),
+			"updatedAt" = CURRENT_TIMESTAMP
+		WHERE "id" = $1`,
+		productID,
+	).Tx()
+}
+
+// convertProductVariantToDTO convertit un ProductVariantModel en ProductVariantResponse
+func convertProductVariantToDTO(variant *db.ProductVariantModel) *dtos.ProductVariantResponse {
+	sku, _ := variant.Sku()
+	barcode, _ := variant.Barcode()
+
+	return &dtos.ProductVariantResponse{
+		ID:          variant.ID,
+		SKU:         string(sku),
+		Label:       variant.Label,
+		Price:       variant.Price,
+		Stock:       variant.Stock,
+		Barcode:     string(barcode),
+		IsDefault:   variant.IsDefault,
+		WeightGrams: variant.WeightGrams,
+		CreatedAt:   variant.CreatedAt,
+		UpdatedAt:   variant.UpdatedAt,
+	}
+}
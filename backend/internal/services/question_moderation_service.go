@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"api/internal/db"
+	"api/internal/dtos"
+)
+
+// GetQuestionsForModeration liste les questions avec toutes leurs réponses, des plus récentes aux plus anciennes,
+// filtrables par statut et par produit (admin only)
+func GetQuestionsForModeration(client *db.PrismaClient, status, productID string, page, limit int) (*dtos.PaginatedQuestionsResponse, error) {
+	ctx := context.Background()
+
+	page, limit = moderationPage(page, limit)
+
+	var filters []db.QuestionWhereParam
+	if status != "" {
+		questionStatus, err := parseQuestionStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, db.Question.Status.Equals(questionStatus))
+	}
+	if productID != "" {
+		filters = append(filters, db.Question.ProductID.Equals(productID))
+	}
+
+	total, err := countModerationQueue(client, "Question", status, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := client.Question.FindMany(
+		filters...,
+	).With(
+		db.Question.User.Fetch(),
+		answersWith(),
+	).OrderBy(
+		db.Question.CreatedAt.Order(db.SortOrderDesc),
+	).Take(limit).Skip((page - 1) * limit).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des questions: %w", err)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return &dtos.PaginatedQuestionsResponse{
+		Questions:  convertQuestionsToDTO(questions),
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// GetAnswersForModeration liste les réponses, des plus récentes aux plus anciennes, filtrables par statut (admin only)
+func GetAnswersForModeration(client *db.PrismaClient, status string, page, limit int) (*dtos.PaginatedAnswersResponse, error) {
+	ctx := context.Background()
+
+	page, limit = moderationPage(page, limit)
+
+	var filters []db.AnswerWhereParam
+	if status != "" {
+		answerStatus, err := parseQuestionStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, db.Answer.Status.Equals(answerStatus))
+	}
+
+	total, err := countModerationQueue(client, "Answer", status, "")
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := client.Answer.FindMany(
+		filters...,
+	).With(
+		db.Answer.User.Fetch(),
+	).OrderBy(
+		db.Answer.CreatedAt.Order(db.SortOrderDesc),
+	).Take(limit).Skip((page - 1) * limit).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des réponses: %w", err)
+	}
+
+	result := make([]dtos.AnswerResponse, 0, len(answers))
+	for i, answer := range answers {
+		if user := answer.User(); user != nil {
+			result = append(result, *convertAnswerToDTO(&answers[i], user.Email))
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return &dtos.PaginatedAnswersResponse{
+		Answers:    result,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// ApproveQuestion publie une question (admin only)
+func ApproveQuestion(client *db.PrismaClient, questionID string) (*dtos.QuestionResponse, error) {
+	return moderateQuestion(client, questionID, db.QuestionStatusApproved, nil)
+}
+
+// RejectQuestion refuse une question avec un motif obligatoire (admin only)
+func RejectQuestion(client *db.PrismaClient, questionID string, req dtos.ModerateQuestionRequest) (*dtos.QuestionResponse, error) {
+	reason, err := requireModerationReason(req.Reason, "du refus")
+	if err != nil {
+		return nil, err
+	}
+	return moderateQuestion(client, questionID, db.QuestionStatusRejected, &reason)
+}
+
+// ApproveAnswer publie une réponse (admin only)
+// Elle n'est visible que lorsque sa question est elle-même publiée.
+func ApproveAnswer(client *db.PrismaClient, answerID string) (*dtos.AnswerResponse, error) {
+	return moderateAnswer(client, answerID, db.QuestionStatusApproved, nil)
+}
+
+// RejectAnswer refuse une réponse avec un motif obligatoire (admin only)
+func RejectAnswer(client *db.PrismaClient, answerID string, req dtos.ModerateQuestionRequest) (*dtos.AnswerResponse, error) {
+	reason, err := requireModerationReason(req.Reason, "du refus")
+	if err != nil {
+		return nil, err
+	}
+	return moderateAnswer(client, answerID, db.QuestionStatusRejected, &reason)
+}
+
+// DeleteQuestionAsAdmin supprime définitivement une question et ses réponses (admin only)
+// Le motif et la question supprimée sont enregistrés dans le journal de modération, dans la même transaction.
+func DeleteQuestionAsAdmin(client *db.PrismaClient, questionID, adminID string, req dtos.ModerateQuestionRequest) error {
+	ctx := context.Background()
+
+	reason, err := requireModerationReason(req.Reason, "de la suppression")
+	if err != nil {
+		return err
+	}
+
+	question, err := client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).Exec(ctx)
+	if err != nil || question == nil {
+		return fmt.Errorf("question non trouvée")
+	}
+
+	err = client.Prisma.Transaction(
+		client.Question.FindUnique(
+			db.Question.ID.Equals(questionID),
+		).Delete().Tx(),
+		moderationLogTx(client, moderationLog{
+			EntityType: moderationEntityQuestion,
+			EntityID:   question.ID,
+			ProductID:  question.ProductID,
+			AuthorID:   question.UserID,
+			AdminID:    adminID,
+			Reason:     reason,
+			Content:    question.Body,
+		}),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la question: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAnswerAsAdmin supprime définitivement une réponse (admin only)
+// Le motif et la réponse supprimée sont enregistrés dans le journal de modération, dans la même transaction.
+func DeleteAnswerAsAdmin(client *db.PrismaClient, answerID, adminID string, req dtos.ModerateQuestionRequest) error {
+	ctx := context.Background()
+
+	reason, err := requireModerationReason(req.Reason, "de la suppression")
+	if err != nil {
+		return err
+	}
+
+	answer, err := client.Answer.FindUnique(
+		db.Answer.ID.Equals(answerID),
+	).With(
+		db.Answer.Question.Fetch(),
+	).Exec(ctx)
+	if err != nil || answer == nil {
+		return fmt.Errorf("réponse non trouvée")
+	}
+
+	err = client.Prisma.Transaction(
+		client.Answer.FindUnique(
+			db.Answer.ID.Equals(answerID),
+		).Delete().Tx(),
+		moderationLogTx(client, moderationLog{
+			EntityType: moderationEntityAnswer,
+			EntityID:   answer.ID,
+			ProductID:  answer.Question().ProductID,
+			AuthorID:   answer.UserID,
+			AdminID:    adminID,
+			Reason:     reason,
+			Content:    answer.Body,
+		}),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la réponse: %w", err)
+	}
+
+	return nil
+}
+
+// moderateQuestion applique la décision de l'administrateur sur une question
+func moderateQuestion(client *db.PrismaClient, questionID string, status db.QuestionStatus, reason *string) (*dtos.QuestionResponse, error) {
+	ctx := context.Background()
+
+	question, err := client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).With(
+		db.Question.User.Fetch(),
+		answersWith(),
+	).Exec(ctx)
+	if err != nil || question == nil {
+		return nil, fmt.Errorf("question non trouvée")
+	}
+
+	updated, err := client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).Update(
+		db.Question.Status.Set(status),
+		db.Question.ModerationReason.SetOptional(reason),
+		db.Question.ModeratedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la modération de la question: %w", err)
+	}
+
+	question.InnerQuestion = updated.InnerQuestion
+	return convertQuestionToDTO(question, question.User().Email), nil
+}
+
+// moderateAnswer applique la décision de l'administrateur sur une réponse
+func moderateAnswer(client *db.PrismaClient, answerID string, status db.QuestionStatus, reason *string) (*dtos.AnswerResponse, error) {
+	ctx := context.Background()
+
+	answer, err := client.Answer.FindUnique(
+		db.Answer.ID.Equals(answerID),
+	).With(
+		db.Answer.User.Fetch(),
+	).Exec(ctx)
+	if err != nil || answer == nil {
+		return nil, fmt.Errorf("réponse non trouvée")
+	}
+
+	updated, err := client.Answer.FindUnique(
+		db.Answer.ID.Equals(answerID),
+	).Update(
+		db.Answer.Status.Set(status),
+		db.Answer.ModerationReason.SetOptional(reason),
+		db.Answer.ModeratedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la modération de la réponse: %w", err)
+	}
+
+	return convertAnswerToDTO(updated, answer.User().Email), nil
+}
+
+// parseQuestionStatus valide un statut de modération de question ou de réponse fourni par l'API
+func parseQuestionStatus(status string) (db.QuestionStatus, error) {
+	switch s := db.QuestionStatus(strings.ToUpper(status)); s {
+	case db.QuestionStatusPending, db.QuestionStatusApproved, db.QuestionStatusRejected:
+		return s, nil
+	}
+	return "", fmt.Errorf("statut invalide: %s", status)
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/utils"
+)
+
+// Longueurs maximales des questions et des réponses, en caractères
+const (
+	maxQuestionLength = 1000
+	maxAnswerLength   = 2000
+)
+
+// CreateQuestion enregistre la question d'un client sur un produit
+// La question est publiée après modération, sauf si QUESTION_AUTO_APPROVE est activé.
+func CreateQuestion(client *db.PrismaClient, productID, userID string, req dtos.CreateQuestionRequest) (*dtos.QuestionResponse, error) {
+	ctx := context.Background()
+
+	body, err := validateQuestionText(req.Body, "la question", maxQuestionLength)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := client.Product.FindUnique(
+		db.Product.ID.Equals(productID),
+	).Exec(ctx)
+	if err != nil || product == nil {
+		return nil, fmt.Errorf("produit non trouvé")
+	}
+
+	user, err := client.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("utilisateur non trouvé")
+	}
+
+	question, err := client.Question.CreateOne(
+		db.Question.Body.Set(body),
+		db.Question.User.Link(db.User.ID.Equals(userID)),
+		db.Question.Product.Link(db.Product.ID.Equals(productID)),
+		db.Question.Status.Set(initialQuestionStatus()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la question: %w", err)
+	}
+
+	return convertQuestionToDTO(question, user.Email), nil
+}
+
+// CreateAnswer enregistre une réponse à une question publiée
+// Un administrateur publie directement une réponse officielle ; un client doit avoir reçu le produit,
+// ne peut pas répondre à sa propre question et sa réponse passe par la modération.
+func CreateAnswer(client *db.PrismaClient, questionID, userID string, isAdmin bool, req dtos.CreateAnswerRequest) (*dtos.AnswerResponse, error) {
+	ctx := context.Background()
+
+	body, err := validateQuestionText(req.Body, "la réponse", maxAnswerLength)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).Exec(ctx)
+	if err != nil || question == nil || question.Status != db.QuestionStatusApproved {
+		return nil, fmt.Errorf("question non trouvée")
+	}
+
+	user, err := client.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("utilisateur non trouvé")
+	}
+
+	status := db.QuestionStatusApproved
+	verified := false
+	if !isAdmin {
+		if question.UserID == userID {
+			return nil, fmt.Errorf("vous ne pouvez pas répondre à votre propre question")
+		}
+		verified, err = hasReceivedProduct(client, userID, question.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if !verified {
+			return nil, fmt.Errorf("vous n'êtes pas autorisé à répondre : seuls les clients ayant reçu ce produit peuvent répondre")
+		}
+		status = initialQuestionStatus()
+	}
+
+	answer, err := client.Answer.CreateOne(
+		db.Answer.Body.Set(body),
+		db.Answer.Question.Link(db.Question.ID.Equals(questionID)),
+		db.Answer.User.Link(db.User.ID.Equals(userID)),
+		db.Answer.Status.Set(status),
+		db.Answer.Official.Set(isAdmin),
+		db.Answer.VerifiedPurchase.Set(verified),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la réponse: %w", err)
+	}
+
+	return convertAnswerToDTO(answer, user.Email), nil
+}
+
+// GetProductQuestions récupère une page des questions publiées d'un produit avec leurs réponses publiées
+// Les réponses officielles viennent en premier, puis les plus votées.
+func GetProductQuestions(client *db.PrismaClient, productID, userID string, filters dtos.QuestionFilters) (*dtos.PaginatedQuestionsResponse, error) {
+	ctx := context.Background()
+
+	page, limit := filters.Page, filters.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	order, err := questionOrderParams(filters.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := client.Product.FindUnique(
+		db.Product.ID.Equals(productID),
+	).Exec(ctx)
+	if err != nil || product == nil {
+		return nil, fmt.Errorf("produit non trouvé")
+	}
+
+	where := []db.QuestionWhereParam{
+		db.Question.ProductID.Equals(productID),
+		db.Question.Status.Equals(db.QuestionStatusApproved),
+	}
+	if filters.Answered != nil {
+		published := db.Answer.Status.Equals(db.QuestionStatusApproved)
+		if *filters.Answered {
+			where = append(where, db.Question.Answers.Some(published))
+		} else {
+			where = append(where, db.Question.Answers.None(published))
+		}
+	}
+
+	total, err := countPublishedQuestions(client, productID, filters.Answered)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := client.Question.FindMany(
+		where...,
+	).With(
+		db.Question.User.Fetch(),
+		answersWith(db.Answer.Status.Equals(db.QuestionStatusApproved)),
+	).OrderBy(
+		order...,
+	).Take(limit).Skip((page - 1) * limit).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des questions: %w", err)
+	}
+
+	result := convertQuestionsToDTO(questions)
+	if err := markUpvoted(client, userID, result); err != nil {
+		return nil, err
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return &dtos.PaginatedQuestionsResponse{
+		Questions:  result,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// DeleteQuestion supprime une question de son auteur, avec ses réponses
+func DeleteQuestion(client *db.PrismaClient, questionID, userID string) error {
+	ctx := context.Background()
+
+	question, err := client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).Exec(ctx)
+	if err != nil || question == nil {
+		return fmt.Errorf("question non trouvée")
+	}
+	if question.UserID != userID {
+		return fmt.Errorf("vous n'êtes pas autorisé à supprimer cette question")
+	}
+
+	_, err = client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la question: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAnswer supprime une réponse de son auteur
+func DeleteAnswer(client *db.PrismaClient, answerID, userID string) error {
+	ctx := context.Background()
+
+	answer, err := client.Answer.FindUnique(
+		db.Answer.ID.Equals(answerID),
+	).Exec(ctx)
+	if err != nil || answer == nil {
+		return fmt.Errorf("réponse non trouvée")
+	}
+	if answer.UserID != userID {
+		return fmt.Errorf("vous n'êtes pas autorisé à supprimer cette réponse")
+	}
+
+	_, err = client.Answer.FindUnique(
+		db.Answer.ID.Equals(answerID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la réponse: %w", err)
+	}
+
+	return nil
+}
+
+// countPublishedQuestions compte les questions publiées d'un produit ; answered, s'il est fourni,
+// ne retient que les questions ayant (true) ou n'ayant pas (false) au moins une réponse publiée
+func countPublishedQuestions(client *db.PrismaClient, productID string, answered *bool) (int, error) {
+	ctx := context.Background()
+
+	query := `SELECT COUNT(*)::int AS "count"
+		FROM "Question" q
+		WHERE q."productID" = $1 AND q."status" = 'APPROVED'`
+	if answered != nil {
+		published := `EXISTS (SELECT 1 FROM "Answer" a WHERE a."questionID" = q."id" AND a."status" = 'APPROVED')`
+		if *answered {
+			query += ` AND ` + published
+		} else {
+			query += ` AND NOT ` + published
+		}
+	}
+
+	var rows []struct {
+		Count db.RawInt `json:"count"`
+	}
+	if err := client.Prisma.QueryRaw(query, productID).Exec(ctx, &rows); err != nil {
+		return 0, fmt.Errorf("erreur lors du comptage des questions: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	return int(rows[0].Count), nil
+}
+
+// questionAutoApprove indique si les questions et les réponses des clients sont publiées sans modération
+// (QUESTION_AUTO_APPROVE, défaut: false) ; le filtre de termes interdits s'applique toujours
+func questionAutoApprove() bool {
+	v, _ := strconv.ParseBool(os.Getenv("QUESTION_AUTO_APPROVE"))
+	return v
+}
+
+// initialQuestionStatus retourne le statut d'une question ou d'une réponse d'un client
+func initialQuestionStatus() db.QuestionStatus {
+	if questionAutoApprove() {
+		return db.QuestionStatusApproved
+	}
+	return db.QuestionStatusPending
+}
+
+// validateQuestionText nettoie et valide le texte d'une question ou d'une réponse (label : "la question" ou "la réponse")
+func validateQuestionText(body, label string, maxLength int) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("%s est requise", label)
+	}
+	if utf8.RuneCountInString(body) > maxLength {
+		return "", fmt.Errorf("%s ne doit pas dépasser %d caractères", label, maxLength)
+	}
+	if containsBannedWord(body) {
+		return "", fmt.Errorf("%s contient des termes interdits", label)
+	}
+	return body, nil
+}
+
+// questionOrderParams traduit le tri demandé en tri Prisma
+func questionOrderParams(sort string) ([]db.QuestionOrderByParam, error) {
+	switch sort {
+	case "", "recent":
+		return []db.QuestionOrderByParam{db.Question.CreatedAt.Order(db.SortOrderDesc)}, nil
+	case "popular":
+		return []db.QuestionOrderByParam{
+			db.Question.UpvoteCount.Order(db.SortOrderDesc),
+			db.Question.CreatedAt.Order(db.SortOrderDesc),
+		}, nil
+	}
+	return nil, fmt.Errorf("tri invalide: %s (recent, popular)", sort)
+}
+
+// answersWith charge les réponses d'une question filtrées par where, avec leurs auteurs,
+// les réponses officielles d'abord puis les plus votées
+func answersWith(where ...db.AnswerWhereParam) db.QuestionRelationWith {
+	return db.Question.Answers.Fetch(
+		where...,
+	).With(
+		db.Answer.User.Fetch(),
+	).OrderBy(
+		db.Answer.Official.Order(db.SortOrderDesc),
+		db.Answer.UpvoteCount.Order(db.SortOrderDesc),
+		db.Answer.CreatedAt.Order(db.SortOrderAsc),
+	)
+}
+
+// convertQuestionsToDTO convertit des questions chargées avec leur auteur et leurs réponses (voir answersWith)
+func convertQuestionsToDTO(questions []db.QuestionModel) []dtos.QuestionResponse {
+	result := make([]dtos.QuestionResponse, 0, len(questions))
+	for i, question := range questions {
+		if user := question.User(); user != nil {
+			result = append(result, *convertQuestionToDTO(&questions[i], user.Email))
+		}
+	}
+	return result
+}
+
+// convertQuestionToDTO convertit une question Prisma en DTO, avec l'email masqué de son auteur
+// Les réponses ne sont renseignées que si elles ont été chargées avec leurs auteurs.
+func convertQuestionToDTO(question *db.QuestionModel, userEmail string) *dtos.QuestionResponse {
+	answers := []dtos.AnswerResponse{}
+	for i, answer := range question.RelationsQuestion.Answers {
+		if user := answer.User(); user != nil {
+			answers = append(answers, *convertAnswerToDTO(&question.RelationsQuestion.Answers[i], user.Email))
+		}
+	}
+
+	var reason *string
+	if reasonVal, ok := question.ModerationReason(); ok {
+		reasonStr := string(reasonVal)
+		reason = &reasonStr
+	}
+
+	var moderatedAt *time.Time
+	if moderatedAtVal, ok := question.ModeratedAt(); ok {
+		moderatedAtTime := time.Time(moderatedAtVal)
+		moderatedAt = &moderatedAtTime
+	}
+
+	return &dtos.QuestionResponse{
+		ID:               question.ID,
+		Body:             question.Body,
+		Status:           string(question.Status),
+		ModerationReason: reason,
+		ModeratedAt:      moderatedAt,
+		UpvoteCount:      question.UpvoteCount,
+		Answers:          answers,
+		AnswerCount:      len(answers),
+		UserID:           question.UserID,
+		UserEmail:        utils.MaskEmail(userEmail),
+		ProductID:        question.ProductID,
+		CreatedAt:        question.CreatedAt,
+		UpdatedAt:        question.UpdatedAt,
+	}
+}
+
+// convertAnswerToDTO convertit une réponse Prisma en DTO, avec l'email masqué de son auteur
+func convertAnswerToDTO(answer *db.AnswerModel, userEmail string) *dtos.AnswerResponse {
+	var reason *string
+	if reasonVal, ok := answer.ModerationReason(); ok {
+		reasonStr := string(reasonVal)
+		reason = &reasonStr
+	}
+
+	var moderatedAt *time.Time
+	if moderatedAtVal, ok := answer.ModeratedAt(); ok {
+		moderatedAtTime := time.Time(moderatedAtVal)
+		moderatedAt = &moderatedAtTime
+	}
+
+	return &dtos.AnswerResponse{
+		ID:               answer.ID,
+		Body:             answer.Body,
+		Status:           string(answer.Status),
+		ModerationReason: reason,
+		ModeratedAt:      moderatedAt,
+		Official:         answer.Official,
+		VerifiedPurchase: answer.VerifiedPurchase,
+		UpvoteCount:      answer.UpvoteCount,
+		UserID:           answer.UserID,
+		UserEmail:        utils.MaskEmail(userEmail),
+		QuestionID:       answer.QuestionID,
+		CreatedAt:        answer.CreatedAt,
+		UpdatedAt:        answer.UpdatedAt,
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"api/internal/db"
+	"api/internal/dtos"
+)
+
+// UpvoteQuestion enregistre le vote d'un utilisateur pour une question publiée
+// Un utilisateur vote au plus une fois par question, et jamais pour sa propre question.
+func UpvoteQuestion(client *db.PrismaClient, questionID, userID string) (*dtos.QuestionResponse, error) {
+	question, err := findPublishedQuestion(client, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	question.UpvoteCount, err = addVote(client, questionVotes, questionID, question.UserID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return questionResponseFor(client, question, userID)
+}
+
+// RemoveQuestionUpvote retire le vote d'un utilisateur pour une question publiée
+func RemoveQuestionUpvote(client *db.PrismaClient, questionID, userID string) (*dtos.QuestionResponse, error) {
+	question, err := findPublishedQuestion(client, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	question.UpvoteCount, err = removeVote(client, questionVotes, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return questionResponseFor(client, question, userID)
+}
+
+// UpvoteAnswer enregistre le vote d'un utilisateur pour une réponse publiée
+// Un utilisateur vote au plus une fois par réponse, et jamais pour sa propre réponse.
+func UpvoteAnswer(client *db.PrismaClient, answerID, userID string) (*dtos.AnswerResponse, error) {
+	answer, err := findPublishedAnswer(client, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	answer.UpvoteCount, err = addVote(client, answerVotes, answerID, answer.UserID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertAnswerToDTO(answer, answer.User().Email)
+	response.Upvoted = true
+	return response, nil
+}
+
+// RemoveAnswerUpvote retire le vote d'un utilisateur pour une réponse publiée
+func RemoveAnswerUpvote(client *db.PrismaClient, answerID, userID string) (*dtos.AnswerResponse, error) {
+	answer, err := findPublishedAnswer(client, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	answer.UpvoteCount, err = removeVote(client, answerVotes, answerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertAnswerToDTO(answer, answer.User().Email), nil
+}
+
+// markUpvoted renseigne Upvoted sur une liste de questions et sur leurs réponses pour l'utilisateur connecté
+func markUpvoted(client *db.PrismaClient, userID string, questions []dtos.QuestionResponse) error {
+	ctx := context.Background()
+
+	if userID == "" || len(questions) == 0 {
+		return nil
+	}
+
+	var questionIDs, answerIDs []string
+	for _, q := range questions {
+		questionIDs = append(questionIDs, q.ID)
+		for _, a := range q.Answers {
+			answerIDs = append(answerIDs, a.ID)
+		}
+	}
+
+	questionVotes, err := client.QuestionVote.FindMany(
+		db.QuestionVote.UserID.Equals(userID),
+		db.QuestionVote.QuestionID.In(questionIDs),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des votes: %w", err)
+	}
+	voted := make(map[string]bool, len(questionVotes))
+	for _, v := range questionVotes {
+		voted[v.QuestionID] = true
+	}
+
+	if len(answerIDs) > 0 {
+		answerVotes, err := client.AnswerVote.FindMany(
+			db.AnswerVote.UserID.Equals(userID),
+			db.AnswerVote.AnswerID.In(answerIDs),
+		).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("erreur lors de la récupération des votes: %w", err)
+		}
+		for _, v := range answerVotes {
+			voted[v.AnswerID] = true
+		}
+	}
+
+	for i := range questions {
+		questions[i].Upvoted = voted[questions[i].ID]
+		for j := range questions[i].Answers {
+			questions[i].Answers[j].Upvoted = voted[questions[i].Answers[j].ID]
+		}
+	}
+
+	return nil
+}
+
+// questionResponseFor convertit une question publiée (voir findPublishedQuestion) pour l'utilisateur connecté
+func questionResponseFor(client *db.PrismaClient, question *db.QuestionModel, userID string) (*dtos.QuestionResponse, error) {
+	result := []dtos.QuestionResponse{*convertQuestionToDTO(question, question.User().Email)}
+	if err := markUpvoted(client, userID, result); err != nil {
+		return nil, err
+	}
+	return &result[0], nil
+}
+
+// findPublishedQuestion récupère une question approuvée avec son auteur et ses réponses publiées ;
+// les autres questions ne sont pas visibles des clients
+func findPublishedQuestion(client *db.PrismaClient, questionID string) (*db.QuestionModel, error) {
+	ctx := context.Background()
+
+	question, err := client.Question.FindUnique(
+		db.Question.ID.Equals(questionID),
+	).With(
+		db.Question.User.Fetch(),
+		answersWith(db.Answer.Status.Equals(db.QuestionStatusApproved)),
+	).Exec(ctx)
+	if err != nil || question == nil || question.Status != db.QuestionStatusApproved {
+		return nil, fmt.Errorf("question non trouvée")
+	}
+
+	return question, nil
+}
+
+// findPublishedAnswer récupère une réponse approuvée à une question approuvée, avec son auteur
+func findPublishedAnswer(client *db.PrismaClient, answerID string) (*db.AnswerModel, error) {
+	ctx := context.Background()
+
+	answer, err := client.Answer.FindUnique(
+		db.Answer.ID.Equals(answerID),
+	).With(
+		db.Answer.User.Fetch(),
+		db.Answer.Question.Fetch(),
+	).Exec(ctx)
+	if err != nil || answer == nil || answer.Status != db.QuestionStatusApproved {
+		return nil, fmt.Errorf("réponse non trouvée")
+	}
+	if answer.Question().Status != db.QuestionStatusApproved {
+		return nil, fmt.Errorf("réponse non trouvée")
+	}
+
+	return answer, nil
+}
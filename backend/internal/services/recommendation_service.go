@@ -0,0 +1,250 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Poids des signaux utilisés pour classer les recommandations
+const (
+	recommendationSkinTypeWeight   = 3.0 // Le produit convient au type de peau du profil
+	recommendationConcernWeight    = 2.0 // Par préoccupation du profil ciblée par le produit
+	recommendationRatingWeight     = 1.0 // Multiplié par la note moyenne, atténuée quand il y a peu d'avis
+	recommendationCoPurchaseWeight = 1.5 // Par commande d'autres clients contenant aussi un produit déjà acheté
+	recommendationCoPurchaseCap    = 5   // Nombre maximal de commandes communes prises en compte
+	recommendationRatingPrior      = 3   // Nombre d'avis à partir duquel la note compte à moitié
+)
+
+// recommendationRecentDays retourne la période (en jours) pendant laquelle un produit acheté n'est plus recommandé
+// Configurable via RECOMMENDATION_RECENT_DAYS (défaut: 90)
+func recommendationRecentDays() int {
+	if v, err := strconv.Atoi(os.Getenv("RECOMMENDATION_RECENT_DAYS")); err == nil && v >= 0 {
+		return v
+	}
+	return 90
+}
+
+// GetRecommendedProducts classe les produits pour un utilisateur selon son profil de peau,
+// les notes des avis et les achats croisés des autres clients
+// Les produits achetés récemment, en rupture de stock ou contenant un ingrédient à éviter sont exclus
+func GetRecommendedProducts(client *db.PrismaClient, userID string, limit int) ([]dtos.RecommendedProductResponse, error) {
+	ctx := context.Background()
+
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	// Profil de peau (optionnel)
+	profile, err := client.SkinProfile.FindUnique(
+		db.SkinProfile.UserID.Equals(userID),
+	).Exec(ctx)
+	if err != nil && err != db.ErrNotFound {
+		return nil, fmt.Errorf("erreur lors de la récupération du profil de peau: %w", err)
+	}
+
+	// Historique d'achat de l'utilisateur
+	orders, err := client.Order.FindMany(
+		db.Order.UserID.Equals(userID),
+		db.Order.Status.Not(db.OrderStatusCancelled),
+	).With(
+		db.Order.OrderItems.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des commandes: %w", err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -recommendationRecentDays())
+	purchased := make(map[string]bool)
+	var purchasedIDs, recentIDs []string
+	for _, order := range orders {
+		for _, item := range order.OrderItems() {
+			if !purchased[item.ProductID] {
+				purchased[item.ProductID] = true
+				purchasedIDs = append(purchasedIDs, item.ProductID)
+			}
+			if order.OrderDate.After(cutoff) {
+				recentIDs = append(recentIDs, item.ProductID)
+			}
+		}
+	}
+
+	coPurchases, err := countCoPurchases(client, userID, purchasedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Produits candidats
+	filters := dtos.ProductFilters{}
+	if profile != nil {
+		filters.ExcludeIngredients = profile.AvoidedIngredients
+	}
+	where, err := productFilterParams(client, filters)
+	if err != nil {
+		return nil, err
+	}
+	where = append(where, db.Product.Stock.Gt(0))
+	if len(recentIDs) > 0 {
+		where = append(where, db.Product.ID.NotIn(recentIDs))
+	}
+
+	products, err := client.Product.FindMany(where...).With(
+		productWith()...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des produits: %w", err)
+	}
+
+	type scored struct {
+		product     *db.ProductModel
+		score       float64
+		reasons     []string
+		reviewCount int
+	}
+	candidates := make([]scored, len(products))
+
+	for i := range products {
+		product := &products[i]
+		c := scored{product: product, reasons: []string{}}
+
+		// Correspondance avec le profil de peau
+		if profile != nil {
+			if skinType, ok := profile.SkinType(); ok {
+				for _, t := range product.SkinTypes {
+					if t == skinType {
+						c.score += recommendationSkinTypeWeight
+						c.reasons = append(c.reasons, "adapté à votre type de peau")
+					}
+				}
+			}
+			for _, concern := range profile.Concerns {
+				for _, pc := range product.Concerns {
+					if pc == concern {
+						c.score += recommendationConcernWeight
+						c.reasons = append(c.reasons, "cible : "+strings.ToLower(string(pc)))
+					}
+				}
+			}
+		}
+
+		// Note moyenne des avis approuvés, atténuée quand le produit a peu d'avis
+		c.reviewCount = product.RatingCount
+		if product.RatingCount > 0 {
+			average := product.RatingAverage
+			confidence := float64(product.RatingCount) / float64(product.RatingCount+recommendationRatingPrior)
+			c.score += recommendationRatingWeight * average * confidence
+			if average >= 4 {
+				c.reasons = append(c.reasons, fmt.Sprintf("bien noté (%.1f/5)", math.Round(average*10)/10))
+			}
+		}
+
+		// Achats croisés
+		if count := coPurchases[product.ID]; count > 0 {
+			if count > recommendationCoPurchaseCap {
+				count = recommendationCoPurchaseCap
+			}
+			c.score += recommendationCoPurchaseWeight * float64(count)
+			c.reasons = append(c.reasons, "souvent acheté avec vos produits")
+		}
+
+		candidates[i] = c
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].reviewCount != candidates[j].reviewCount {
+			return candidates[i].reviewCount > candidates[j].reviewCount
+		}
+		return candidates[i].product.Name < candidates[j].product.Name
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	recommended := make([]dtos.ProductResponse, len(candidates))
+	for i, c := range candidates {
+		recommended[i] = *convertProductToDTO(c.product)
+	}
+	if err := MarkFavorites(client, userID, recommended); err != nil {
+		return nil, err
+	}
+
+	result := make([]dtos.RecommendedProductResponse, len(candidates))
+	for i, c := range candidates {
+		result[i] = dtos.RecommendedProductResponse{
+			Product: recommended[i],
+			Score:   math.Round(c.score*100) / 100,
+			Reasons: c.reasons,
+		}
+	}
+
+	return result, nil
+}
+
+// countCoPurchases compte, pour chaque produit, le nombre de commandes d'autres clients
+// qui le contiennent avec au moins un des produits déjà achetés par l'utilisateur
+func countCoPurchases(client *db.PrismaClient, userID string, purchasedIDs []string) (map[string]int, error) {
+	ctx := context.Background()
+	counts := make(map[string]int)
+
+	if len(purchasedIDs) == 0 {
+		return counts, nil
+	}
+
+	// Commandes d'autres clients contenant un produit déjà acheté
+	seedItems, err := client.OrderItem.FindMany(
+		db.OrderItem.ProductID.In(purchasedIDs),
+		db.OrderItem.Order.Where(
+			db.Order.UserID.Not(userID),
+			db.Order.Status.Not(db.OrderStatusCancelled),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'analyse des achats croisés: %w", err)
+	}
+
+	orderIDs := make([]string, 0, len(seedItems))
+	seen := make(map[string]bool)
+	for _, item := range seedItems {
+		if !seen[item.OrderID] {
+			seen[item.OrderID] = true
+			orderIDs = append(orderIDs, item.OrderID)
+		}
+	}
+	if len(orderIDs) == 0 {
+		return counts, nil
+	}
+
+	// Autres produits de ces commandes (chaque commande compte une fois par produit)
+	items, err := client.OrderItem.FindMany(
+		db.OrderItem.OrderID.In(orderIDs),
+		db.OrderItem.ProductID.NotIn(purchasedIDs),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'analyse des achats croisés: %w", err)
+	}
+
+	counted := make(map[string]bool)
+	for _, item := range items {
+		key := item.OrderID + "/" + item.ProductID
+		if !counted[key] {
+			counted[key] = true
+			counts[item.ProductID]++
+		}
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"api/internal/payments"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// returnWindowDays retourne le délai de retour après livraison (RETURN_WINDOW_DAYS, défaut: 14 jours)
+func returnWindowDays() int {
+	if v, err := strconv.Atoi(os.Getenv("RETURN_WINDOW_DAYS")); err == nil && v >= 0 {
+		return v
+	}
+	return 14
+}
+
+// CreateReturn enregistre une demande de retour pour des articles d'une commande livrée
+func CreateReturn(client *db.PrismaClient, orderID, userID string, req dtos.CreateReturnRequest) (*dtos.ReturnResponse, error) {
+	ctx := context.Background()
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("motif du retour requis")
+	}
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("au moins un article à retourner est requis")
+	}
+
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).With(
+		db.Order.OrderItems.Fetch().With(
+			db.OrderItem.ReturnItems.Fetch().With(
+				db.ReturnItem.ReturnRequest.Fetch(),
+			),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("commande non trouvée")
+	}
+	if order.UserID != userID {
+		return nil, fmt.Errorf("accès non autorisé à cette commande")
+	}
+
+	deliveredAt, delivered := order.DeliveredAt()
+	if order.Status != db.OrderStatusDelivered || !delivered {
+		return nil, fmt.Errorf("seule une commande livrée peut faire l'objet d'un retour")
+	}
+	window := returnWindowDays()
+	if time.Since(deliveredAt) > time.Duration(window)*24*time.Hour {
+		return nil, fmt.Errorf("délai de retour dépassé (%d jours après la livraison)", window)
+	}
+
+	// Quantité encore retournable par ligne : les retours refusés ne comptent pas
+	returnable := make(map[string]int)
+	for _, item := range order.OrderItems() {
+		remaining := item.Quantity
+		for _, ri := range item.ReturnItems() {
+			if ri.ReturnRequest().Status != db.ReturnStatusRejected {
+				remaining -= ri.Quantity
+			}
+		}
+		returnable[item.ID] = remaining
+	}
+
+	requested := make(map[string]int)
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("quantité invalide pour l'article %s", item.OrderItemID)
+		}
+		if _, ok := returnable[item.OrderItemID]; !ok {
+			return nil, fmt.Errorf("article de commande non trouvé: %s", item.OrderItemID)
+		}
+		requested[item.OrderItemID] += item.Quantity
+	}
+	for orderItemID, quantity := range requested {
+		if quantity > returnable[orderItemID] {
+			return nil, fmt.Errorf("quantité retournée supérieure à la quantité retournable pour l'article %s (%d)", orderItemID, returnable[orderItemID])
+		}
+	}
+
+	// La demande et ses articles sont créés dans une seule transaction : pas de demande de retour sans articles.
+	// Les quantités sont revérifiées sous verrou de la commande après l'insertion des articles (checkReturnQuantitiesTx).
+	returnID := newID()
+	txs := []db.PrismaTransaction{
+		client.ReturnRequest.CreateOne(
+			db.ReturnRequest.Reason.Set(reason),
+			db.ReturnRequest.Order.Link(db.Order.ID.Equals(order.ID)),
+			db.ReturnRequest.User.Link(db.User.ID.Equals(userID)),
+			db.ReturnRequest.ID.Set(returnID),
+		).Tx(),
+	}
+	for orderItemID, quantity := range requested {
+		txs = append(txs, client.ReturnItem.CreateOne(
+			db.ReturnItem.Quantity.Set(quantity),
+			db.ReturnItem.ReturnRequest.Link(db.ReturnRequest.ID.Equals(returnID)),
+			db.ReturnItem.OrderItem.Link(db.OrderItem.ID.Equals(orderItemID)),
+		).Tx())
+	}
+	txs = append(txs, checkReturnQuantitiesTx(client, order.ID))
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		for orderItemID := range requested {
+			if strings.Contains(err.Error(), "quantité retournée supérieure à la quantité retournable pour l'article "+orderItemID) {
+				return nil, fmt.Errorf("quantité retournée supérieure à la quantité retournable pour l'article %s", orderItemID)
+			}
+		}
+		return nil, fmt.Errorf("erreur lors de la création du retour: %w", err)
+	}
+
+	return getReturn(client, returnID)
+}
+
+// checkReturnQuantitiesTx revérifie, dans la transaction qui crée une demande de retour, qu'aucun article
+// de la commande n'est retourné au-delà de sa quantité commandée
+// La fonction SQL check_return_quantities verrouille la commande jusqu'à la fin de la transaction : deux demandes
+// simultanées ne peuvent pas retourner plus que commandé. Elle doit suivre la création des articles retournés.
+func checkReturnQuantitiesTx(client *db.PrismaClient, orderID string) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(
+		`SELECT "check_return_quantities"($1)`,
+		orderID,
+	).Tx()
+}
+
+// GetOrderReturns récupère les demandes de retour d'une commande (propriétaire ou admin)
+func GetOrderReturns(client *db.PrismaClient, orderID, userID string, isAdmin bool) ([]dtos.ReturnResponse, error) {
+	ctx := context.Background()
+
+	order, err := client.Order.FindUnique(
+		db.Order.ID.Equals(orderID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("commande non trouvée")
+	}
+	if !isAdmin && order.UserID != userID {
+		return nil, fmt.Errorf("accès non autorisé à cette commande")
+	}
+
+	returns, err := client.ReturnRequest.FindMany(
+		db.ReturnRequest.OrderID.Equals(orderID),
+	).With(
+		returnItemsFetch(),
+	).OrderBy(
+		db.ReturnRequest.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des retours: %w", err)
+	}
+
+	result := make([]dtos.ReturnResponse, len(returns))
+	for i := range returns {
+		result[i] = *convertReturnToDTO(&returns[i])
+	}
+
+	return result, nil
+}
+
+// GetAllReturns récupère toutes les demandes de retour, filtrables par statut (admin only)
+func GetAllReturns(client *db.PrismaClient, status string) ([]dtos.ReturnResponse, error) {
+	ctx := context.Background()
+
+	var filters []db.ReturnRequestWhereParam
+	if status != "" {
+		returnStatus, err := parseReturnStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, db.ReturnRequest.Status.Equals(returnStatus))
+	}
+
+	returns, err := client.ReturnRequest.FindMany(
+		filters...,
+	).With(
+		returnItemsFetch(),
+	).OrderBy(
+		db.ReturnRequest.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des retours: %w", err)
+	}
+
+	result := make([]dtos.ReturnResponse, len(returns))
+	for i := range returns {
+		result[i] = *convertReturnToDTO(&returns[i])
+	}
+
+	return result, nil
+}
+
+// GetReturnByID récupère une demande de retour (admin only)
+func GetReturnByID(client *db.PrismaClient, returnID string) (*dtos.ReturnResponse, error) {
+	return getReturn(client, returnID)
+}
+
+// ApproveReturn accepte une demande de retour en attente (admin only)
+func ApproveReturn(client *db.PrismaClient, returnID string, req dtos.ReviewReturnRequest) (*dtos.ReturnResponse, error) {
+	return reviewReturn(client, returnID, db.ReturnStatusApproved, req.Note)
+}
+
+// RejectReturn refuse une demande de retour en attente (admin only)
+// Les articles redeviennent retournables dans le délai de retour
+func RejectReturn(client *db.PrismaClient, returnID string, req dtos.ReviewReturnRequest) (*dtos.ReturnResponse, error) {
+	return reviewReturn(client, returnID, db.ReturnStatusRejected, req.Note)
+}
+
+// ReceiveReturn enregistre la réception des articles d'un retour approuvé (admin only) :
+// les articles sont remis en stock et le remboursement est émis sur le paiement de la commande,
+// dans la limite du montant payé pour les articles retournés
+func ReceiveReturn(client *db.PrismaClient, registry *payments.Registry, returnID string, req dtos.ReceiveReturnRequest) (*dtos.ReturnResponse, error) {
+	ctx := context.Background()
+
+	returnRequest, err := client.ReturnRequest.FindUnique(
+		db.ReturnRequest.ID.Equals(returnID),
+	).With(
+		returnItemsFetch(),
+		db.ReturnRequest.Order.Fetch().With(
+			db.Order.Payments.Fetch(),
+		),
+	).Exec(ctx)
+	if err != nil || returnRequest == nil {
+		return nil, fmt.Errorf("retour non trouvé")
+	}
+	if returnRequest.Status != db.ReturnStatusApproved {
+		return nil, fmt.Errorf("seul un retour approuvé peut être réceptionné")
+	}
+
+	refundable := returnRefundableAmount(returnRequest)
+	amount := refundable
+	if req.RefundAmount != nil {
+		amount = roundAmount(*req.RefundAmount)
+	}
+	if amount < 0 || amount > refundable {
+		return nil, fmt.Errorf("le montant du remboursement doit être compris entre 0 et %.2f", refundable)
+	}
+
+	// Réserver la réception avant tout appel au prestataire : si deux réceptions sont simultanées,
+	// une seule fait passer le retour de APPROVED à RECEIVED, l'autre est refusée
+	claimed, err := client.ReturnRequest.FindMany(
+		db.ReturnRequest.ID.Equals(returnRequest.ID),
+		db.ReturnRequest.Status.Equals(db.ReturnStatusApproved),
+	).Update(
+		db.ReturnRequest.Status.Set(db.ReturnStatusReceived),
+		db.ReturnRequest.ReceivedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la réception du retour: %w", err)
+	}
+	if claimed.Count == 0 {
+		return nil, fmt.Errorf("seul un retour approuvé peut être réceptionné")
+	}
+
+	var txs []db.PrismaTransaction
+	providerRefunded := false
+
+	// Remboursement sur le paiement capturé disposant du plus grand solde, sinon remboursement manuel
+	if amount > 0 {
+		var payment *db.PaymentModel
+		for i, p := range returnRequest.Order().Payments() {
+			if p.Status != db.PaymentStatusSucceeded && p.Status != db.PaymentStatusPartiallyRefunded {
+				continue
+			}
+			if payment == nil || p.Amount-p.AmountRefunded > payment.Amount-payment.AmountRefunded {
+				payment = &returnRequest.Order().Payments()[i]
+			}
+		}
+
+		refundTxs, err := issueRefund(ctx, client, registry, returnRequest.OrderID, payment, amount, returnRequest.ID)
+		if err != nil {
+			releaseReturnReception(client, returnRequest.ID)
+			return nil, err
+		}
+		txs = append(txs, refundTxs...)
+		providerRefunded = payment != nil
+	}
+
+	// Remise en stock : variante et produit (somme des variantes), comme à la commande
+	for _, ri := range returnRequest.Items() {
+		item := ri.OrderItem()
+		variantID, ok := item.VariantID()
+		if !ok {
+			// Variante supprimée depuis la commande : rien à remettre en stock
+			continue
+		}
+		txs = append(txs,
+			client.ProductVariant.FindUnique(
+				db.ProductVariant.ID.Equals(string(variantID)),
+			).Update(
+				db.ProductVariant.Stock.Increment(ri.Quantity),
+			).Tx(),
+			client.Product.FindUnique(
+				db.Product.ID.Equals(item.ProductID),
+			).Update(
+				db.Product.Stock.Increment(ri.Quantity),
+			).Tx(),
+			stockChangedTx(client, item.ProductID, string(variantID), ri.Quantity, events.StockReasonReturn),
+		)
+	}
+
+	params := []db.ReturnRequestSetParam{
+		db.ReturnRequest.RefundAmount.Set(amount),
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		params = append(params, db.ReturnRequest.AdminNote.Set(note))
+	}
+	txs = append(txs, client.ReturnRequest.FindUnique(
+		db.ReturnRequest.ID.Equals(returnRequest.ID),
+	).Update(params...).Tx())
+
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		// Après un remboursement chez le prestataire, le retour reste réceptionné et le montant reste réservé
+		// sur le paiement pour ne pas rembourser deux fois
+		if providerRefunded {
+			log.Printf("retour %s: remboursement de %.2f émis mais réception non enregistrée: %v", returnRequest.ID, amount, err)
+		} else {
+			releaseReturnReception(client, returnRequest.ID)
+		}
+		return nil, fmt.Errorf("erreur lors de la réception du retour: %w", err)
+	}
+
+	return getReturn(client, returnRequest.ID)
+}
+
+// releaseReturnReception remet en APPROVED un retour réservé par ReceiveReturn dont la réception a échoué
+func releaseReturnReception(client *db.PrismaClient, returnID string) {
+	ctx := context.Background()
+
+	_, err := client.ReturnRequest.FindMany(
+		db.ReturnRequest.ID.Equals(returnID),
+		db.ReturnRequest.Status.Equals(db.ReturnStatusReceived),
+	).Update(
+		db.ReturnRequest.Status.Set(db.ReturnStatusApproved),
+		db.ReturnRequest.ReceivedAt.SetOptional(nil),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("retour %s: impossible d'annuler la réservation de la réception: %v", returnID, err)
+	}
+}
+
+// reviewReturn applique la décision de l'administrateur sur une demande en attente
+func reviewReturn(client *db.PrismaClient, returnID string, status db.ReturnStatus, note string) (*dtos.ReturnResponse, error) {
+	ctx := context.Background()
+
+	returnRequest, err := client.ReturnRequest.FindUnique(
+		db.ReturnRequest.ID.Equals(returnID),
+	).Exec(ctx)
+	if err != nil || returnRequest == nil {
+		return nil, fmt.Errorf("retour non trouvé")
+	}
+	if returnRequest.Status != db.ReturnStatusRequested {
+		return nil, fmt.Errorf("seul un retour en attente peut être approuvé ou refusé")
+	}
+
+	params := []db.ReturnRequestSetParam{db.ReturnRequest.Status.Set(status)}
+	if note = strings.TrimSpace(note); note != "" {
+		params = append(params, db.ReturnRequest.AdminNote.Set(note))
+	}
+
+	_, err = client.ReturnRequest.FindUnique(
+		db.ReturnRequest.ID.Equals(returnID),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour du retour: %w", err)
+	}
+
+	return getReturn(client, returnID)
+}
+
+// getReturn récupère une demande de retour avec ses articles
+func getReturn(client *db.PrismaClient, returnID string) (*dtos.ReturnResponse, error) {
+	ctx := context.Background()
+
+	returnRequest, err := client.ReturnRequest.FindUnique(
+		db.ReturnRequest.ID.Equals(returnID),
+	).With(
+		returnItemsFetch(),
+	).Exec(ctx)
+	if err != nil || returnRequest == nil {
+		return nil, fmt.Errorf("retour non trouvé")
+	}
+
+	return convertReturnToDTO(returnRequest), nil
+}
+
+// returnItemsFetch charge les articles d'un retour avec leur ligne de commande et leur produit
+func returnItemsFetch() db.ReturnRequestRelationWith {
+	return db.ReturnRequest.Items.Fetch().With(
+		db.ReturnItem.OrderItem.Fetch().With(
+			db.OrderItem.Product.Fetch(),
+		),
+	)
+}
+
+// parseReturnStatus valide un statut de retour fourni par l'API
+func parseReturnStatus(status string) (db.ReturnStatus, error) {
+	switch s := db.ReturnStatus(strings.ToUpper(status)); s {
+	case db.ReturnStatusRequested, db.ReturnStatusApproved, db.ReturnStatusRejected, db.ReturnStatusReceived:
+		return s, nil
+	}
+	return "", fmt.Errorf("statut de retour invalide: %s", status)
+}
+
+// returnItemAmount retourne le montant TTC payé pour une quantité d'une ligne de commande (remise déduite)
+func returnItemAmount(item *db.OrderItemModel, quantity int) float64 {
+	if item.Quantity == 0 {
+		return 0
+	}
+	return roundAmount(item.GrossAmount * float64(quantity) / float64(item.Quantity))
+}
+
+// returnRefundableAmount retourne le montant payé pour l'ensemble des articles d'un retour
+func returnRefundableAmount(returnRequest *db.ReturnRequestModel) float64 {
+	total := 0.0
+	for _, ri := range returnRequest.Items() {
+		total += returnItemAmount(ri.OrderItem(), ri.Quantity)
+	}
+	return roundAmount(total)
+}
+
+// convertReturnToDTO convertit un ReturnRequestModel (chargé avec ses articles) en ReturnResponse
+func convertReturnToDTO(returnRequest *db.ReturnRequestModel) *dtos.ReturnResponse {
+	items := make([]dtos.ReturnItemResponse, len(returnRequest.Items()))
+	for i, ri := range returnRequest.Items() {
+		item := ri.OrderItem()
+		variantLabel, _ := item.VariantLabel()
+		items[i] = dtos.ReturnItemResponse{
+			ID:           ri.ID,
+			OrderItemID:  item.ID,
+			ProductID:    item.ProductID,
+			ProductName:  item.Product().Name,
+			VariantLabel: string(variantLabel),
+			Quantity:     ri.Quantity,
+			Amount:       returnItemAmount(item, ri.Quantity),
+		}
+	}
+
+	adminNote, _ := returnRequest.AdminNote()
+	var receivedAt *time.Time
+	if v, ok := returnRequest.ReceivedAt(); ok {
+		receivedAt = &v
+	}
+
+	return &dtos.ReturnResponse{
+		ID:               returnRequest.ID,
+		OrderID:          returnRequest.OrderID,
+		UserID:           returnRequest.UserID,
+		Status:           string(returnRequest.Status),
+		Reason:           returnRequest.Reason,
+		AdminNote:        string(adminNote),
+		Items:            items,
+		RefundableAmount: returnRefundableAmount(returnRequest),
+		RefundAmount:     returnRequest.RefundAmount,
+		ReceivedAt:       receivedAt,
+		CreatedAt:        returnRequest.CreatedAt,
+		UpdatedAt:        returnRequest.UpdatedAt,
+	}
+}
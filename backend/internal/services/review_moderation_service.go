@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"api/internal/db"
+	"api/internal/dtos"
+)
+
+// reviewAutoApproveMinRating retourne la note à partir de laquelle un avis est publié sans modération
+// (REVIEW_AUTO_APPROVE_MIN_RATING, de 1 à 5) ; 0 si tous les avis passent par la modération (défaut)
+func reviewAutoApproveMinRating() int {
+	if v, err := strconv.Atoi(os.Getenv("REVIEW_AUTO_APPROVE_MIN_RATING")); err == nil && v >= 1 && v <= 5 {
+		return v
+	}
+	return 0
+}
+
+// initialReviewStatus retourne le statut d'un avis créé ou modifié par son auteur
+func initialReviewStatus(rating int) db.ReviewStatus {
+	if minRating := reviewAutoApproveMinRating(); minRating > 0 && rating >= minRating {
+		return db.ReviewStatusApproved
+	}
+	return db.ReviewStatusPending
+}
+
+// resetModerationParams remet un avis modifié par son auteur dans l'état d'un nouvel avis
+func resetModerationParams(status db.ReviewStatus) []db.ReviewSetParam {
+	return []db.ReviewSetParam{
+		db.Review.Status.Set(status),
+		db.Review.ModerationReason.SetOptional(nil),
+		db.Review.ModeratedAt.SetOptional(nil),
+	}
+}
+
+// normalizeReviewText met un texte en minuscules et réduit la ponctuation à des espaces simples,
+// encadré d'espaces pour la recherche de mots entiers
+func normalizeReviewText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return " " + strings.Join(words, " ") + " "
+}
+
+// containsBannedWord indique si le texte contient un terme de REVIEW_BANNED_WORDS (liste séparée par des virgules).
+// La comparaison porte sur des mots entiers, sans tenir compte de la casse ni de la ponctuation ; un terme peut contenir plusieurs mots.
+func containsBannedWord(text string) bool {
+	normalized := normalizeReviewText(text)
+	for _, word := range strings.Split(os.Getenv("REVIEW_BANNED_WORDS"), ",") {
+		term := normalizeReviewText(word)
+		if term != "  " && strings.Contains(normalized, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetReviewsForModeration liste les avis, des plus récents aux plus anciens, filtrables par statut et par produit (admin only)
+func GetReviewsForModeration(client *db.PrismaClient, status, productID string, page, limit int) (*dtos.PaginatedReviewsResponse, error) {
+	ctx := context.Background()
+
+	page, limit = moderationPage(page, limit)
+
+	var filters []db.ReviewWhereParam
+	if status != "" {
+		reviewStatus, err := parseReviewStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, db.Review.Status.Equals(reviewStatus))
+	}
+	if productID != "" {
+		filters = append(filters, db.Review.ProductID.Equals(productID))
+	}
+
+	total, err := countModerationQueue(client, "Review", status, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := client.Review.FindMany(
+		filters...,
+	).With(
+		db.Review.User.Fetch(),
+		db.Review.Reply.Fetch(),
+	).OrderBy(
+		db.Review.CreatedAt.Order(db.SortOrderDesc),
+	).Take(limit).Skip((page - 1) * limit).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des avis: %w", err)
+	}
+
+	result := make([]dtos.ReviewResponse, 0, len(reviews))
+	for i, review := range reviews {
+		if user := review.User(); user != nil {
+			result = append(result, *convertReviewToDTO(&reviews[i], user.Email))
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return &dtos.PaginatedReviewsResponse{
+		Reviews:    result,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// ApproveReview publie un avis en attente ou précédemment refusé (admin only)
+func ApproveReview(client *db.PrismaClient, reviewID string) (*dtos.ReviewResponse, error) {
+	return moderateReview(client, reviewID, db.ReviewStatusApproved, nil)
+}
+
+// RejectReview refuse un avis ; il n'est plus visible que par son auteur, avec le motif (admin only)
+func RejectReview(client *db.PrismaClient, reviewID string, req dtos.ModerateReviewRequest) (*dtos.ReviewResponse, error) {
+	reason, err := requireModerationReason(req.Reason, "du refus")
+	if err != nil {
+		return nil, err
+	}
+	return moderateReview(client, reviewID, db.ReviewStatusRejected, &reason)
+}
+
+// DeleteReviewAsAdmin supprime définitivement un avis (admin only)
+// Le motif et le commentaire supprimé sont enregistrés dans le journal de modération, dans la même transaction.
+func DeleteReviewAsAdmin(client *db.PrismaClient, reviewID, adminID string, req dtos.ModerateReviewRequest) error {
+	ctx := context.Background()
+
+	reason, err := requireModerationReason(req.Reason, "de la suppression")
+	if err != nil {
+		return err
+	}
+
+	review, err := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).Exec(ctx)
+	if err != nil || review == nil {
+		return fmt.Errorf("avis non trouvé")
+	}
+
+	comment, _ := review.Comment()
+	txs := withProductRatingSync(client, review.ProductID,
+		client.Review.FindUnique(
+			db.Review.ID.Equals(reviewID),
+		).Delete().Tx(),
+		moderationLogTx(client, moderationLog{
+			EntityType: moderationEntityReview,
+			EntityID:   review.ID,
+			ProductID:  review.ProductID,
+			AuthorID:   review.UserID,
+			AdminID:    adminID,
+			Reason:     reason,
+			Content:    string(comment),
+		}),
+	)
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de la suppression de l'avis: %w", err)
+	}
+
+	return nil
+}
+
+// moderateReview applique la décision de l'administrateur sur un avis
+func moderateReview(client *db.PrismaClient, reviewID string, status db.ReviewStatus, reason *string) (*dtos.ReviewResponse, error) {
+	ctx := context.Background()
+
+	existing, err := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).Exec(ctx)
+	if err != nil || existing == nil {
+		return nil, fmt.Errorf("avis non trouvé")
+	}
+
+	// Décision et notes du produit dans la même transaction
+	updateTx := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).Update(
+		db.Review.Status.Set(status),
+		db.Review.ModerationReason.SetOptional(reason),
+		db.Review.ModeratedAt.Set(time.Now()),
+	).Tx()
+	if err := client.Prisma.Transaction(withProductRatingSync(client, existing.ProductID, updateTx)...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("erreur lors de la modération de l'avis: %w", err)
+	}
+	review := updateTx.Result()
+
+	user, err := client.User.FindUnique(
+		db.User.ID.Equals(review.UserID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
+	}
+
+	return convertReviewToDTO(review, user.Email), nil
+}
+
+// parseReviewStatus valide un statut de modération fourni par l'API
+func parseReviewStatus(status string) (db.ReviewStatus, error) {
+	switch s := db.ReviewStatus(strings.ToUpper(status)); s {
+	case db.ReviewStatusPending, db.ReviewStatusApproved, db.ReviewStatusRejected:
+		return s, nil
+	}
+	return "", fmt.Errorf("statut d'avis invalide: %s", status)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"api/internal/db"
+	"api/internal/dtos"
+)
+
+// productRatingsSQL recalcule les agrégats de notes (avis approuvés) d'un produit, ou de tous les produits si $1 est vide.
+// Seuls les produits dont les agrégats changent sont mis à jour.
+const productRatingsSQL = `UPDATE "Product" p SET
+		"ratingCount" = s."count",
+		"ratingAverage" = s."average",
+		"rating1Count" = s."r1",
+		"rating2Count" = s."r2",
+		"rating3Count" = s."r3",
+		"rating4Count" = s."r4",
+		"rating5Count" = s."r5"
+	FROM (
+		SELECT pr."id",
+			COUNT(r."id")::int AS "count",
+			COALESCE(AVG(r."rating"), 0)::float8 AS "average",
+			COUNT(r."id") FILTER (WHERE r."rating" = 1)::int AS "r1",
+			COUNT(r."id") FILTER (WHERE r."rating" = 2)::int AS "r2",
+			COUNT(r."id") FILTER (WHERE r."rating" = 3)::int AS "r3",
+			COUNT(r."id") FILTER (WHERE r."rating" = 4)::int AS "r4",
+			COUNT(r."id") FILTER (WHERE r."rating" = 5)::int AS "r5"
+		FROM "Product" pr
+		LEFT JOIN "Review" r ON r."productID" = pr."id" AND r."status" = 'APPROVED'
+		WHERE $1 = '' OR pr."id" = $1
+		GROUP BY pr."id"
+	) s
+	WHERE p."id" = s."id"
+		AND (p."ratingCount", p."ratingAverage", p."rating1Count", p."rating2Count", p."rating3Count", p."rating4Count", p."rating5Count")
+			IS DISTINCT FROM (s."count", s."average", s."r1", s."r2", s."r3", s."r4", s."r5")`
+
+// withProductRatingSync encadre les écritures d'avis d'un produit pour maintenir ses agrégats de notes :
+// le produit est verrouillé avant les écritures, afin que deux transactions concurrentes ne recalculent pas
+// chacune sans voir l'avis de l'autre, puis ses agrégats sont recalculés après.
+func withProductRatingSync(client *db.PrismaClient, productID string, txs ...db.PrismaTransaction) []db.PrismaTransaction {
+	result := make([]db.PrismaTransaction, 0, len(txs)+2)
+	result = append(result, lockProductTx(client, productID))
+	result = append(result, txs...)
+	return append(result, syncProductRatingsTx(client, productID))
+}
+
+// lockProductTx verrouille la ligne d'un produit jusqu'à la fin de la transaction
+func lockProductTx(client *db.PrismaClient, productID string) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(
+		`SELECT 1 FROM "Product" WHERE "id" = $1 FOR UPDATE`,
+		productID,
+	).Tx()
+}
+
+// syncProductRatingsTx recalcule les agrégats de notes d'un produit à partir de ses avis approuvés
+func syncProductRatingsTx(client *db.PrismaClient, productID string) db.PrismaTransaction {
+	return client.Prisma.ExecuteRaw(productRatingsSQL, productID).Tx()
+}
+
+// RecomputeProductRatings recalcule les agrégats de notes de tous les produits à partir des avis approuvés (admin only)
+// Retourne le nombre de produits dont les agrégats étaient faux.
+func RecomputeProductRatings(client *db.PrismaClient) (*dtos.RecomputeRatingsResponse, error) {
+	ctx := context.Background()
+
+	result, err := client.Prisma.ExecuteRaw(productRatingsSQL, "").Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du recalcul des notes: %w", err)
+	}
+
+	return &dtos.RecomputeRatingsResponse{Corrected: result.Count}, nil
+}
+
+// productRatingToDTO retourne la note moyenne arrondie à 1 décimale et l'histogramme des notes d'un produit
+func productRatingToDTO(product *db.ProductModel) (float64, dtos.RatingHistogram) {
+	return math.Round(product.RatingAverage*10) / 10, dtos.RatingHistogram{
+		One:   product.Rating1Count,
+		Two:   product.Rating2Count,
+		Three: product.Rating3Count,
+		Four:  product.Rating4Count,
+		Five:  product.Rating5Count,
+	}
+}
+
+// histogramCounts retourne les nombres d'avis de l'histogramme, indexés par note - 1
+func histogramCounts(histogram dtos.RatingHistogram) [5]int {
+	return [5]int{histogram.One, histogram.Two, histogram.Three, histogram.Four, histogram.Five}
+}
+
+// verifiedRatingStats calcule la note moyenne (arrondie à 1 décimale), le nombre et l'histogramme
+// des avis approuvés d'achats vérifiés d'un produit
+func verifiedRatingStats(client *db.PrismaClient, productID string) (float64, int, dtos.RatingHistogram, error) {
+	ctx := context.Background()
+
+	var rows []struct {
+		Count   db.RawInt   `json:"count"`
+		Average db.RawFloat `json:"average"`
+		R1      db.RawInt   `json:"r1"`
+		R2      db.RawInt   `json:"r2"`
+		R3      db.RawInt   `json:"r3"`
+		R4      db.RawInt   `json:"r4"`
+		R5      db.RawInt   `json:"r5"`
+	}
+	err := client.Prisma.QueryRaw(
+		`SELECT
+			COUNT(*)::int AS "count",
+			COALESCE(AVG("rating"), 0)::float8 AS "average",
+			COUNT(*) FILTER (WHERE "rating" = 1)::int AS "r1",
+			COUNT(*) FILTER (WHERE "rating" = 2)::int AS "r2",
+			COUNT(*) FILTER (WHERE "rating" = 3)::int AS "r3",
+			COUNT(*) FILTER (WHERE "rating" = 4)::int AS "r4",
+			COUNT(*) FILTER (WHERE "rating" = 5)::int AS "r5"
+		FROM "Review"
+		WHERE "productID" = $1 AND "status" = 'APPROVED' AND "verifiedPurchase" = true`,
+		productID,
+	).Exec(ctx, &rows)
+	if err != nil {
+		return 0, 0, dtos.RatingHistogram{}, fmt.Errorf("erreur lors du calcul des statistiques des avis: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, 0, dtos.RatingHistogram{}, nil
+	}
+
+	row := rows[0]
+	return math.Round(float64(row.Average)*10) / 10, int(row.Count), dtos.RatingHistogram{
+		One:   int(row.R1),
+		Two:   int(row.R2),
+		Three: int(row.R3),
+		Four:  int(row.R4),
+		Five:  int(row.R5),
+	}, nil
+}
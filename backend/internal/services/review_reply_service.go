@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+)
+
+// maxReviewReplyLength est la longueur maximale d'une réponse à un avis, en caractères
+const maxReviewReplyLength = 2000
+
+// CreateReviewReply publie la réponse de la marque à un avis publié (admin only)
+// Un avis n'a qu'une réponse ; son auteur est notifié via l'événement ReviewReplied.
+// Un avis en attente ou refusé ne peut pas recevoir de réponse : elle serait notifiée sans être visible.
+func CreateReviewReply(client *db.PrismaClient, reviewID, adminID string, req dtos.ReviewReplyRequest) (*dtos.ReviewResponse, error) {
+	ctx := context.Background()
+
+	body, err := validateReviewReplyBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := findReviewWithReply(client, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != db.ReviewStatusApproved {
+		return nil, fmt.Errorf("seul un avis publié peut recevoir une réponse")
+	}
+	if _, ok := review.Reply(); ok {
+		return nil, fmt.Errorf("une réponse existe déjà pour cet avis")
+	}
+
+	// Réponse et événement ReviewReplied dans la même transaction
+	replyID := newID()
+	createTx := client.ReviewReply.CreateOne(
+		db.ReviewReply.Body.Set(body),
+		db.ReviewReply.Review.Link(db.Review.ID.Equals(reviewID)),
+		db.ReviewReply.Author.Link(db.User.ID.Equals(adminID)),
+		db.ReviewReply.ID.Set(replyID),
+	).Tx()
+	eventTx := outboxEventTx(client, events.ReviewReplied, reviewID, events.ReviewRepliedPayload{
+		ReviewID:  reviewID,
+		ReplyID:   replyID,
+		ProductID: review.ProductID,
+		UserID:    review.UserID,
+	})
+	if err := client.Prisma.Transaction(createTx, eventTx).Exec(ctx); err != nil {
+		// Deux réponses simultanées : la seconde est refusée par la contrainte d'unicité
+		if again, _ := findReviewWithReply(client, reviewID); again != nil {
+			if _, ok := again.Reply(); ok {
+				return nil, fmt.Errorf("une réponse existe déjà pour cet avis")
+			}
+		}
+		return nil, fmt.Errorf("erreur lors de la création de la réponse: %w", err)
+	}
+
+	response := convertReviewToDTO(review, review.User().Email)
+	response.Reply = convertReviewReplyToDTO(createTx.Result())
+	return response, nil
+}
+
+// UpdateReviewReply corrige la réponse de la marque à un avis (admin only)
+// La correction n'est pas notifiée à l'auteur de l'avis.
+func UpdateReviewReply(client *db.PrismaClient, reviewID, adminID string, req dtos.ReviewReplyRequest) (*dtos.ReviewResponse, error) {
+	ctx := context.Background()
+
+	body, err := validateReviewReplyBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := findReviewWithReply(client, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := review.Reply(); !ok {
+		return nil, fmt.Errorf("réponse non trouvée")
+	}
+
+	reply, err := client.ReviewReply.FindUnique(
+		db.ReviewReply.ReviewID.Equals(reviewID),
+	).Update(
+		db.ReviewReply.Body.Set(body),
+		db.ReviewReply.Author.Link(db.User.ID.Equals(adminID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour de la réponse: %w", err)
+	}
+
+	response := convertReviewToDTO(review, review.User().Email)
+	response.Reply = convertReviewReplyToDTO(reply)
+	return response, nil
+}
+
+// DeleteReviewReply retire la réponse de la marque à un avis (admin only)
+func DeleteReviewReply(client *db.PrismaClient, reviewID string) error {
+	ctx := context.Background()
+
+	deleted, err := client.ReviewReply.FindMany(
+		db.ReviewReply.ReviewID.Equals(reviewID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la réponse: %w", err)
+	}
+	if deleted.Count == 0 {
+		return fmt.Errorf("réponse non trouvée")
+	}
+
+	return nil
+}
+
+// validateReviewReplyBody nettoie et valide le texte d'une réponse
+func validateReviewReplyBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("la réponse est requise")
+	}
+	if utf8.RuneCountInString(body) > maxReviewReplyLength {
+		return "", fmt.Errorf("la réponse ne doit pas dépasser %d caractères", maxReviewReplyLength)
+	}
+	return body, nil
+}
+
+// findReviewWithReply récupère un avis, quel que soit son statut, avec son auteur et sa réponse éventuelle
+func findReviewWithReply(client *db.PrismaClient, reviewID string) (*db.ReviewModel, error) {
+	ctx := context.Background()
+
+	review, err := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).With(
+		db.Review.User.Fetch(),
+		db.Review.Reply.Fetch(),
+	).Exec(ctx)
+	if err != nil || review == nil {
+		return nil, fmt.Errorf("avis non trouvé")
+	}
+
+	return review, nil
+}
+
+// reviewReplyToDTO retourne la réponse d'un avis chargée avec db.Review.Reply.Fetch(), ou nil
+func reviewReplyToDTO(review *db.ReviewModel) *dtos.ReviewReplyResponse {
+	if reply, ok := review.Reply(); ok && reply != nil {
+		return convertReviewReplyToDTO(reply)
+	}
+	return nil
+}
+
+// convertReviewReplyToDTO convertit une réponse Prisma en DTO ; l'administrateur auteur n'est pas exposé
+func convertReviewReplyToDTO(reply *db.ReviewReplyModel) *dtos.ReviewReplyResponse {
+	return &dtos.ReviewReplyResponse{
+		ID:        reply.ID,
+		Body:      reply.Body,
+		CreatedAt: reply.CreatedAt,
+		UpdatedAt: reply.UpdatedAt,
+	}
+}
@@ -3,13 +3,19 @@ package services
 import (
 	"api/internal/db"
 	"api/internal/dtos"
+	"api/internal/events"
 	"api/internal/utils"
 	"context"
 	"fmt"
 	"math"
+	"os"
+	"strconv"
+	"time"
 )
 
 // CreateReview crée un nouvel avis pour un produit
+// L'avis est en attente de modération, sauf approbation automatique (REVIEW_AUTO_APPROVE_MIN_RATING).
+// Il est marqué comme achat vérifié si l'utilisateur a reçu le produit ; avec REVIEW_REQUIRE_PURCHASE=true, il est refusé sinon.
 func CreateReview(client *db.PrismaClient, userID string, req dtos.CreateReviewRequest) (*dtos.ReviewResponse, error) {
 	ctx := context.Background()
 
@@ -24,6 +30,28 @@ func CreateReview(client *db.PrismaClient, userID string, req dtos.CreateReviewR
 		return nil, fmt.Errorf("produit non trouvé")
 	}
 
+	// Filtre des termes interdits
+	if req.Comment != nil && containsBannedWord(*req.Comment) {
+		return nil, fmt.Errorf("le commentaire contient des termes interdits")
+	}
+	status := initialReviewStatus(req.Rating)
+
+	verified, err := hasReceivedProduct(client, userID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if !verified && reviewRequiresPurchase() {
+		return nil, fmt.Errorf("vous n'êtes pas autorisé à donner votre avis sur un produit que vous n'avez pas reçu")
+	}
+
+	// Récupérer l'utilisateur pour l'email
+	user, err := client.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
+	}
+
 	// Vérifier si l'utilisateur a déjà laissé un avis pour ce produit
 	// Utiliser FindFirst avec des conditions au lieu de FindUnique avec composite key
 	existingReview, err := client.Review.FindFirst(
@@ -31,7 +59,7 @@ func CreateReview(client *db.PrismaClient, userID string, req dtos.CreateReviewR
 		db.Review.ProductID.Equals(req.ProductID),
 	).Exec(ctx)
 
-	// Si l'avis existe déjà, on le met à jour au lieu de créer un nouveau
+	// Si l'avis existe déjà, on le met à jour au lieu de créer un nouveau (il repasse en modération)
 	if err == nil && existingReview != nil {
 		// Mettre à jour l'avis existant
 		updateParams := []db.ReviewSetParam{
@@ -42,38 +70,17 @@ func CreateReview(client *db.PrismaClient, userID string, req dtos.CreateReviewR
 		} else {
 			updateParams = append(updateParams, db.Review.Comment.Set(""))
 		}
+		updateParams = append(updateParams, db.Review.VerifiedPurchase.Set(verified))
+		updateParams = append(updateParams, resetModerationParams(status)...)
 
-		review, err := client.Review.FindUnique(
+		updateTx := client.Review.FindUnique(
 			db.Review.ID.Equals(existingReview.ID),
-		).Update(updateParams...).Exec(ctx)
-		if err != nil {
+		).Update(updateParams...).Tx()
+		if err := client.Prisma.Transaction(withProductRatingSync(client, req.ProductID, updateTx)...).Exec(ctx); err != nil {
 			return nil, fmt.Errorf("erreur lors de la mise à jour de l'avis: %w", err)
 		}
 
-		// Récupérer l'utilisateur pour l'email
-		user, err := client.User.FindUnique(
-			db.User.ID.Equals(userID),
-		).Exec(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
-		}
-
-		var comment *string
-		if commentVal, ok := review.Comment(); ok && commentVal != "" {
-			commentStr := string(commentVal)
-			comment = &commentStr
-		}
-
-		return &dtos.ReviewResponse{
-			ID:        review.ID,
-			Rating:    review.Rating,
-			Comment:   comment,
-			UserID:    review.UserID,
-			UserEmail: utils.MaskEmail(user.Email),
-			ProductID: review.ProductID,
-			CreatedAt: review.CreatedAt,
-			UpdatedAt: review.UpdatedAt,
-		}, nil
+		return convertReviewToDTO(updateTx.Result(), user.Email), nil
 	}
 
 	// Créer un nouvel avis
@@ -85,111 +92,174 @@ func CreateReview(client *db.PrismaClient, userID string, req dtos.CreateReviewR
 		commentParam = db.Review.Comment.Set("")
 	}
 
-	// Créer l'avis avec les paramètres dans l'ordre requis
-	review, err := client.Review.CreateOne(
+	// Créer l'avis avec les paramètres dans l'ordre requis, avec l'événement ReviewCreated et les notes du produit
+	reviewID := newID()
+	createTx := client.Review.CreateOne(
 		db.Review.Rating.Set(req.Rating),
 		db.Review.User.Link(db.User.ID.Equals(userID)),
 		db.Review.Product.Link(db.Product.ID.Equals(req.ProductID)),
 		commentParam,
-	).Exec(ctx)
-	if err != nil {
+		db.Review.Status.Set(status),
+		db.Review.VerifiedPurchase.Set(verified),
+		db.Review.ID.Set(reviewID),
+	).Tx()
+	eventTx := outboxEventTx(client, events.ReviewCreated, reviewID, events.ReviewCreatedPayload{
+		ReviewID:  reviewID,
+		ProductID: req.ProductID,
+		UserID:    userID,
+		Rating:    req.Rating,
+	})
+	if err := client.Prisma.Transaction(withProductRatingSync(client, req.ProductID, createTx, eventTx)...).Exec(ctx); err != nil {
 		return nil, fmt.Errorf("erreur lors de la création de l'avis: %w", err)
 	}
 
-	// Récupérer l'utilisateur pour l'email
-	user, err := client.User.FindUnique(
-		db.User.ID.Equals(userID),
-	).Exec(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
-	}
-
-	var comment *string
-	if commentVal, ok := review.Comment(); ok && commentVal != "" {
-		commentStr := string(commentVal)
-		comment = &commentStr
-	}
-
-	return &dtos.ReviewResponse{
-		ID:        review.ID,
-		Rating:    review.Rating,
-		Comment:   comment,
-		UserID:    review.UserID,
-		UserEmail: utils.MaskEmail(user.Email),
-		ProductID: review.ProductID,
-		CreatedAt: review.CreatedAt,
-		UpdatedAt: review.UpdatedAt,
-	}, nil
+	return convertReviewToDTO(createTx.Result(), user.Email), nil
 }
 
-// GetProductReviews récupère tous les avis d'un produit avec statistiques
-func GetProductReviews(client *db.PrismaClient, productID string) (*dtos.ProductReviewsResponse, error) {
+// GetProductReviews récupère une page des avis publiés (approuvés) d'un produit, avec les statistiques du produit
+// Les filtres de note et la pagination ne portent que sur la page ; verifiedOnly limite aussi les statistiques aux achats vérifiés.
+// userID (utilisateur connecté) sert à indiquer les avis pour lesquels il a voté « utile ».
+func GetProductReviews(client *db.PrismaClient, productID, userID string, filters dtos.ReviewFilters) (*dtos.ProductReviewsResponse, error) {
 	ctx := context.Background()
 
 	// Vérifier que le produit existe
-	_, err := client.Product.FindUnique(
+	product, err := client.Product.FindUnique(
 		db.Product.ID.Equals(productID),
 	).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("produit non trouvé")
 	}
 
-	// Récupérer tous les avis du produit avec les utilisateurs
-	reviews, err := client.Review.FindMany(
+	page, limit := filters.Page, filters.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	order, err := reviewOrderParams(filters.Sort)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := reviewRatingFilter(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statistiques : agrégats maintenus sur le produit, ou calculés sur les achats vérifiés
+	averageRating, histogram := productRatingToDTO(product)
+	totalReviews := product.RatingCount
+	if filters.VerifiedOnly {
+		averageRating, totalReviews, histogram, err = verifiedRatingStats(client, productID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	where := []db.ReviewWhereParam{
 		db.Review.ProductID.Equals(productID),
+		db.Review.Status.Equals(db.ReviewStatusApproved),
+	}
+	if filters.VerifiedOnly {
+		where = append(where, db.Review.VerifiedPurchase.Equals(true))
+	}
+	if len(ratings) < 5 {
+		where = append(where, db.Review.Rating.In(ratings))
+	}
+
+	// Le nombre d'avis correspondant aux filtres de note se lit dans l'histogramme
+	counts := histogramCounts(histogram)
+	total := 0
+	for _, rating := range ratings {
+		total += counts[rating-1]
+	}
+
+	reviews, err := client.Review.FindMany(
+		where...,
 	).With(
 		db.Review.User.Fetch(),
-	).Exec(ctx)
+		db.Review.Reply.Fetch(),
+	).OrderBy(
+		order...,
+	).Take(limit).Skip((page - 1) * limit).Exec(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de la récupération des avis: %w", err)
 	}
 
-	// Convertir en DTOs et calculer la moyenne
-	totalRating := 0
-	result := make([]dtos.ReviewResponse, len(reviews))
-
+	result := make([]dtos.ReviewResponse, 0, len(reviews))
 	for i, review := range reviews {
-		user := review.User()
-		if user == nil {
-			continue
-		}
-
-		totalRating += review.Rating
-
-		var comment *string
-		if commentVal, ok := review.Comment(); ok && commentVal != "" {
-			commentStr := string(commentVal)
-			comment = &commentStr
-		}
-
-		result[i] = dtos.ReviewResponse{
-			ID:        review.ID,
-			Rating:    review.Rating,
-			Comment:   comment,
-			UserID:    review.UserID,
-			UserEmail: utils.MaskEmail(user.Email),
-			ProductID: review.ProductID,
-			CreatedAt: review.CreatedAt,
-			UpdatedAt: review.UpdatedAt,
+		if user := review.User(); user != nil {
+			result = append(result, *convertReviewToDTO(&reviews[i], user.Email))
 		}
 	}
+	if err := markVotedHelpful(client, userID, result); err != nil {
+		return nil, err
+	}
 
-	// Calculer la moyenne
-	var averageRating float64
-	if len(reviews) > 0 {
-		averageRating = float64(totalRating) / float64(len(reviews))
-		// Arrondir à 1 décimale
-		averageRating = math.Round(averageRating*10) / 10
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	if totalPages == 0 {
+		totalPages = 1
 	}
 
 	return &dtos.ProductReviewsResponse{
 		Reviews:       result,
 		AverageRating: averageRating,
-		TotalReviews:  len(reviews),
+		TotalReviews:  totalReviews,
+		Histogram:     histogram,
+		Total:         total,
+		Page:          page,
+		Limit:         limit,
+		TotalPages:    totalPages,
+		HasNext:       page < totalPages,
+		HasPrev:       page > 1,
 	}, nil
 }
 
-// GetUserReview récupère l'avis d'un utilisateur pour un produit spécifique
+// reviewOrderParams convertit le tri de GET /products/{productID}/reviews en ordre Prisma
+// À critère égal, les avis les plus récents passent devant.
+func reviewOrderParams(sort string) ([]db.ReviewOrderByParam, error) {
+	recent := db.Review.CreatedAt.Order(db.SortOrderDesc)
+	switch sort {
+	case "", "recent":
+		return []db.ReviewOrderByParam{recent}, nil
+	case "helpful":
+		return []db.ReviewOrderByParam{db.Review.HelpfulCount.Order(db.SortOrderDesc), recent}, nil
+	case "rating_high":
+		return []db.ReviewOrderByParam{db.Review.Rating.Order(db.SortOrderDesc), recent}, nil
+	case "rating_low":
+		return []db.ReviewOrderByParam{db.Review.Rating.Order(db.SortOrderAsc), recent}, nil
+	}
+	return nil, fmt.Errorf("tri invalide: %s (valeurs acceptées: recent, helpful, rating_high, rating_low)", sort)
+}
+
+// reviewRatingFilter retourne les notes retenues par les filtres, dans l'ordre croissant
+func reviewRatingFilter(filters dtos.ReviewFilters) ([]int, error) {
+	if filters.MinRating < 0 || filters.MinRating > 5 {
+		return nil, fmt.Errorf("filtre de note invalide: %d (de 1 à 5)", filters.MinRating)
+	}
+
+	selected := make(map[int]bool, len(filters.Ratings))
+	for _, rating := range filters.Ratings {
+		if rating < 1 || rating > 5 {
+			return nil, fmt.Errorf("filtre de note invalide: %d (de 1 à 5)", rating)
+		}
+		selected[rating] = true
+	}
+
+	ratings := []int{}
+	for rating := max(filters.MinRating, 1); rating <= 5; rating++ {
+		if len(selected) == 0 || selected[rating] {
+			ratings = append(ratings, rating)
+		}
+	}
+	return ratings, nil
+}
+
+// GetUserReview récupère l'avis d'un utilisateur pour un produit spécifique, quel que soit son statut de modération
 func GetUserReview(client *db.PrismaClient, userID, productID string) (*dtos.ReviewResponse, error) {
 	ctx := context.Background()
 
@@ -198,6 +268,7 @@ func GetUserReview(client *db.PrismaClient, userID, productID string) (*dtos.Rev
 		db.Review.ProductID.Equals(productID),
 	).With(
 		db.Review.User.Fetch(),
+		db.Review.Reply.Fetch(),
 	).Exec(ctx)
 	if err != nil {
 		return nil, nil // Pas d'avis trouvé, ce n'est pas une erreur
@@ -208,25 +279,11 @@ func GetUserReview(client *db.PrismaClient, userID, productID string) (*dtos.Rev
 		return nil, fmt.Errorf("utilisateur non trouvé")
 	}
 
-	var comment *string
-	if commentVal, ok := review.Comment(); ok && commentVal != "" {
-		commentStr := string(commentVal)
-		comment = &commentStr
-	}
-
-	return &dtos.ReviewResponse{
-		ID:        review.ID,
-		Rating:    review.Rating,
-		Comment:   comment,
-		UserID:    review.UserID,
-		UserEmail: utils.MaskEmail(user.Email),
-		ProductID: review.ProductID,
-		CreatedAt: review.CreatedAt,
-		UpdatedAt: review.UpdatedAt,
-	}, nil
+	return convertReviewToDTO(review, user.Email), nil
 }
 
 // UpdateReview met à jour un avis existant
+// L'avis modifié repasse en modération, sauf approbation automatique
 func UpdateReview(client *db.PrismaClient, reviewID, userID string, req dtos.UpdateReviewRequest) (*dtos.ReviewResponse, error) {
 	ctx := context.Background()
 
@@ -244,12 +301,17 @@ func UpdateReview(client *db.PrismaClient, reviewID, userID string, req dtos.Upd
 
 	// Préparer les paramètres de mise à jour
 	var updateParams []db.ReviewSetParam
+	rating := review.Rating
 
 	if req.Rating != nil {
 		updateParams = append(updateParams, db.Review.Rating.Set(*req.Rating))
+		rating = *req.Rating
 	}
 
 	if req.Comment != nil {
+		if containsBannedWord(*req.Comment) {
+			return nil, fmt.Errorf("le commentaire contient des termes interdits")
+		}
 		updateParams = append(updateParams, db.Review.Comment.Set(*req.Comment))
 	}
 
@@ -257,13 +319,22 @@ func UpdateReview(client *db.PrismaClient, reviewID, userID string, req dtos.Upd
 		return nil, fmt.Errorf("aucune modification à effectuer")
 	}
 
-	// Mettre à jour l'avis
-	updatedReview, err := client.Review.FindUnique(
-		db.Review.ID.Equals(reviewID),
-	).Update(updateParams...).Exec(ctx)
+	// Le produit a pu être livré depuis la création de l'avis
+	verified, err := hasReceivedProduct(client, userID, review.ProductID)
 	if err != nil {
+		return nil, err
+	}
+	updateParams = append(updateParams, db.Review.VerifiedPurchase.Set(verified))
+	updateParams = append(updateParams, resetModerationParams(initialReviewStatus(rating))...)
+
+	// Mettre à jour l'avis et les notes du produit
+	updateTx := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).Update(updateParams...).Tx()
+	if err := client.Prisma.Transaction(withProductRatingSync(client, review.ProductID, updateTx)...).Exec(ctx); err != nil {
 		return nil, fmt.Errorf("erreur lors de la mise à jour de l'avis: %w", err)
 	}
+	updatedReview := updateTx.Result()
 
 	// Récupérer l'utilisateur pour l'email
 	user, err := client.User.FindUnique(
@@ -273,22 +344,7 @@ func UpdateReview(client *db.PrismaClient, reviewID, userID string, req dtos.Upd
 		return nil, fmt.Errorf("erreur lors de la récupération de l'utilisateur: %w", err)
 	}
 
-	var comment *string
-	if commentVal, ok := updatedReview.Comment(); ok && commentVal != "" {
-		commentStr := string(commentVal)
-		comment = &commentStr
-	}
-
-	return &dtos.ReviewResponse{
-		ID:        updatedReview.ID,
-		Rating:    updatedReview.Rating,
-		Comment:   comment,
-		UserID:    updatedReview.UserID,
-		UserEmail: utils.MaskEmail(user.Email),
-		ProductID: updatedReview.ProductID,
-		CreatedAt: updatedReview.CreatedAt,
-		UpdatedAt: updatedReview.UpdatedAt,
-	}, nil
+	return convertReviewToDTO(updatedReview, user.Email), nil
 }
 
 // DeleteReview supprime un avis
@@ -307,13 +363,75 @@ func DeleteReview(client *db.PrismaClient, reviewID, userID string) error {
 		return fmt.Errorf("vous n'êtes pas autorisé à supprimer cet avis")
 	}
 
-	// Supprimer l'avis
-	_, err = client.Review.FindUnique(
+	// Supprimer l'avis et mettre à jour les notes du produit
+	deleteTx := client.Review.FindUnique(
 		db.Review.ID.Equals(reviewID),
-	).Delete().Exec(ctx)
-	if err != nil {
+	).Delete().Tx()
+	if err := client.Prisma.Transaction(withProductRatingSync(client, review.ProductID, deleteTx)...).Exec(ctx); err != nil {
 		return fmt.Errorf("erreur lors de la suppression de l'avis: %w", err)
 	}
 
 	return nil
 }
+
+// reviewRequiresPurchase indique si seuls les clients ayant reçu un produit peuvent le noter (REVIEW_REQUIRE_PURCHASE, défaut: false)
+func reviewRequiresPurchase() bool {
+	required, _ := strconv.ParseBool(os.Getenv("REVIEW_REQUIRE_PURCHASE"))
+	return required
+}
+
+// hasReceivedProduct indique si l'utilisateur a une commande livrée contenant le produit
+func hasReceivedProduct(client *db.PrismaClient, userID, productID string) (bool, error) {
+	ctx := context.Background()
+
+	items, err := client.OrderItem.FindMany(
+		db.OrderItem.ProductID.Equals(productID),
+		db.OrderItem.Order.Where(
+			db.Order.UserID.Equals(userID),
+			db.Order.Status.Equals(db.OrderStatusDelivered),
+		),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de la vérification de l'achat: %w", err)
+	}
+
+	return len(items) > 0, nil
+}
+
+// convertReviewToDTO convertit un avis Prisma en DTO, avec l'email masqué de son auteur
+func convertReviewToDTO(review *db.ReviewModel, userEmail string) *dtos.ReviewResponse {
+	var comment *string
+	if commentVal, ok := review.Comment(); ok && commentVal != "" {
+		commentStr := string(commentVal)
+		comment = &commentStr
+	}
+
+	var reason *string
+	if reasonVal, ok := review.ModerationReason(); ok {
+		reasonStr := string(reasonVal)
+		reason = &reasonStr
+	}
+
+	var moderatedAt *time.Time
+	if moderatedAtVal, ok := review.ModeratedAt(); ok {
+		moderatedAtTime := time.Time(moderatedAtVal)
+		moderatedAt = &moderatedAtTime
+	}
+
+	return &dtos.ReviewResponse{
+		ID:               review.ID,
+		Rating:           review.Rating,
+		Comment:          comment,
+		Status:           string(review.Status),
+		ModerationReason: reason,
+		ModeratedAt:      moderatedAt,
+		VerifiedPurchase: review.VerifiedPurchase,
+		HelpfulCount:     review.HelpfulCount,
+		Reply:            reviewReplyToDTO(review),
+		UserID:           review.UserID,
+		UserEmail:        utils.MaskEmail(userEmail),
+		ProductID:        review.ProductID,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+	}
+}
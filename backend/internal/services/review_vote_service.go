@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"api/internal/db"
+	"api/internal/dtos"
+)
+
+// VoteReviewHelpful enregistre le vote « utile » d'un utilisateur sur un avis publié
+// Un utilisateur vote au plus une fois par avis, et jamais pour son propre avis.
+func VoteReviewHelpful(client *db.PrismaClient, reviewID, userID string) (*dtos.ReviewResponse, error) {
+	review, err := findPublishedReview(client, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.HelpfulCount, err = addVote(client, reviewVotes, reviewID, review.UserID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertReviewToDTO(review, review.User().Email)
+	response.Reply = reviewReplyToDTO(review)
+	response.VotedHelpful = true
+	return response, nil
+}
+
+// RemoveReviewHelpfulVote retire le vote « utile » d'un utilisateur sur un avis publié
+func RemoveReviewHelpfulVote(client *db.PrismaClient, reviewID, userID string) (*dtos.ReviewResponse, error) {
+	review, err := findPublishedReview(client, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.HelpfulCount, err = removeVote(client, reviewVotes, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertReviewToDTO(review, review.User().Email)
+	response.Reply = reviewReplyToDTO(review)
+	return response, nil
+}
+
+// markVotedHelpful renseigne VotedHelpful sur une liste d'avis pour l'utilisateur connecté
+func markVotedHelpful(client *db.PrismaClient, userID string, reviews []dtos.ReviewResponse) error {
+	ctx := context.Background()
+
+	if userID == "" || len(reviews) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(reviews))
+	for i, r := range reviews {
+		ids[i] = r.ID
+	}
+
+	votes, err := client.ReviewVote.FindMany(
+		db.ReviewVote.UserID.Equals(userID),
+		db.ReviewVote.ReviewID.In(ids),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des votes: %w", err)
+	}
+
+	voted := make(map[string]bool, len(votes))
+	for _, v := range votes {
+		voted[v.ReviewID] = true
+	}
+	for i := range reviews {
+		reviews[i].VotedHelpful = voted[reviews[i].ID]
+	}
+
+	return nil
+}
+
+// findPublishedReview récupère un avis approuvé avec son auteur ; les autres avis ne sont pas visibles des clients
+func findPublishedReview(client *db.PrismaClient, reviewID string) (*db.ReviewModel, error) {
+	ctx := context.Background()
+
+	review, err := client.Review.FindUnique(
+		db.Review.ID.Equals(reviewID),
+	).With(
+		db.Review.User.Fetch(),
+		db.Review.Reply.Fetch(),
+	).Exec(ctx)
+	if err != nil || review == nil || review.Status != db.ReviewStatusApproved {
+		return nil, fmt.Errorf("avis non trouvé")
+	}
+
+	return review, nil
+}
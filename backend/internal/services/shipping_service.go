@@ -0,0 +1,229 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Règles de prix acceptées par l'API
+var shippingRateTypeValues = map[string]db.ShippingRateType{
+	"FLAT":   db.ShippingRateTypeFlat,
+	"WEIGHT": db.ShippingRateTypeWeight,
+}
+
+// GetShippingMethods récupère les modes de livraison (uniquement les actifs si activeOnly)
+func GetShippingMethods(client *db.PrismaClient, activeOnly bool) ([]dtos.ShippingMethodResponse, error) {
+	ctx := context.Background()
+
+	var where []db.ShippingMethodWhereParam
+	if activeOnly {
+		where = append(where, db.ShippingMethod.Active.Equals(true))
+	}
+
+	methods, err := client.ShippingMethod.FindMany(where...).OrderBy(
+		db.ShippingMethod.Price.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des modes de livraison: %w", err)
+	}
+
+	result := make([]dtos.ShippingMethodResponse, len(methods))
+	for i := range methods {
+		result[i] = *convertShippingMethodToDTO(&methods[i])
+	}
+
+	return result, nil
+}
+
+// CreateShippingMethod crée un mode de livraison (admin only)
+func CreateShippingMethod(client *db.PrismaClient, req dtos.ShippingMethodRequest) (*dtos.ShippingMethodResponse, error) {
+	ctx := context.Background()
+
+	rateType, params, err := shippingMethodParams(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkShippingMethodNameUnique(client, req.Name, ""); err != nil {
+		return nil, err
+	}
+
+	params = append(params, db.ShippingMethod.Type.Set(rateType))
+	if req.Active != nil {
+		params = append(params, db.ShippingMethod.Active.Set(*req.Active))
+	}
+
+	method, err := client.ShippingMethod.CreateOne(
+		db.ShippingMethod.Name.Set(strings.TrimSpace(req.Name)),
+		db.ShippingMethod.Price.Set(req.Price),
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création du mode de livraison: %w", err)
+	}
+
+	return convertShippingMethodToDTO(method), nil
+}
+
+// UpdateShippingMethod remplace les paramètres d'un mode de livraison (admin only)
+// Les commandes passées conservent les frais de port calculés
+func UpdateShippingMethod(client *db.PrismaClient, methodID string, req dtos.ShippingMethodRequest) (*dtos.ShippingMethodResponse, error) {
+	ctx := context.Background()
+
+	existing, err := client.ShippingMethod.FindUnique(
+		db.ShippingMethod.ID.Equals(methodID),
+	).Exec(ctx)
+	if err != nil || existing == nil {
+		return nil, fmt.Errorf("mode de livraison non trouvé")
+	}
+
+	rateType, params, err := shippingMethodParams(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkShippingMethodNameUnique(client, req.Name, methodID); err != nil {
+		return nil, err
+	}
+
+	params = append(params,
+		db.ShippingMethod.Name.Set(strings.TrimSpace(req.Name)),
+		db.ShippingMethod.Price.Set(req.Price),
+		db.ShippingMethod.Type.Set(rateType),
+	)
+	if req.Active != nil {
+		params = append(params, db.ShippingMethod.Active.Set(*req.Active))
+	}
+
+	method, err := client.ShippingMethod.FindUnique(
+		db.ShippingMethod.ID.Equals(methodID),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour du mode de livraison: %w", err)
+	}
+
+	return convertShippingMethodToDTO(method), nil
+}
+
+// DeleteShippingMethod supprime un mode de livraison (admin only)
+// Les commandes qui l'ont utilisé conservent son nom et les frais de port
+func DeleteShippingMethod(client *db.PrismaClient, methodID string) error {
+	ctx := context.Background()
+
+	_, err := client.ShippingMethod.FindUnique(
+		db.ShippingMethod.ID.Equals(methodID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if err == db.ErrNotFound {
+			return fmt.Errorf("mode de livraison non trouvé")
+		}
+		return fmt.Errorf("erreur lors de la suppression du mode de livraison: %w", err)
+	}
+
+	return nil
+}
+
+// shippingCost calcule les frais de port TTC d'une commande
+// itemsAmount est le montant des articles après remise, weightGrams le poids total expédié
+func shippingCost(method *db.ShippingMethodModel, itemsAmount float64, weightGrams int) float64 {
+	if freeAbove, ok := method.FreeAbove(); ok && itemsAmount >= freeAbove {
+		return 0
+	}
+
+	cost := method.Price
+	if method.Type == db.ShippingRateTypeWeight && weightGrams > 0 {
+		// Chaque kilogramme entamé est facturé
+		kilograms := math.Ceil(float64(weightGrams) / 1000)
+		cost += method.PricePerKg * kilograms
+	}
+
+	return roundAmount(cost)
+}
+
+// shippingMethodParams valide un ShippingMethodRequest et prépare les champs optionnels
+func shippingMethodParams(req dtos.ShippingMethodRequest) (db.ShippingRateType, []db.ShippingMethodSetParam, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return "", nil, fmt.Errorf("le nom du mode de livraison est requis")
+	}
+
+	rateType := db.ShippingRateTypeFlat
+	if req.Type != "" {
+		t, ok := shippingRateTypeValues[strings.ToUpper(strings.TrimSpace(req.Type))]
+		if !ok {
+			return "", nil, fmt.Errorf("type de tarif invalide: %s", req.Type)
+		}
+		rateType = t
+	}
+
+	if req.Price < 0 || req.PricePerKg < 0 || (req.FreeAbove != nil && *req.FreeAbove < 0) {
+		return "", nil, fmt.Errorf("les montants ne peuvent pas être négatifs")
+	}
+	if req.EstimatedDays < 0 {
+		return "", nil, fmt.Errorf("le délai de livraison ne peut pas être négatif")
+	}
+
+	pricePerKg := req.PricePerKg
+	if rateType == db.ShippingRateTypeFlat {
+		pricePerKg = 0
+	}
+
+	params := []db.ShippingMethodSetParam{
+		db.ShippingMethod.PricePerKg.Set(pricePerKg),
+		db.ShippingMethod.FreeAbove.SetOptional(req.FreeAbove),
+	}
+	if description := strings.TrimSpace(req.Description); description != "" {
+		params = append(params, db.ShippingMethod.Description.Set(description))
+	} else {
+		params = append(params, db.ShippingMethod.Description.SetOptional(nil))
+	}
+	if req.EstimatedDays > 0 {
+		params = append(params, db.ShippingMethod.EstimatedDays.Set(req.EstimatedDays))
+	} else {
+		params = append(params, db.ShippingMethod.EstimatedDays.SetOptional(nil))
+	}
+
+	return rateType, params, nil
+}
+
+// checkShippingMethodNameUnique vérifie que le nom n'est pas utilisé par un autre mode de livraison
+func checkShippingMethodNameUnique(client *db.PrismaClient, name, methodID string) error {
+	ctx := context.Background()
+
+	existing, _ := client.ShippingMethod.FindUnique(
+		db.ShippingMethod.Name.Equals(strings.TrimSpace(name)),
+	).Exec(ctx)
+	if existing != nil && existing.ID != methodID {
+		return fmt.Errorf("un mode de livraison avec ce nom existe déjà")
+	}
+	return nil
+}
+
+// convertShippingMethodToDTO convertit un ShippingMethodModel en ShippingMethodResponse
+func convertShippingMethodToDTO(method *db.ShippingMethodModel) *dtos.ShippingMethodResponse {
+	description, _ := method.Description()
+
+	var freeAbove *float64
+	if v, ok := method.FreeAbove(); ok {
+		freeAbove = &v
+	}
+	var estimatedDays *int
+	if v, ok := method.EstimatedDays(); ok {
+		estimatedDays = &v
+	}
+
+	return &dtos.ShippingMethodResponse{
+		ID:            method.ID,
+		Name:          method.Name,
+		Description:   string(description),
+		Type:          string(method.Type),
+		Price:         method.Price,
+		PricePerKg:    method.PricePerKg,
+		FreeAbove:     freeAbove,
+		EstimatedDays: estimatedDays,
+		Active:        method.Active,
+		CreatedAt:     method.CreatedAt,
+		UpdatedAt:     method.UpdatedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetSkinProfile récupère le profil de peau d'un utilisateur (profil vide s'il n'a jamais été renseigné)
+func GetSkinProfile(client *db.PrismaClient, userID string) (*dtos.SkinProfileResponse, error) {
+	ctx := context.Background()
+
+	profile, err := client.SkinProfile.FindUnique(
+		db.SkinProfile.UserID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		if err == db.ErrNotFound {
+			return &dtos.SkinProfileResponse{
+				Concerns:           []string{},
+				AvoidedIngredients: []string{},
+			}, nil
+		}
+		return nil, fmt.Errorf("erreur lors de la récupération du profil de peau: %w", err)
+	}
+
+	return convertSkinProfileToDTO(profile), nil
+}
+
+// UpdateSkinProfile crée ou remplace le profil de peau d'un utilisateur
+func UpdateSkinProfile(client *db.PrismaClient, userID string, req dtos.SkinProfileRequest) (*dtos.SkinProfileResponse, error) {
+	ctx := context.Background()
+
+	concerns, err := parseSkinConcerns(req.Concerns)
+	if err != nil {
+		return nil, err
+	}
+
+	params := []db.SkinProfileSetParam{
+		db.SkinProfile.Concerns.Set(concerns),
+		db.SkinProfile.AvoidedIngredients.Set(normalizeIngredients(req.AvoidedIngredients)),
+	}
+	if strings.TrimSpace(req.SkinType) != "" {
+		skinType, err := parseSkinType(req.SkinType)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.SkinProfile.SkinType.Set(skinType))
+	} else {
+		params = append(params, db.SkinProfile.SkinType.SetOptional(nil))
+	}
+
+	profile, err := client.SkinProfile.UpsertOne(
+		db.SkinProfile.UserID.Equals(userID),
+	).Create(
+		db.SkinProfile.User.Link(db.User.ID.Equals(userID)),
+		params...,
+	).Update(
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'enregistrement du profil de peau: %w", err)
+	}
+
+	return convertSkinProfileToDTO(profile), nil
+}
+
+// convertSkinProfileToDTO convertit un SkinProfileModel en SkinProfileResponse
+func convertSkinProfileToDTO(profile *db.SkinProfileModel) *dtos.SkinProfileResponse {
+	var skinType string
+	if v, ok := profile.SkinType(); ok {
+		skinType = strings.ToLower(string(v))
+	}
+	updatedAt := profile.UpdatedAt
+
+	return &dtos.SkinProfileResponse{
+		SkinType:           skinType,
+		Concerns:           skinConcernsToDTO(profile.Concerns),
+		AvoidedIngredients: append([]string{}, profile.AvoidedIngredients...),
+		UpdatedAt:          &updatedAt,
+	}
+}
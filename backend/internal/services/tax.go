@@ -0,0 +1,78 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// defaultTaxRate retourne le taux de TVA (en %) appliqué aux produits sans taux de catégorie
+// Configurable via VAT_DEFAULT_RATE (défaut: 20, taux normal en France)
+func defaultTaxRate() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("VAT_DEFAULT_RATE"), 64); err == nil && v >= 0 && v <= 100 {
+		return v
+	}
+	return 20
+}
+
+// validateTaxRate vérifie un taux de TVA fourni par l'API
+func validateTaxRate(rate float64) error {
+	if rate < 0 || rate > 100 {
+		return fmt.Errorf("le taux de TVA doit être compris entre 0 et 100")
+	}
+	return nil
+}
+
+// productTaxRate retourne le taux de TVA d'un produit chargé avec sa catégorie
+// Le taux de la catégorie est prioritaire sur le taux par défaut
+func productTaxRate(product *db.ProductModel) float64 {
+	if category, ok := product.Category(); ok {
+		if rate, ok := category.TaxRate(); ok {
+			return rate
+		}
+	}
+	return defaultTaxRate()
+}
+
+// splitGrossAmount décompose un montant TTC en HT et TVA (arrondis au centime, HT + TVA = TTC)
+// Les prix du catalogue sont exprimés TTC
+func splitGrossAmount(gross, rate float64) (net, tax float64) {
+	gross = roundAmount(gross)
+	net = roundAmount(gross / (1 + rate/100))
+	return net, roundAmount(gross - net)
+}
+
+// taxBreakdownToDTO regroupe les montants d'une commande (lignes et frais de port) par taux de TVA
+func taxBreakdownToDTO(order *db.OrderModel) []dtos.TaxLineResponse {
+	byRate := make(map[float64]*dtos.TaxLineResponse)
+	add := func(rate, net, tax, gross float64) {
+		line, ok := byRate[rate]
+		if !ok {
+			line = &dtos.TaxLineResponse{Rate: rate}
+			byRate[rate] = line
+		}
+		line.NetAmount = roundAmount(line.NetAmount + net)
+		line.TaxAmount = roundAmount(line.TaxAmount + tax)
+		line.GrossAmount = roundAmount(line.GrossAmount + gross)
+	}
+
+	for _, item := range order.OrderItems() {
+		add(item.TaxRate, item.NetAmount, item.TaxAmount, item.GrossAmount)
+	}
+	if order.ShippingAmount > 0 {
+		net, tax := splitGrossAmount(order.ShippingAmount, order.ShippingTaxRate)
+		add(order.ShippingTaxRate, net, tax, order.ShippingAmount)
+	}
+
+	result := make([]dtos.TaxLineResponse, 0, len(byRate))
+	for _, line := range byRate {
+		result = append(result, *line)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Rate > result[j].Rate
+	})
+	return result
+}
@@ -3,10 +3,12 @@ package services
 
 import (
 	"api/internal/db"
+	"api/internal/events"
 	"api/internal/models"
 	"api/internal/utils"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -63,13 +65,21 @@ func CreateUser(client *db.PrismaClient, email string, password string) (*models
 		return nil, fmt.Errorf("L'email existe déjà")
 	}
 
-	newUser, err := client.User.CreateOne(
+	// Le compte et l'événement UserRegistered sont écrits dans la même transaction
+	userID := newID()
+	createTx := client.User.CreateOne(
 		db.User.Email.Set(email),
 		db.User.Password.Set(hash), //utilisation du hash
-	).Exec(ctx)
-	if err != nil {
+		db.User.ID.Set(userID),
+	).Tx()
+	eventTx := outboxEventTx(client, events.UserRegistered, userID, events.UserRegisteredPayload{
+		UserID: userID,
+		Email:  email,
+	})
+	if err := client.Prisma.Transaction(createTx, eventTx).Exec(ctx); err != nil {
 		return nil, err
 	}
+	newUser := createTx.Result()
 
 	return &models.User{
 		ID:        newUser.ID,
@@ -166,10 +176,51 @@ func UpdateUser(client *db.PrismaClient, userID, email, password string) (*model
 }
 
 // Supprimer un user
+// Ses avis et ses votes sont supprimés en cascade : les notes des produits et les compteurs de votes
+// concernés sont mis à jour dans la même transaction
 func DeleteUser(client *db.PrismaClient, userID string) error {
 	ctx := context.Background()
-	_, err := client.User.FindUnique(
+
+	reviews, err := client.Review.FindMany(
+		db.Review.UserID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	productIDs := make([]string, len(reviews))
+	for i, review := range reviews {
+		productIDs[i] = review.ProductID
+	}
+	// Verrous pris dans un ordre stable pour éviter les interblocages
+	productIDs = uniqueStrings(productIDs)
+	sort.Strings(productIDs)
+
+	var txs []db.PrismaTransaction
+	for _, productID := range productIDs {
+		txs = append(txs, lockProductTx(client, productID))
+	}
+	// Ses votes « utile » et ses votes sur les questions et réponses sont aussi supprimés en cascade
+	txs = append(txs, client.Prisma.ExecuteRaw(
+		`UPDATE "Review" SET "helpfulCount" = "helpfulCount" - 1
+		WHERE "id" IN (SELECT "reviewID" FROM "ReviewVote" WHERE "userID" = $1)`,
+		userID,
+	).Tx())
+	txs = append(txs, client.Prisma.ExecuteRaw(
+		`UPDATE "Question" SET "upvoteCount" = "upvoteCount" - 1
+		WHERE "id" IN (SELECT "questionID" FROM "QuestionVote" WHERE "userID" = $1)`,
+		userID,
+	).Tx())
+	txs = append(txs, client.Prisma.ExecuteRaw(
+		`UPDATE "Answer" SET "upvoteCount" = "upvoteCount" - 1
+		WHERE "id" IN (SELECT "answerID" FROM "AnswerVote" WHERE "userID" = $1)`,
+		userID,
+	).Tx())
+	txs = append(txs, client.User.FindUnique(
 		db.User.ID.Equals(userID),
-	).Delete().Exec(ctx)
-	return err
+	).Delete().Tx())
+	for _, productID := range productIDs {
+		txs = append(txs, syncProductRatingsTx(client, productID))
+	}
+
+	return client.Prisma.Transaction(txs...).Exec(ctx)
 }
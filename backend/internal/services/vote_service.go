@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"api/internal/db"
+)
+
+// voteTarget décrit un contenu sur lequel les clients votent (avis, question, réponse) :
+// sa table, la table de ses votes (un vote par contenu et par utilisateur) et le compteur maintenu avec eux
+type voteTarget struct {
+	table      string // Table du contenu
+	voteTable  string // Table des votes, avec une contrainte d'unicité (contenu, utilisateur)
+	foreignKey string // Colonne de la table des votes qui référence le contenu
+	counter    string // Compteur de votes du contenu
+	this       string // Désignation du contenu dans les messages (« cet avis »)
+	own        string // Désignation du contenu de l'utilisateur dans les messages (« votre propre avis »)
+}
+
+// Contenus sur lesquels les clients votent
+var (
+	reviewVotes   = voteTarget{"Review", "ReviewVote", "reviewID", "helpfulCount", "cet avis", "votre propre avis"}
+	questionVotes = voteTarget{"Question", "QuestionVote", "questionID", "upvoteCount", "cette question", "votre propre question"}
+	answerVotes   = voteTarget{"Answer", "AnswerVote", "answerID", "upvoteCount", "cette réponse", "votre propre réponse"}
+)
+
+// addVote enregistre le vote de userID pour un contenu publié d'authorID et retourne le nouveau compteur
+// Le vote et l'incrément sont une seule requête : deux votes simultanés du même utilisateur n'en comptent qu'un.
+func addVote(client *db.PrismaClient, target voteTarget, targetID, authorID, userID string) (int, error) {
+	if authorID == userID {
+		return 0, fmt.Errorf("vous ne pouvez pas voter pour %s", target.own)
+	}
+
+	count, ok, err := execVote(client, fmt.Sprintf(
+		`WITH vote AS (
+			INSERT INTO "%[2]s" ("id", "%[3]s", "userID") VALUES ($3, $1, $2)
+			ON CONFLICT ("%[3]s", "userID") DO NOTHING
+			RETURNING 1
+		)
+		UPDATE "%[1]s" SET "%[4]s" = "%[4]s" + 1
+		WHERE "id" = $1 AND EXISTS (SELECT 1 FROM vote)
+		RETURNING "%[4]s" AS "count"`,
+		target.table, target.voteTable, target.foreignKey, target.counter,
+	), targetID, userID, newID())
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de l'enregistrement du vote: %w", err)
+	}
+	if !ok {
+		return 0, fmt.Errorf("vous avez déjà voté pour %s", target.this)
+	}
+	return count, nil
+}
+
+// removeVote retire le vote de userID pour un contenu et retourne le nouveau compteur
+// Le retrait et le décrément sont une seule requête : le compteur n'est décrémenté qu'une fois par vote.
+func removeVote(client *db.PrismaClient, target voteTarget, targetID, userID string) (int, error) {
+	count, ok, err := execVote(client, fmt.Sprintf(
+		`WITH vote AS (
+			DELETE FROM "%[2]s" WHERE "%[3]s" = $1 AND "userID" = $2
+			RETURNING 1
+		)
+		UPDATE "%[1]s" SET "%[4]s" = "%[4]s" - 1
+		WHERE "id" = $1 AND EXISTS (SELECT 1 FROM vote)
+		RETURNING "%[4]s" AS "count"`,
+		target.table, target.voteTable, target.foreignKey, target.counter,
+	), targetID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors du retrait du vote: %w", err)
+	}
+	if !ok {
+		return 0, fmt.Errorf("vote non trouvé")
+	}
+	return count, nil
+}
+
+// execVote exécute une requête de vote et retourne le compteur, ou false si aucun vote n'a été ajouté ou retiré
+func execVote(client *db.PrismaClient, query string, args ...any) (int, bool, error) {
+	var rows []struct {
+		Count db.RawInt `json:"count"`
+	}
+	if err := client.Prisma.QueryRaw(query, args...).Exec(context.Background(), &rows); err != nil {
+		return 0, false, err
+	}
+	if len(rows) == 0 {
+		return 0, false, nil
+	}
+	return int(rows[0].Count), true, nil
+}
@@ -0,0 +1,540 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"api/internal/webhooks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// webhookBatchSize est le nombre de livraisons réservées à chaque passage : les envois étant séquentiels,
+// batch × WEBHOOK_TIMEOUT_SECONDS doit rester sous le bail
+const webhookBatchSize = 20
+
+// webhookLease est la durée de réservation d'une livraison en cours d'envoi
+const webhookLease = 5 * time.Minute
+
+// webhookAllEvents abonne une destination à tous les types d'événements
+const webhookAllEvents = "*"
+
+// webhookMaxAttempts retourne le nombre de tentatives d'une livraison avant abandon (WEBHOOK_MAX_ATTEMPTS, défaut: 8)
+func webhookMaxAttempts() int {
+	if v, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS")); err == nil && v > 0 {
+		return v
+	}
+	return 8
+}
+
+// webhookDisableAfter retourne le nombre de tentatives échouées d'affilée qui désactive une destination
+// (WEBHOOK_DISABLE_AFTER_FAILURES, défaut: 20)
+func webhookDisableAfter() int {
+	if v, err := strconv.Atoi(os.Getenv("WEBHOOK_DISABLE_AFTER_FAILURES")); err == nil && v > 0 {
+		return v
+	}
+	return 20
+}
+
+// webhookBackoff retourne le délai avant la tentative suivante : 30s, 1min, 2min, ... plafonné à 6h
+func webhookBackoff(attempt int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempt && delay < 6*time.Hour; i++ {
+		delay *= 2
+	}
+	return min(delay, 6*time.Hour)
+}
+
+// webhookEnvelope est le corps JSON envoyé aux destinations
+type webhookEnvelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// GetWebhookEndpoints liste les destinations de webhooks (admin only)
+func GetWebhookEndpoints(client *db.PrismaClient) ([]dtos.WebhookEndpointResponse, error) {
+	ctx := context.Background()
+
+	endpoints, err := client.WebhookEndpoint.FindMany().OrderBy(
+		db.WebhookEndpoint.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des webhooks: %w", err)
+	}
+
+	response := make([]dtos.WebhookEndpointResponse, len(endpoints))
+	for i := range endpoints {
+		response[i] = *convertWebhookEndpointToDTO(&endpoints[i])
+	}
+	return response, nil
+}
+
+// GetWebhookEndpoint récupère une destination de webhooks (admin only)
+func GetWebhookEndpoint(client *db.PrismaClient, endpointID string) (*dtos.WebhookEndpointResponse, error) {
+	endpoint, err := findWebhookEndpoint(client, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	return convertWebhookEndpointToDTO(endpoint), nil
+}
+
+// CreateWebhookEndpoint crée une destination de webhooks avec une clé de signature générée (admin only)
+// La clé n'est retournée qu'ici et par RotateWebhookSecret.
+func CreateWebhookEndpoint(client *db.PrismaClient, req dtos.WebhookEndpointRequest) (*dtos.WebhookEndpointSecretResponse, error) {
+	ctx := context.Background()
+
+	eventTypes, err := validateWebhookEndpointRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	params := []db.WebhookEndpointSetParam{
+		db.WebhookEndpoint.Description.Set(req.Description),
+		db.WebhookEndpoint.EventTypes.Set(eventTypes),
+	}
+	if req.Active != nil {
+		params = append(params, db.WebhookEndpoint.Active.Set(*req.Active))
+	}
+
+	endpoint, err := client.WebhookEndpoint.CreateOne(
+		db.WebhookEndpoint.URL.Set(req.URL),
+		db.WebhookEndpoint.Secret.Set(webhooks.NewSecret()),
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création du webhook: %w", err)
+	}
+
+	return convertWebhookEndpointToSecretDTO(endpoint), nil
+}
+
+// UpdateWebhookEndpoint remplace l'URL, la description et les types d'événements d'une destination (admin only)
+// Réactiver une destination désactivée remet à zéro son compteur d'échecs ; les livraisons en attente reprennent.
+func UpdateWebhookEndpoint(client *db.PrismaClient, endpointID string, req dtos.WebhookEndpointRequest) (*dtos.WebhookEndpointResponse, error) {
+	ctx := context.Background()
+
+	existing, err := findWebhookEndpoint(client, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := validateWebhookEndpointRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	params := []db.WebhookEndpointSetParam{
+		db.WebhookEndpoint.URL.Set(req.URL),
+		db.WebhookEndpoint.Description.Set(req.Description),
+		db.WebhookEndpoint.EventTypes.Set(eventTypes),
+	}
+	if req.Active != nil {
+		params = append(params, db.WebhookEndpoint.Active.Set(*req.Active))
+		if *req.Active && !existing.Active {
+			params = append(params,
+				db.WebhookEndpoint.ConsecutiveFailures.Set(0),
+				db.WebhookEndpoint.DisabledAt.SetOptional(nil),
+			)
+		}
+	}
+
+	endpoint, err := client.WebhookEndpoint.FindUnique(
+		db.WebhookEndpoint.ID.Equals(endpointID),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour du webhook: %w", err)
+	}
+
+	return convertWebhookEndpointToDTO(endpoint), nil
+}
+
+// RotateWebhookSecret remplace la clé de signature d'une destination et retourne la nouvelle (admin only)
+// Les envois suivants, y compris les livraisons en attente, sont signés avec la nouvelle clé.
+func RotateWebhookSecret(client *db.PrismaClient, endpointID string) (*dtos.WebhookEndpointSecretResponse, error) {
+	ctx := context.Background()
+
+	if _, err := findWebhookEndpoint(client, endpointID); err != nil {
+		return nil, err
+	}
+	endpoint, err := client.WebhookEndpoint.FindUnique(
+		db.WebhookEndpoint.ID.Equals(endpointID),
+	).Update(
+		db.WebhookEndpoint.Secret.Set(webhooks.NewSecret()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la rotation de la clé du webhook: %w", err)
+	}
+
+	return convertWebhookEndpointToSecretDTO(endpoint), nil
+}
+
+// DeleteWebhookEndpoint supprime une destination et son journal de livraisons (admin only)
+func DeleteWebhookEndpoint(client *db.PrismaClient, endpointID string) error {
+	ctx := context.Background()
+
+	if _, err := findWebhookEndpoint(client, endpointID); err != nil {
+		return err
+	}
+	_, err := client.WebhookEndpoint.FindUnique(
+		db.WebhookEndpoint.ID.Equals(endpointID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression du webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries retourne le journal des livraisons d'une destination, des plus récentes aux plus anciennes
+// status (PENDING, SUCCEEDED, FAILED) filtre le journal s'il est fourni
+func GetWebhookDeliveries(client *db.PrismaClient, endpointID, status string, limit int) ([]dtos.WebhookDeliveryResponse, error) {
+	ctx := context.Background()
+
+	if _, err := findWebhookEndpoint(client, endpointID); err != nil {
+		return nil, err
+	}
+
+	where := []db.WebhookDeliveryWhereParam{db.WebhookDelivery.EndpointID.Equals(endpointID)}
+	if status != "" {
+		deliveryStatus := db.WebhookDeliveryStatus(status)
+		switch deliveryStatus {
+		case db.WebhookDeliveryStatusPending, db.WebhookDeliveryStatusSucceeded, db.WebhookDeliveryStatusFailed:
+			where = append(where, db.WebhookDelivery.Status.Equals(deliveryStatus))
+		default:
+			return nil, fmt.Errorf("statut de livraison invalide: %s", status)
+		}
+	}
+
+	deliveries, err := client.WebhookDelivery.FindMany(where...).OrderBy(
+		db.WebhookDelivery.CreatedAt.Order(db.SortOrderDesc),
+	).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des livraisons: %w", err)
+	}
+
+	response := make([]dtos.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		response[i] = *convertWebhookDeliveryToDTO(&deliveries[i])
+	}
+	return response, nil
+}
+
+// RedeliverWebhook programme un nouvel envoi immédiat d'une livraison (réussie ou non) vers sa destination
+// Le corps et l'identifiant d'événement sont identiques : le destinataire peut dédupliquer sur "id".
+func RedeliverWebhook(client *db.PrismaClient, endpointID, deliveryID string) (*dtos.WebhookDeliveryResponse, error) {
+	ctx := context.Background()
+
+	endpoint, err := findWebhookEndpoint(client, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.Active {
+		return nil, fmt.Errorf("webhook désactivé : réactivez-le avant de relivrer")
+	}
+
+	original, err := client.WebhookDelivery.FindUnique(
+		db.WebhookDelivery.ID.Equals(deliveryID),
+	).Exec(ctx)
+	if err != nil || original.EndpointID != endpointID {
+		return nil, fmt.Errorf("livraison non trouvée")
+	}
+
+	delivery, err := client.WebhookDelivery.CreateOne(
+		db.WebhookDelivery.EventID.Set(original.EventID),
+		db.WebhookDelivery.EventType.Set(original.EventType),
+		db.WebhookDelivery.Payload.Set(original.Payload),
+		db.WebhookDelivery.Endpoint.Link(db.WebhookEndpoint.ID.Equals(endpointID)),
+		db.WebhookDelivery.RedeliveryOf.Set(original.ID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la relivraison: %w", err)
+	}
+
+	return convertWebhookDeliveryToDTO(delivery), nil
+}
+
+// SubscribeWebhooks abonne les webhooks sortants à tous les événements de domaine :
+// chaque événement crée une livraison par destination active abonnée à son type
+func SubscribeWebhooks(bus *events.Bus, client *db.PrismaClient) {
+	for _, eventType := range events.Types {
+		bus.Subscribe(eventType, "webhooks", func(ctx context.Context, event events.Event) error {
+			return enqueueWebhookDeliveries(ctx, client, event)
+		})
+	}
+}
+
+// enqueueWebhookDeliveries crée les livraisons d'un événement
+// Un événement redistribué par l'outbox ne crée pas de seconde livraison vers la même destination.
+func enqueueWebhookDeliveries(ctx context.Context, client *db.PrismaClient, event events.Event) error {
+	endpoints, err := client.WebhookEndpoint.FindMany(
+		db.WebhookEndpoint.Active.Equals(true),
+		db.WebhookEndpoint.EventTypes.HasSome([]string{event.Type, webhookAllEvents}),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des webhooks: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	existing, err := client.WebhookDelivery.FindMany(
+		db.WebhookDelivery.EventID.Equals(event.ID),
+		db.WebhookDelivery.RedeliveryOf.IsNull(),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération des livraisons: %w", err)
+	}
+	delivered := make(map[string]bool, len(existing))
+	for _, d := range existing {
+		delivered[d.EndpointID] = true
+	}
+
+	payload, err := json.Marshal(webhookEnvelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.OccurredAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("événement %s invalide: %w", event.ID, err)
+	}
+
+	var txs []db.PrismaTransaction
+	for _, endpoint := range endpoints {
+		if delivered[endpoint.ID] {
+			continue
+		}
+		txs = append(txs, client.WebhookDelivery.CreateOne(
+			db.WebhookDelivery.EventID.Set(event.ID),
+			db.WebhookDelivery.EventType.Set(event.Type),
+			db.WebhookDelivery.Payload.Set(db.JSON(payload)),
+			db.WebhookDelivery.Endpoint.Link(db.WebhookEndpoint.ID.Equals(endpoint.ID)),
+		).Tx())
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return fmt.Errorf("erreur lors de la création des livraisons: %w", err)
+	}
+	return nil
+}
+
+// DeliverWebhooks envoie les livraisons en attente des destinations actives, jusqu'à vider la file.
+// Une livraison en échec est retentée avec un délai exponentiel, puis abandonnée après WEBHOOK_MAX_ATTEMPTS tentatives ;
+// une destination est désactivée après WEBHOOK_DISABLE_AFTER_FAILURES tentatives échouées d'affilée.
+// Retourne le nombre de livraisons réussies.
+func DeliverWebhooks(client *db.PrismaClient, sender *webhooks.Sender) (int, error) {
+	ctx := context.Background()
+
+	succeeded := 0
+	for {
+		var rows []struct {
+			ID         db.RawString `json:"id"`
+			EventType  db.RawString `json:"eventType"`
+			Payload    db.RawString `json:"payload"`
+			Attempts   db.RawInt    `json:"attempts"`
+			EndpointID db.RawString `json:"endpointID"`
+			URL        db.RawString `json:"url"`
+			Secret     db.RawString `json:"secret"`
+		}
+		err := client.Prisma.QueryRaw(
+			`UPDATE "WebhookDelivery" d
+			SET "attempts" = d."attempts" + 1, "nextAttemptAt" = NOW() + ($2 * INTERVAL '1 second')
+			FROM "WebhookEndpoint" e
+			WHERE e."id" = d."endpointID" AND d."id" IN (
+				SELECT pending."id" FROM "WebhookDelivery" pending
+				JOIN "WebhookEndpoint" endpoint ON endpoint."id" = pending."endpointID"
+				WHERE pending."status" = 'PENDING' AND pending."nextAttemptAt" <= NOW() AND endpoint."active"
+				ORDER BY pending."createdAt"
+				LIMIT $1
+				FOR UPDATE OF pending SKIP LOCKED
+			)
+			RETURNING d."id", d."eventType", d."payload"::text AS "payload", d."attempts", e."id" AS "endpointID", e."url", e."secret"`,
+			webhookBatchSize, int(webhookLease.Seconds()),
+		).Exec(ctx, &rows)
+		if err != nil {
+			return succeeded, fmt.Errorf("erreur lors de la réservation des livraisons de webhooks: %w", err)
+		}
+
+		for _, row := range rows {
+			result := sender.Send(ctx, string(row.URL), string(row.Secret), string(row.ID), string(row.EventType), []byte(row.Payload))
+			if err := recordWebhookAttempt(ctx, client, string(row.ID), string(row.EndpointID), int(row.Attempts), result); err != nil {
+				return succeeded, err
+			}
+			if result.OK() {
+				succeeded++
+			}
+		}
+
+		if len(rows) < webhookBatchSize {
+			return succeeded, nil
+		}
+	}
+}
+
+// recordWebhookAttempt enregistre le résultat d'une tentative sur la livraison et sur le compteur d'échecs de la destination
+func recordWebhookAttempt(ctx context.Context, client *db.PrismaClient, deliveryID, endpointID string, attempt int, result webhooks.Result) error {
+	now := time.Now()
+	params := []db.WebhookDeliverySetParam{
+		db.WebhookDelivery.LastAttemptAt.Set(now),
+		db.WebhookDelivery.DurationMs.Set(int(result.Duration.Milliseconds())),
+		db.WebhookDelivery.ResponseBody.Set(result.Body),
+	}
+	if result.StatusCode != 0 {
+		params = append(params, db.WebhookDelivery.ResponseStatus.Set(result.StatusCode))
+	} else {
+		params = append(params, db.WebhookDelivery.ResponseStatus.SetOptional(nil))
+	}
+
+	var endpointTx db.PrismaTransaction
+	switch {
+	case result.OK():
+		params = append(params,
+			db.WebhookDelivery.Status.Set(db.WebhookDeliveryStatusSucceeded),
+			db.WebhookDelivery.DeliveredAt.Set(now),
+			db.WebhookDelivery.LastError.SetOptional(nil),
+		)
+		endpointTx = client.WebhookEndpoint.FindUnique(
+			db.WebhookEndpoint.ID.Equals(endpointID),
+		).Update(
+			db.WebhookEndpoint.ConsecutiveFailures.Set(0),
+		).Tx()
+	default:
+		params = append(params, db.WebhookDelivery.LastError.Set(result.Err.Error()))
+		if attempt >= webhookMaxAttempts() {
+			params = append(params, db.WebhookDelivery.Status.Set(db.WebhookDeliveryStatusFailed))
+		} else {
+			params = append(params, db.WebhookDelivery.NextAttemptAt.Set(now.Add(webhookBackoff(attempt))))
+		}
+		// Compteur et désactivation dans la même requête : pas de course entre deux instances
+		endpointTx = client.Prisma.ExecuteRaw(
+			`UPDATE "WebhookEndpoint" SET
+				"consecutiveFailures" = "consecutiveFailures" + 1,
+				"active" = CASE WHEN "consecutiveFailures" + 1 >= $2 THEN false ELSE "active" END,
+				"disabledAt" = CASE WHEN "active" AND "consecutiveFailures" + 1 >= $2 THEN CURRENT_TIMESTAMP ELSE "disabledAt" END,
+				"updatedAt" = CURRENT_TIMESTAMP
+			WHERE "id" = $1`,
+			endpointID, webhookDisableAfter(),
+		).Tx()
+		log.Printf("webhooks: échec de la tentative %d de la livraison %s: %v", attempt, deliveryID, result.Err)
+	}
+
+	err := client.Prisma.Transaction(
+		client.WebhookDelivery.FindUnique(
+			db.WebhookDelivery.ID.Equals(deliveryID),
+		).Update(params...).Tx(),
+		endpointTx,
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'enregistrement de la livraison %s: %w", deliveryID, err)
+	}
+	return nil
+}
+
+// findWebhookEndpoint récupère une destination par son ID
+func findWebhookEndpoint(client *db.PrismaClient, endpointID string) (*db.WebhookEndpointModel, error) {
+	endpoint, err := client.WebhookEndpoint.FindUnique(
+		db.WebhookEndpoint.ID.Equals(endpointID),
+	).Exec(context.Background())
+	if err != nil || endpoint == nil {
+		return nil, fmt.Errorf("webhook non trouvé")
+	}
+	return endpoint, nil
+}
+
+// validateWebhookEndpointRequest valide l'URL et retourne les types d'événements dédoublonnés
+func validateWebhookEndpointRequest(req dtos.WebhookEndpointRequest) ([]string, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("URL de webhook invalide (http ou https requis)")
+	}
+
+	eventTypes := uniqueStrings(req.Events)
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("au moins un type d'événement est requis")
+	}
+	for _, eventType := range eventTypes {
+		if eventType != webhookAllEvents && !slices.Contains(events.Types, eventType) {
+			return nil, fmt.Errorf("type d'événement inconnu: %s", eventType)
+		}
+	}
+	return eventTypes, nil
+}
+
+// convertWebhookEndpointToDTO convertit un WebhookEndpointModel en WebhookEndpointResponse
+func convertWebhookEndpointToDTO(endpoint *db.WebhookEndpointModel) *dtos.WebhookEndpointResponse {
+	response := &dtos.WebhookEndpointResponse{
+		ID:                  endpoint.ID,
+		URL:                 endpoint.URL,
+		Description:         endpoint.Description,
+		Events:              endpoint.EventTypes,
+		Active:              endpoint.Active,
+		ConsecutiveFailures: endpoint.ConsecutiveFailures,
+		CreatedAt:           endpoint.CreatedAt,
+		UpdatedAt:           endpoint.UpdatedAt,
+	}
+	if disabledAt, ok := endpoint.DisabledAt(); ok {
+		value := time.Time(disabledAt)
+		response.DisabledAt = &value
+	}
+	return response
+}
+
+// convertWebhookEndpointToSecretDTO convertit un WebhookEndpointModel en WebhookEndpointSecretResponse, clé comprise
+func convertWebhookEndpointToSecretDTO(endpoint *db.WebhookEndpointModel) *dtos.WebhookEndpointSecretResponse {
+	return &dtos.WebhookEndpointSecretResponse{
+		WebhookEndpointResponse: *convertWebhookEndpointToDTO(endpoint),
+		Secret:                  endpoint.Secret,
+	}
+}
+
+// convertWebhookDeliveryToDTO convertit un WebhookDeliveryModel en WebhookDeliveryResponse
+func convertWebhookDeliveryToDTO(delivery *db.WebhookDeliveryModel) *dtos.WebhookDeliveryResponse {
+	response := &dtos.WebhookDeliveryResponse{
+		ID:         delivery.ID,
+		EndpointID: delivery.EndpointID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Status:     string(delivery.Status),
+		Attempts:   delivery.Attempts,
+		CreatedAt:  delivery.CreatedAt,
+	}
+	if delivery.Status == db.WebhookDeliveryStatusPending {
+		next := delivery.NextAttemptAt
+		response.NextAttemptAt = &next
+	}
+	if v, ok := delivery.LastAttemptAt(); ok {
+		value := time.Time(v)
+		response.LastAttemptAt = &value
+	}
+	if v, ok := delivery.ResponseStatus(); ok {
+		value := int(v)
+		response.ResponseStatus = &value
+	}
+	if v, ok := delivery.ResponseBody(); ok {
+		response.ResponseBody = string(v)
+	}
+	if v, ok := delivery.LastError(); ok {
+		response.LastError = string(v)
+	}
+	if v, ok := delivery.DurationMs(); ok {
+		value := int(v)
+		response.DurationMs = &value
+	}
+	if v, ok := delivery.DeliveredAt(); ok {
+		value := time.Time(v)
+		response.DeliveredAt = &value
+	}
+	if v, ok := delivery.RedeliveryOf(); ok {
+		response.RedeliveryOf = string(v)
+	}
+	return response
+}
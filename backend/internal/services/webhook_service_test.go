@@ -0,0 +1,350 @@
+package services
+
+import (
+	"api/internal/db"
+	"api/internal/dtos"
+	"api/internal/events"
+	"api/internal/webhooks"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Les tests de livraison utilisent une base PostgreSQL dédiée, migrations appliquées (TEST_DATABASE_URL) :
+// DeliverWebhooks envoie toutes les livraisons en attente de la base, il ne doit jamais tourner sur une base réelle.
+
+// newWebhookTestClient connecte le client Prisma à la base de test, ou ignore le test sans TEST_DATABASE_URL
+func newWebhookTestClient(t *testing.T) *db.PrismaClient {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL non défini : tests de livraison des webhooks ignorés")
+	}
+	client := db.NewClient(db.WithDatasourceURL(url))
+	if err := client.Connect(); err != nil {
+		t.Fatalf("connexion à la base de test: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Disconnect()
+	})
+	return client
+}
+
+// webhookReceiver est un destinataire local : il vérifie la signature, note les événements reçus et répond avec status
+type webhookReceiver struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	secret   string
+	status   int
+	eventIDs []string
+}
+
+func newWebhookReceiver(t *testing.T, status int) *webhookReceiver {
+	t.Helper()
+
+	receiver := &webhookReceiver{status: status}
+	receiver.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+		if err := webhooks.Verify(receiver.secret, r.Header.Get(webhooks.SignatureHeader), body, time.Minute); err != nil {
+			t.Errorf("signature reçue invalide: %v", err)
+		}
+		var envelope webhookEnvelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			t.Errorf("corps reçu invalide: %v", err)
+		}
+		receiver.eventIDs = append(receiver.eventIDs, envelope.ID)
+		w.WriteHeader(receiver.status)
+	}))
+	t.Cleanup(receiver.server.Close)
+	return receiver
+}
+
+// received retourne les identifiants des événements reçus, dans l'ordre
+func (r *webhookReceiver) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.eventIDs...)
+}
+
+// createTestWebhookEndpoint crée une destination vers receiver, supprimée à la fin du test
+func createTestWebhookEndpoint(t *testing.T, client *db.PrismaClient, receiver *webhookReceiver) string {
+	t.Helper()
+
+	endpoint, err := CreateWebhookEndpoint(client, dtos.WebhookEndpointRequest{
+		URL:    receiver.server.URL,
+		Events: []string{events.OrderCreated},
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhookEndpoint() = %v", err)
+	}
+	t.Cleanup(func() {
+		if err := DeleteWebhookEndpoint(client, endpoint.ID); err != nil {
+			t.Errorf("DeleteWebhookEndpoint() = %v", err)
+		}
+	})
+
+	receiver.mu.Lock()
+	receiver.secret = endpoint.Secret
+	receiver.mu.Unlock()
+	return endpoint.ID
+}
+
+// enqueueTestWebhookEvent publie un événement order.created et retourne la livraison créée pour la destination
+func enqueueTestWebhookEvent(t *testing.T, client *db.PrismaClient, endpointID string) *db.WebhookDeliveryModel {
+	t.Helper()
+
+	event := events.Event{
+		ID:          newID(),
+		Type:        events.OrderCreated,
+		AggregateID: "order-test",
+		Payload:     json.RawMessage(`{"orderID":"order-test","userID":"user-test","totalAmount":42}`),
+		OccurredAt:  time.Now(),
+		Attempt:     1,
+	}
+	if err := enqueueWebhookDeliveries(context.Background(), client, event); err != nil {
+		t.Fatalf("enqueueWebhookDeliveries() = %v", err)
+	}
+
+	deliveries, err := client.WebhookDelivery.FindMany(
+		db.WebhookDelivery.EndpointID.Equals(endpointID),
+		db.WebhookDelivery.EventID.Equals(event.ID),
+	).Exec(context.Background())
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("livraison de l'événement %s: %d trouvée(s), err = %v", event.ID, len(deliveries), err)
+	}
+	return &deliveries[0]
+}
+
+// deliverTestWebhooks lance un passage d'envoi
+func deliverTestWebhooks(t *testing.T, client *db.PrismaClient) {
+	t.Helper()
+
+	if _, err := DeliverWebhooks(client, webhooks.NewSender(5*time.Second)); err != nil {
+		t.Fatalf("DeliverWebhooks() = %v", err)
+	}
+}
+
+// findTestWebhookDelivery relit une livraison
+func findTestWebhookDelivery(t *testing.T, client *db.PrismaClient, deliveryID string) *db.WebhookDeliveryModel {
+	t.Helper()
+
+	delivery, err := client.WebhookDelivery.FindUnique(
+		db.WebhookDelivery.ID.Equals(deliveryID),
+	).Exec(context.Background())
+	if err != nil {
+		t.Fatalf("livraison %s: %v", deliveryID, err)
+	}
+	return delivery
+}
+
+// makeTestWebhookDeliveryDue rend une livraison en attente immédiatement éligible à une nouvelle tentative
+func makeTestWebhookDeliveryDue(t *testing.T, client *db.PrismaClient, deliveryID string) {
+	t.Helper()
+
+	_, err := client.WebhookDelivery.FindUnique(
+		db.WebhookDelivery.ID.Equals(deliveryID),
+	).Update(
+		db.WebhookDelivery.NextAttemptAt.Set(time.Now().Add(-time.Minute)),
+	).Exec(context.Background())
+	if err != nil {
+		t.Fatalf("livraison %s: %v", deliveryID, err)
+	}
+}
+
+// assertNextAttempt vérifie que la prochaine tentative est programmée backoff après une tentative faite entre before et after
+func assertNextAttempt(t *testing.T, delivery *db.WebhookDeliveryModel, before, after time.Time, backoff time.Duration) {
+	t.Helper()
+
+	earliest := before.Add(backoff - time.Second)
+	latest := after.Add(backoff + time.Second)
+	if delivery.NextAttemptAt.Before(earliest) || delivery.NextAttemptAt.After(latest) {
+		t.Fatalf("nextAttemptAt = %s, attendu entre %s et %s (délai %s)", delivery.NextAttemptAt, earliest, latest, backoff)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{6, 16 * time.Minute},
+		{20, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := webhookBackoff(tt.attempt); got != tt.want {
+			t.Errorf("webhookBackoff(%d) = %s, %s attendu", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestDeliverWebhooksMarksDelivered(t *testing.T) {
+	client := newWebhookTestClient(t)
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	endpointID := createTestWebhookEndpoint(t, client, receiver)
+	delivery := enqueueTestWebhookEvent(t, client, endpointID)
+
+	deliverTestWebhooks(t, client)
+
+	delivery = findTestWebhookDelivery(t, client, delivery.ID)
+	if delivery.Status != db.WebhookDeliveryStatusSucceeded {
+		t.Fatalf("status = %s, SUCCEEDED attendu", delivery.Status)
+	}
+	if delivery.Attempts != 1 {
+		t.Fatalf("attempts = %d, 1 attendu", delivery.Attempts)
+	}
+	if _, ok := delivery.DeliveredAt(); !ok {
+		t.Fatal("deliveredAt absent")
+	}
+	if status, ok := delivery.ResponseStatus(); !ok || int(status) != http.StatusOK {
+		t.Fatalf("responseStatus = %v, 200 attendu", status)
+	}
+	if got := receiver.received(); len(got) != 1 || got[0] != delivery.EventID {
+		t.Fatalf("événements reçus = %v, [%s] attendu", got, delivery.EventID)
+	}
+}
+
+func TestDeliverWebhooksRetriesWithBackoff(t *testing.T) {
+	t.Setenv("WEBHOOK_DISABLE_AFTER_FAILURES", "100")
+
+	client := newWebhookTestClient(t)
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError)
+	endpointID := createTestWebhookEndpoint(t, client, receiver)
+	delivery := enqueueTestWebhookEvent(t, client, endpointID)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		before := time.Now()
+		deliverTestWebhooks(t, client)
+		after := time.Now()
+
+		delivery = findTestWebhookDelivery(t, client, delivery.ID)
+		if delivery.Status != db.WebhookDeliveryStatusPending {
+			t.Fatalf("tentative %d: status = %s, PENDING attendu", attempt, delivery.Status)
+		}
+		if delivery.Attempts != attempt {
+			t.Fatalf("tentative %d: attempts = %d", attempt, delivery.Attempts)
+		}
+		if status, ok := delivery.ResponseStatus(); !ok || int(status) != http.StatusInternalServerError {
+			t.Fatalf("tentative %d: responseStatus = %v, 500 attendu", attempt, status)
+		}
+		assertNextAttempt(t, delivery, before, after, webhookBackoff(attempt))
+
+		// Sans rien changer, la livraison n'est pas encore due : un nouveau passage ne l'envoie pas
+		deliverTestWebhooks(t, client)
+		if got := len(receiver.received()); got != attempt {
+			t.Fatalf("tentative %d: %d envois reçus avant l'échéance", attempt, got)
+		}
+		makeTestWebhookDeliveryDue(t, client, delivery.ID)
+	}
+
+	endpoint, err := findWebhookEndpoint(client, endpointID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.ConsecutiveFailures != 3 || !endpoint.Active {
+		t.Fatalf("destination: consecutiveFailures = %d, active = %t ; 3 et true attendus", endpoint.ConsecutiveFailures, endpoint.Active)
+	}
+}
+
+func TestDeliverWebhooksDisablesEndpointAfterFailures(t *testing.T) {
+	t.Setenv("WEBHOOK_DISABLE_AFTER_FAILURES", "2")
+
+	client := newWebhookTestClient(t)
+	receiver := newWebhookReceiver(t, http.StatusServiceUnavailable)
+	endpointID := createTestWebhookEndpoint(t, client, receiver)
+	delivery := enqueueTestWebhookEvent(t, client, endpointID)
+
+	deliverTestWebhooks(t, client)
+	makeTestWebhookDeliveryDue(t, client, delivery.ID)
+	deliverTestWebhooks(t, client)
+
+	endpoint, err := findWebhookEndpoint(client, endpointID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.Active {
+		t.Fatal("destination toujours active après 2 échecs d'affilée")
+	}
+	if _, ok := endpoint.DisabledAt(); !ok {
+		t.Fatal("disabledAt absent")
+	}
+
+	// Une destination désactivée ne reçoit plus rien ; ses livraisons restent en attente
+	makeTestWebhookDeliveryDue(t, client, delivery.ID)
+	deliverTestWebhooks(t, client)
+	if got := len(receiver.received()); got != 2 {
+		t.Fatalf("%d envois reçus, 2 attendus", got)
+	}
+	if delivery = findTestWebhookDelivery(t, client, delivery.ID); delivery.Status != db.WebhookDeliveryStatusPending {
+		t.Fatalf("status = %s, PENDING attendu", delivery.Status)
+	}
+	if _, err := RedeliverWebhook(client, endpointID, delivery.ID); err == nil || err.Error() != "webhook désactivé : réactivez-le avant de relivrer" {
+		t.Fatalf("RedeliverWebhook() = %v, refus attendu sur une destination désactivée", err)
+	}
+
+	// Réactiver remet le compteur à zéro et reprend les livraisons en attente
+	active := true
+	_, err = UpdateWebhookEndpoint(client, endpointID, dtos.WebhookEndpointRequest{
+		URL:    receiver.server.URL,
+		Events: []string{events.OrderCreated},
+		Active: &active,
+	})
+	if err != nil {
+		t.Fatalf("UpdateWebhookEndpoint() = %v", err)
+	}
+	receiver.mu.Lock()
+	receiver.status = http.StatusOK
+	receiver.mu.Unlock()
+
+	deliverTestWebhooks(t, client)
+	if delivery = findTestWebhookDelivery(t, client, delivery.ID); delivery.Status != db.WebhookDeliveryStatusSucceeded {
+		t.Fatalf("status après réactivation = %s, SUCCEEDED attendu", delivery.Status)
+	}
+}
+
+func TestRedeliverWebhookCreatesDelivery(t *testing.T) {
+	client := newWebhookTestClient(t)
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	endpointID := createTestWebhookEndpoint(t, client, receiver)
+	original := enqueueTestWebhookEvent(t, client, endpointID)
+	deliverTestWebhooks(t, client)
+
+	redelivery, err := RedeliverWebhook(client, endpointID, original.ID)
+	if err != nil {
+		t.Fatalf("RedeliverWebhook() = %v", err)
+	}
+	if redelivery.ID == original.ID {
+		t.Fatal("la relivraison réutilise la livraison d'origine")
+	}
+	if redelivery.RedeliveryOf != original.ID || redelivery.EventID != original.EventID {
+		t.Fatalf("relivraison: redeliveryOf = %s, eventID = %s ; %s et %s attendus", redelivery.RedeliveryOf, redelivery.EventID, original.ID, original.EventID)
+	}
+	if redelivery.Status != string(db.WebhookDeliveryStatusPending) {
+		t.Fatalf("relivraison: status = %s, PENDING attendu", redelivery.Status)
+	}
+
+	deliverTestWebhooks(t, client)
+
+	if got := receiver.received(); len(got) != 2 || got[0] != original.EventID || got[1] != original.EventID {
+		t.Fatalf("événements reçus = %v, deux fois %s attendu", got, original.EventID)
+	}
+	if d := findTestWebhookDelivery(t, client, redelivery.ID); d.Status != db.WebhookDeliveryStatusSucceeded {
+		t.Fatalf("relivraison: status = %s, SUCCEEDED attendu", d.Status)
+	}
+	if d := findTestWebhookDelivery(t, client, original.ID); d.Attempts != 1 {
+		t.Fatalf("livraison d'origine: attempts = %d, 1 attendu", d.Attempts)
+	}
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+)
+
+// BlobStore abstrait le stockage des fichiers binaires (images produits, ...)
+// Les clés sont des chemins relatifs utilisant "/" comme séparateur (ex: "products/<id>/abc.jpg")
+type BlobStore interface {
+	// Put enregistre le contenu de r sous la clé donnée (écrase le fichier existant)
+	Put(key string, r io.Reader, contentType string) error
+	// Delete supprime le fichier associé à la clé (aucune erreur s'il n'existe pas)
+	Delete(key string) error
+	// URL retourne l'URL publique permettant de télécharger le fichier
+	URL(key string) string
+}
+
+// NewKeyToken génère un identifiant aléatoire utilisable dans une clé de stockage
+func NewKeyToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
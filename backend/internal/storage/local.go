@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore stocke les fichiers sur le disque local et les sert via une route statique
+type LocalBlobStore struct {
+	root    string // Dossier racine sur le disque
+	baseURL string // Préfixe des URLs publiques (ex: "/media")
+}
+
+// NewLocalBlobStore crée le dossier racine si nécessaire et retourne le store
+func NewLocalBlobStore(root, baseURL string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("impossible de créer le dossier de stockage %s: %w", root, err)
+	}
+	return &LocalBlobStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// NewLocalBlobStoreFromEnv configure le store depuis MEDIA_ROOT et MEDIA_BASE_URL
+func NewLocalBlobStoreFromEnv() (*LocalBlobStore, error) {
+	root := os.Getenv("MEDIA_ROOT")
+	if root == "" {
+		root = "./uploads"
+	}
+	baseURL := os.Getenv("MEDIA_BASE_URL")
+	if baseURL == "" {
+		baseURL = "/media"
+	}
+	return NewLocalBlobStore(root, baseURL)
+}
+
+// path convertit une clé en chemin sur le disque en refusant toute sortie du dossier racine
+func (s *LocalBlobStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" {
+		return "", fmt.Errorf("clé de stockage invalide: %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
+
+// Put enregistre le fichier sur le disque (écriture atomique via un fichier temporaire)
+func (s *LocalBlobStore) Put(key string, r io.Reader, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("impossible de créer le dossier: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("impossible de créer le fichier: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erreur lors de l'écriture du fichier: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erreur lors de l'écriture du fichier: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+// Delete supprime le fichier du disque
+func (s *LocalBlobStore) Delete(key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erreur lors de la suppression du fichier: %w", err)
+	}
+	return nil
+}
+
+// URL retourne l'URL publique du fichier
+func (s *LocalBlobStore) URL(key string) string {
+	return s.baseURL + path.Clean("/"+key)
+}
+
+// BaseURL retourne le préfixe des URLs publiques, à utiliser pour monter Handler()
+func (s *LocalBlobStore) BaseURL() string {
+	return s.baseURL
+}
+
+// Handler sert les fichiers stockés avec des en-têtes de cache longue durée
+// Les clés contiennent un identifiant aléatoire : un fichier n'est jamais modifié, seulement remplacé par une nouvelle clé
+func (s *LocalBlobStore) Handler() http.Handler {
+	fs := http.StripPrefix(s.baseURL, http.FileServer(http.Dir(s.root)))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Seuls les fichiers existants sont servis (pas de listing des dossiers)
+		p, err := s.path(strings.TrimPrefix(r.URL.Path, s.baseURL))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if info, err := os.Stat(p); err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		fs.ServeHTTP(w, r)
+	})
+}
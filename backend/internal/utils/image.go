@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"image"
+	"image/draw"
+)
+
+// ResizeImage réduit une image pour qu'elle tienne dans un carré de maxSize pixels (ratio conservé)
+// L'image n'est jamais agrandie. La réduction utilise une moyenne par zone (box filter),
+// ce qui donne des miniatures nettes sans dépendance externe.
+func ResizeImage(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW <= maxSize && srcH <= maxSize {
+		return src
+	}
+
+	dstW, dstH := maxSize, maxSize
+	if srcW > srcH {
+		dstH = srcH * maxSize / srcW
+	} else {
+		dstW = srcW * maxSize / srcH
+	}
+	if dstW < 1 {
+		dstW = 1
+	}
+	if dstH < 1 {
+		dstH = 1
+	}
+
+	// Convertir en NRGBA pour accéder directement aux pixels
+	in := image.NewNRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(in, in.Bounds(), src, bounds.Min, draw.Src)
+
+	out := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := y * srcH / dstH
+		y1 := (y + 1) * srcH / dstH
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dstW; x++ {
+			x0 := x * srcW / dstW
+			x1 := (x + 1) * srcW / dstW
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			// Moyenne des pixels de la zone source, pondérée par l'alpha
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := in.Pix[sy*in.Stride : sy*in.Stride+srcW*4]
+				for sx := x0; sx < x1; sx++ {
+					px := row[sx*4 : sx*4+4]
+					alpha := uint64(px[3])
+					r += uint64(px[0]) * alpha
+					g += uint64(px[1]) * alpha
+					b += uint64(px[2]) * alpha
+					a += alpha
+					n++
+				}
+			}
+
+			i := y*out.Stride + x*4
+			if a > 0 {
+				out.Pix[i] = uint8(r / a)
+				out.Pix[i+1] = uint8(g / a)
+				out.Pix[i+2] = uint8(b / a)
+			}
+			out.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// accentReplacer remplace les lettres accentuées courantes par leur équivalent ASCII
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "á", "a", "ã", "a", "å", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ñ", "n",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ý", "y", "ÿ", "y",
+	"œ", "oe", "æ", "ae",
+)
+
+// Slugify convertit un libellé en identifiant d'URL
+// Exemple: "Soins du Visage & Corps" -> "soins-du-visage-corps"
+func Slugify(value string) string {
+	value = accentReplacer.Replace(strings.ToLower(value))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range value {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		// Tout autre caractère sépare deux mots
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,138 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// En-têtes des webhooks envoyés aux intégrations
+const (
+	SignatureHeader = "Webhook-Signature" // "t=<timestamp unix>,v1=<hex HMAC-SHA256(secret, "<timestamp>.<corps>")>"
+	EventHeader     = "Webhook-Event"     // Type de l'événement (ex: order.created)
+	DeliveryHeader  = "Webhook-Delivery"  // Identifiant de la livraison (unique par tentative manuelle)
+)
+
+// maxResponseBody est la taille maximale de la réponse du destinataire conservée dans le journal
+const maxResponseBody = 2048
+
+// ErrInvalidSignature est retournée par Verify quand la signature est absente, invalide ou expirée
+var ErrInvalidSignature = errors.New("signature de webhook invalide")
+
+// Result est le résultat d'un envoi
+type Result struct {
+	StatusCode int           // Code HTTP de la réponse (0 si aucune réponse)
+	Body       string        // Début de la réponse du destinataire
+	Duration   time.Duration // Durée de l'appel
+	Err        error         // Erreur réseau, ou réponse hors 2xx
+}
+
+// OK indique si le destinataire a accusé réception (réponse 2xx)
+func (r Result) OK() bool {
+	return r.Err == nil
+}
+
+// Sender envoie les webhooks signés
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender crée un expéditeur dont les appels expirent après timeout
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+// NewSenderFromEnv configure l'expéditeur depuis WEBHOOK_TIMEOUT_SECONDS (défaut: 10)
+func NewSenderFromEnv() *Sender {
+	timeout := 10 * time.Second
+	if v, err := strconv.Atoi(os.Getenv("WEBHOOK_TIMEOUT_SECONDS")); err == nil && v > 0 {
+		timeout = time.Duration(v) * time.Second
+	}
+	return NewSender(timeout)
+}
+
+// Send poste body (JSON) à url, signé avec secret
+func (s *Sender) Send(ctx context.Context, url, secret, deliveryID, eventType string, body []byte) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return Result{Err: fmt.Errorf("URL invalide: %w", err)}
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PORELO-Webhooks/1.0")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, "t="+timestamp+",v1="+Sign(secret, timestamp, body))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	result := Result{Duration: time.Since(start)}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result.StatusCode = resp.StatusCode
+	result.Body = string(data)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result.Err = fmt.Errorf("réponse HTTP %d", resp.StatusCode)
+	}
+	return result
+}
+
+// Sign calcule la signature HMAC-SHA256 hexadécimale de "<timestamp>.<body>"
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify vérifie l'en-tête SignatureHeader d'un webhook reçu, pour les intégrations écrites en Go
+// Un horodatage plus éloigné que tolerance de l'heure courante est refusé (rejeu).
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewSecret génère une clé de signature aléatoire (préfixe whsec_)
+func NewSecret() string {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return "whsec_" + hex.EncodeToString(b[:])
+}
@@ -0,0 +1,142 @@
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_test"
+
+// signatureHeader construit un en-tête SignatureHeader pour un horodatage donné
+func signatureHeader(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + Sign(secret, timestamp, body)
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"order.created"}`)
+	header := signatureHeader(testSecret, time.Now(), body)
+
+	if err := Verify(testSecret, header, body, 5*time.Minute); err != nil {
+		t.Fatalf("Verify() = %v, signature valide attendue", err)
+	}
+}
+
+func TestVerifyRejectsInvalidSignature(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+	}{
+		{"corps modifié", signatureHeader(testSecret, now, body), []byte(`{"id":"evt_2"}`)},
+		{"mauvaise clé", signatureHeader("whsec_autre", now, body), body},
+		{"en-tête vide", "", body},
+		{"signature absente", "t=" + strconv.FormatInt(now.Unix(), 10), body},
+		{"horodatage invalide", "t=abc,v1=" + Sign(testSecret, "abc", body), body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(testSecret, tt.header, tt.body, 5*time.Minute); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("Verify() = %v, ErrInvalidSignature attendue", err)
+			}
+		})
+	}
+}
+
+func TestVerifyTimestampTolerance(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	tolerance := 5 * time.Minute
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		valid bool
+	}{
+		{"dans la tolérance", time.Now().Add(-4 * time.Minute), true},
+		{"légèrement dans le futur", time.Now().Add(time.Minute), true},
+		{"trop ancien (rejeu)", time.Now().Add(-6 * time.Minute), false},
+		{"trop loin dans le futur", time.Now().Add(6 * time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(testSecret, signatureHeader(testSecret, tt.at, body), body, tolerance)
+			if tt.valid && err != nil {
+				t.Fatalf("Verify() = %v, signature valide attendue", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("Verify() = %v, ErrInvalidSignature attendue", err)
+			}
+		})
+	}
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"order.created"}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+		if err := Verify(testSecret, r.Header.Get(SignatureHeader), received, time.Minute); err != nil {
+			t.Errorf("signature reçue invalide: %v", err)
+		}
+		if got := r.Header.Get(EventHeader); got != "order.created" {
+			t.Errorf("%s = %q, order.created attendu", EventHeader, got)
+		}
+		if got := r.Header.Get(DeliveryHeader); got != "dlv_1" {
+			t.Errorf("%s = %q, dlv_1 attendu", DeliveryHeader, got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, application/json attendu", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	result := NewSender(5*time.Second).Send(t.Context(), server.URL, testSecret, "dlv_1", "order.created", body)
+	if !result.OK() {
+		t.Fatalf("Send() en échec: %v", result.Err)
+	}
+	if result.StatusCode != http.StatusNoContent {
+		t.Fatalf("StatusCode = %d, %d attendu", result.StatusCode, http.StatusNoContent)
+	}
+}
+
+func TestSendServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, strings.Repeat("x", 2*maxResponseBody), http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	result := NewSender(5*time.Second).Send(t.Context(), server.URL, testSecret, "dlv_1", "order.created", []byte(`{}`))
+	if result.OK() {
+		t.Fatal("Send() réussi, échec attendu sur une réponse 500")
+	}
+	if result.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("StatusCode = %d, %d attendu", result.StatusCode, http.StatusInternalServerError)
+	}
+	if len(result.Body) != maxResponseBody {
+		t.Fatalf("len(Body) = %d, réponse tronquée à %d attendue", len(result.Body), maxResponseBody)
+	}
+}
+
+func TestSendUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	result := NewSender(time.Second).Send(t.Context(), url, testSecret, "dlv_1", "order.created", []byte(`{}`))
+	if result.OK() {
+		t.Fatal("Send() réussi, échec attendu sur un destinataire injoignable")
+	}
+	if result.StatusCode != 0 {
+		t.Fatalf("StatusCode = %d, 0 attendu sans réponse", result.StatusCode)
+	}
+}
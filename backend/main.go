@@ -63,6 +63,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -71,7 +72,14 @@ import (
 
 	_ "api/docs" // Documentation Swagger générée - nécessaire pour initialiser SwaggerInfo
 	"api/internal/db"
+	"api/internal/events"
+	"api/internal/mail"
+	"api/internal/payments"
+	"api/internal/realtime"
 	"api/internal/routes"
+	"api/internal/services"
+	"api/internal/storage"
+	"api/internal/webhooks"
 )
 
 func main() {
@@ -80,6 +88,66 @@ func main() {
 		log.Fatal("Erreur de connexion Prisma: ", err)
 	}
 
+	// Stockage des fichiers uploadés (images produits)
+	store, err := storage.NewLocalBlobStoreFromEnv()
+	if err != nil {
+		log.Fatal("Erreur de configuration du stockage: ", err)
+	}
+
+	// Prestataires de paiement
+	registry, err := payments.NewRegistryFromEnv()
+	if err != nil {
+		log.Fatal("Erreur de configuration des paiements: ", err)
+	}
+
+	// Purge périodique des clés d'idempotence expirées et des événements distribués
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := services.PurgeExpiredIdempotencyKeys(client); err != nil {
+				log.Print(err)
+			}
+			if _, err := services.PurgeProcessedOutboxEvents(client); err != nil {
+				log.Print(err)
+			}
+		}
+	}()
+
+	// E-mails transactionnels envoyés en arrière-plan
+	mailer, err := mail.NewMailerFromEnv()
+	if err != nil {
+		log.Fatal("Erreur de configuration des e-mails: ", err)
+	}
+	mailQueue := mail.NewQueue(mailer)
+
+	// Événements de domaine : abonnés internes
+	bus := events.NewBus()
+	services.SubscribeOrderNotifications(bus, client, mailQueue)
+	services.SubscribeReviewNotifications(bus, client, mailQueue)
+	services.SubscribeWebhooks(bus, client)
+
+	// Flux temps réel des commandes (SSE), alimenté par les événements
+	hub := realtime.NewHubFromEnv()
+	services.SubscribeOrderStream(bus, hub)
+
+	// Distribution des événements de l'outbox aux abonnés
+	go func() {
+		for range time.Tick(services.OutboxPollInterval()) {
+			if _, err := services.DispatchOutboxEvents(client, bus); err != nil {
+				log.Print(err)
+			}
+		}
+	}()
+
+	// Envoi des webhooks sortants (et nouvelles tentatives)
+	sender := webhooks.NewSenderFromEnv()
+	go func() {
+		for range time.Tick(5 * time.Second) {
+			if _, err := services.DeliverWebhooks(client, sender); err != nil {
+				log.Print(err)
+			}
+		}
+	}()
+
 	r := chi.NewRouter()
 
 	// Middleware de base
@@ -93,8 +161,8 @@ func main() {
 		// - React Native : "*" car les apps natives n'ont pas d'origine web classique
 		AllowedOrigins:   []string{"*"}, // Pour développement : accepte toutes les origines. En production, spécifiez vos domaines exacts
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Idempotency-Key", "Last-Event-ID"},
+		ExposedHeaders:   []string{"Link", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300, // Durée de cache pour les pré-requêtes OPTIONS (en secondes)
 	}))
@@ -105,13 +173,23 @@ func main() {
 		httpSwagger.DocExpansion("list"),
 	))
 
+	// Fichiers uploadés servis en statique (cache longue durée)
+	r.Handle(store.BaseURL()+"/*", store.Handler())
+
 	// Enregistrement des routes
 	routes.RegisterAuthRoutes(r, client)
-	routes.RegisterProductRoutes(r, client)
+	routes.RegisterProductRoutes(r, client, store)
+	routes.RegisterQuestionRoutes(r, client)
 	routes.RegisterCategoryRoutes(r, client)
-	routes.RegisterOrderRoutes(r, client)
+	routes.RegisterOrderRoutes(r, client, hub)
+	routes.RegisterCouponRoutes(r, client)
+	routes.RegisterShippingRoutes(r, client)
+	routes.RegisterPaymentRoutes(r, client, registry)
+	routes.RegisterReturnRoutes(r, client, registry)
+	routes.RegisterWebhookRoutes(r, client)
 	r.Mount("/", routes.ReviewRoutes(client))
 	routes.RegisterUserRoutes(r, client)
+	routes.RegisterFavoriteRoutes(r, client)
 
 	port := os.Getenv("PORT")
 	if port == "" {
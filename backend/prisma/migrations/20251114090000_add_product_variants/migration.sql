@@ -0,0 +1,45 @@
+-- AlterTable
+ALTER TABLE "OrderItem" ADD COLUMN     "variantID" TEXT,
+ADD COLUMN     "variantLabel" TEXT;
+
+-- CreateTable
+CREATE TABLE "ProductVariant" (
+    "id" TEXT NOT NULL,
+    "sku" TEXT,
+    "label" TEXT NOT NULL,
+    "price" DOUBLE PRECISION NOT NULL,
+    "stock" INTEGER NOT NULL DEFAULT 0,
+    "barcode" TEXT,
+    "isDefault" BOOLEAN NOT NULL DEFAULT false,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "productID" TEXT NOT NULL,
+
+    CONSTRAINT "ProductVariant_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "ProductVariant_sku_key" ON "ProductVariant"("sku");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "ProductVariant_barcode_key" ON "ProductVariant"("barcode");
+
+-- CreateIndex
+CREATE INDEX "ProductVariant_productID_idx" ON "ProductVariant"("productID");
+
+-- AddForeignKey
+ALTER TABLE "ProductVariant" ADD CONSTRAINT "ProductVariant_productID_fkey" FOREIGN KEY ("productID") REFERENCES "Product"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "OrderItem" ADD CONSTRAINT "OrderItem_variantID_fkey" FOREIGN KEY ("variantID") REFERENCES "ProductVariant"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- Migrate data: chaque produit existant reçoit une variante par défaut reprenant son SKU, son prix et son stock
+INSERT INTO "ProductVariant" ("id", "sku", "label", "price", "stock", "isDefault", "createdAt", "updatedAt", "productID")
+SELECT gen_random_uuid()::text, p."sku", 'Standard', p."price", p."stock", true, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, p."id"
+FROM "Product" p;
+
+-- Migrate data: les lignes de commande existantes pointent vers la variante par défaut
+UPDATE "OrderItem" oi
+SET "variantID" = v."id", "variantLabel" = v."label"
+FROM "ProductVariant" v
+WHERE v."productID" = oi."productID" AND v."isDefault" = true;
@@ -0,0 +1,12 @@
+-- CreateEnum
+CREATE TYPE "SkinType" AS ENUM ('DRY', 'OILY', 'COMBINATION', 'SENSITIVE');
+
+-- CreateEnum
+CREATE TYPE "SkinConcern" AS ENUM ('ACNE', 'AGING', 'HYPERPIGMENTATION', 'DEHYDRATION', 'REDNESS', 'PORES', 'DULLNESS');
+
+-- AlterTable
+ALTER TABLE "Product" ADD COLUMN     "concerns" "SkinConcern"[] DEFAULT ARRAY[]::"SkinConcern"[],
+ADD COLUMN     "ingredients" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "paoMonths" INTEGER,
+ADD COLUMN     "skinTypes" "SkinType"[] DEFAULT ARRAY[]::"SkinType"[],
+ADD COLUMN     "volumeML" INTEGER;
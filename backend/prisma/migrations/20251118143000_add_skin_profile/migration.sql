@@ -0,0 +1,18 @@
+-- CreateTable
+CREATE TABLE "SkinProfile" (
+    "id" TEXT NOT NULL,
+    "skinType" "SkinType",
+    "concerns" "SkinConcern"[] DEFAULT ARRAY[]::"SkinConcern"[],
+    "avoidedIngredients" TEXT[] DEFAULT ARRAY[]::TEXT[],
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "SkinProfile_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "SkinProfile_userID_key" ON "SkinProfile"("userID");
+
+-- AddForeignKey
+ALTER TABLE "SkinProfile" ADD CONSTRAINT "SkinProfile_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
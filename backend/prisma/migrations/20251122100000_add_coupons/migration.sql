@@ -0,0 +1,42 @@
+-- CreateEnum
+CREATE TYPE "CouponType" AS ENUM ('PERCENTAGE', 'FIXED', 'FREE_SHIPPING');
+
+-- CreateTable
+CREATE TABLE "Coupon" (
+    "id" TEXT NOT NULL,
+    "code" TEXT NOT NULL,
+    "description" TEXT,
+    "type" "CouponType" NOT NULL,
+    "value" DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "minOrderAmount" DOUBLE PRECISION,
+    "startsAt" TIMESTAMP(3),
+    "endsAt" TIMESTAMP(3),
+    "maxUses" INTEGER,
+    "maxUsesPerUser" INTEGER,
+    "active" BOOLEAN NOT NULL DEFAULT true,
+    "productIDs" TEXT[] DEFAULT ARRAY[]::TEXT[],
+    "categoryIDs" TEXT[] DEFAULT ARRAY[]::TEXT[],
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "Coupon_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "Coupon_code_key" ON "Coupon"("code");
+
+-- AlterTable
+ALTER TABLE "Order" ADD COLUMN     "subtotalAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "discountAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "freeShipping" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "couponCode" TEXT,
+ADD COLUMN     "couponID" TEXT;
+
+-- Les commandes existantes n'ont pas de remise : sous-total = total
+UPDATE "Order" SET "subtotalAmount" = "totalAmount";
+
+-- AlterTable
+ALTER TABLE "OrderItem" ADD COLUMN     "discountAmount" DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- AddForeignKey
+ALTER TABLE "Order" ADD CONSTRAINT "Order_couponID_fkey" FOREIGN KEY ("couponID") REFERENCES "Coupon"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,23 @@
+-- AlterTable
+ALTER TABLE "Category" ADD COLUMN     "taxRate" DOUBLE PRECISION;
+
+-- AlterTable
+ALTER TABLE "Order" ADD COLUMN     "netAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "taxAmount" DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- AlterTable
+ALTER TABLE "OrderItem" ADD COLUMN     "taxRate" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "netAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "taxAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "grossAmount" DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Commandes existantes : prix TTC au taux normal français (20 %)
+UPDATE "OrderItem" SET
+    "taxRate" = 20,
+    "grossAmount" = ROUND(("price" * "quantity" - "discountAmount")::numeric, 2),
+    "netAmount" = ROUND((("price" * "quantity" - "discountAmount") / 1.2)::numeric, 2);
+UPDATE "OrderItem" SET "taxAmount" = ROUND(("grossAmount" - "netAmount")::numeric, 2);
+
+UPDATE "Order" o SET
+    "netAmount" = COALESCE((SELECT SUM(i."netAmount") FROM "OrderItem" i WHERE i."orderID" = o."id"), 0),
+    "taxAmount" = COALESCE((SELECT SUM(i."taxAmount") FROM "OrderItem" i WHERE i."orderID" = o."id"), 0);
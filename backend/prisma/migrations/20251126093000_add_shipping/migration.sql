@@ -0,0 +1,66 @@
+-- CreateEnum
+CREATE TYPE "ShippingRateType" AS ENUM ('FLAT', 'WEIGHT');
+
+-- AlterTable
+ALTER TABLE "ProductVariant" ADD COLUMN     "weightGrams" INTEGER NOT NULL DEFAULT 0;
+
+-- CreateTable
+CREATE TABLE "Address" (
+    "id" TEXT NOT NULL,
+    "label" TEXT,
+    "fullName" TEXT NOT NULL,
+    "line1" TEXT NOT NULL,
+    "line2" TEXT,
+    "postalCode" TEXT NOT NULL,
+    "city" TEXT NOT NULL,
+    "country" TEXT NOT NULL DEFAULT 'FR',
+    "phone" TEXT,
+    "isDefault" BOOLEAN NOT NULL DEFAULT false,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "Address_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "ShippingMethod" (
+    "id" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT,
+    "type" "ShippingRateType" NOT NULL DEFAULT 'FLAT',
+    "price" DOUBLE PRECISION NOT NULL,
+    "pricePerKg" DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "freeAbove" DOUBLE PRECISION,
+    "estimatedDays" INTEGER,
+    "active" BOOLEAN NOT NULL DEFAULT true,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "ShippingMethod_pkey" PRIMARY KEY ("id")
+);
+
+-- AlterTable
+ALTER TABLE "Order" ADD COLUMN     "shippingFullName" TEXT,
+ADD COLUMN     "shippingLine1" TEXT,
+ADD COLUMN     "shippingLine2" TEXT,
+ADD COLUMN     "shippingPostalCode" TEXT,
+ADD COLUMN     "shippingCity" TEXT,
+ADD COLUMN     "shippingCountry" TEXT,
+ADD COLUMN     "shippingPhone" TEXT,
+ADD COLUMN     "shippingMethodName" TEXT,
+ADD COLUMN     "shippingAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "shippingTaxRate" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "shippingMethodID" TEXT;
+
+-- CreateIndex
+CREATE INDEX "Address_userID_idx" ON "Address"("userID");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "ShippingMethod_name_key" ON "ShippingMethod"("name");
+
+-- AddForeignKey
+ALTER TABLE "Address" ADD CONSTRAINT "Address_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Order" ADD CONSTRAINT "Order_shippingMethodID_fkey" FOREIGN KEY ("shippingMethodID") REFERENCES "ShippingMethod"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,77 @@
+-- CreateEnum
+CREATE TYPE "ReturnStatus" AS ENUM ('REQUESTED', 'APPROVED', 'REJECTED', 'RECEIVED');
+
+-- AlterTable
+ALTER TABLE "Order" ADD COLUMN     "deliveredAt" TIMESTAMP(3),
+ADD COLUMN     "refundedAmount" DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Les commandes déjà livrées prennent leur date de dernière mise à jour comme date de livraison
+UPDATE "Order" SET "deliveredAt" = "updatedAt" WHERE "status" = 'DELIVERED';
+
+-- CreateTable
+CREATE TABLE "ReturnRequest" (
+    "id" TEXT NOT NULL,
+    "status" "ReturnStatus" NOT NULL DEFAULT 'REQUESTED',
+    "reason" TEXT NOT NULL,
+    "adminNote" TEXT,
+    "refundAmount" DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "receivedAt" TIMESTAMP(3),
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "orderID" TEXT NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "ReturnRequest_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "ReturnItem" (
+    "id" TEXT NOT NULL,
+    "quantity" INTEGER NOT NULL,
+    "returnRequestID" TEXT NOT NULL,
+    "orderItemID" TEXT NOT NULL,
+
+    CONSTRAINT "ReturnItem_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "Refund" (
+    "id" TEXT NOT NULL,
+    "amount" DOUBLE PRECISION NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "orderID" TEXT NOT NULL,
+    "paymentID" TEXT,
+    "returnRequestID" TEXT,
+
+    CONSTRAINT "Refund_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "ReturnRequest_orderID_idx" ON "ReturnRequest"("orderID");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "Refund_returnRequestID_key" ON "Refund"("returnRequestID");
+
+-- CreateIndex
+CREATE INDEX "Refund_orderID_idx" ON "Refund"("orderID");
+
+-- AddForeignKey
+ALTER TABLE "ReturnRequest" ADD CONSTRAINT "ReturnRequest_orderID_fkey" FOREIGN KEY ("orderID") REFERENCES "Order"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "ReturnRequest" ADD CONSTRAINT "ReturnRequest_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "ReturnItem" ADD CONSTRAINT "ReturnItem_returnRequestID_fkey" FOREIGN KEY ("returnRequestID") REFERENCES "ReturnRequest"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "ReturnItem" ADD CONSTRAINT "ReturnItem_orderItemID_fkey" FOREIGN KEY ("orderItemID") REFERENCES "OrderItem"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Refund" ADD CONSTRAINT "Refund_orderID_fkey" FOREIGN KEY ("orderID") REFERENCES "Order"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Refund" ADD CONSTRAINT "Refund_paymentID_fkey" FOREIGN KEY ("paymentID") REFERENCES "Payment"("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Refund" ADD CONSTRAINT "Refund_returnRequestID_fkey" FOREIGN KEY ("returnRequestID") REFERENCES "ReturnRequest"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,28 @@
+-- AlterTable
+ALTER TABLE "Order" ADD COLUMN     "invoiceNumber" INTEGER,
+ADD COLUMN     "invoicedAt" TIMESTAMP(3);
+
+-- CreateTable
+CREATE TABLE "InvoiceCounter" (
+    "id" INTEGER NOT NULL DEFAULT 1,
+    "lastNumber" INTEGER NOT NULL DEFAULT 0,
+
+    CONSTRAINT "InvoiceCounter_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "Order_invoiceNumber_key" ON "Order"("invoiceNumber");
+
+-- Les commandes déjà payées ou expédiées sont numérotées dans l'ordre chronologique
+UPDATE "Order" o
+SET "invoiceNumber" = numbered."number",
+    "invoicedAt" = COALESCE(o."paidAt", o."updatedAt")
+FROM (
+    SELECT "id", ROW_NUMBER() OVER (ORDER BY COALESCE("paidAt", "updatedAt"), "id")::int AS "number"
+    FROM "Order"
+    WHERE "paidAt" IS NOT NULL OR "status" IN ('SHIPPED', 'DELIVERED')
+) numbered
+WHERE o."id" = numbered."id";
+
+INSERT INTO "InvoiceCounter" ("id", "lastNumber")
+SELECT 1, COALESCE(MAX("invoiceNumber"), 0) FROM "Order";
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Order" ADD COLUMN     "locale" TEXT NOT NULL DEFAULT 'fr';
@@ -0,0 +1,16 @@
+-- CreateEnum
+CREATE TYPE "ReviewStatus" AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+-- AlterTable
+ALTER TABLE "Review" ADD COLUMN     "moderatedAt" TIMESTAMP(3),
+ADD COLUMN     "moderationReason" TEXT,
+ADD COLUMN     "status" "ReviewStatus" NOT NULL DEFAULT 'PENDING';
+
+-- Les avis publiés avant la modération restent visibles
+UPDATE "Review" SET "status" = 'APPROVED';
+
+-- CreateIndex
+CREATE INDEX "Review_productID_status_idx" ON "Review"("productID", "status");
+
+-- CreateIndex
+CREATE INDEX "Review_status_createdAt_idx" ON "Review"("status", "createdAt");
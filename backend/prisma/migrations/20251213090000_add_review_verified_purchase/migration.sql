@@ -0,0 +1,12 @@
+-- AlterTable
+ALTER TABLE "Review" ADD COLUMN     "verifiedPurchase" BOOLEAN NOT NULL DEFAULT false;
+
+-- Avis existants dont l'auteur a reçu le produit
+UPDATE "Review" r SET "verifiedPurchase" = true
+WHERE EXISTS (
+    SELECT 1 FROM "OrderItem" oi
+    JOIN "Order" o ON o."id" = oi."orderID"
+    WHERE oi."productID" = r."productID"
+      AND o."userID" = r."userID"
+      AND o."status" = 'DELIVERED'
+);
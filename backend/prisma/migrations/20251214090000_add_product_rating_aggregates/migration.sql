@@ -0,0 +1,35 @@
+-- AlterTable
+ALTER TABLE "Product" ADD COLUMN     "rating1Count" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "rating2Count" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "rating3Count" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "rating4Count" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "rating5Count" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN     "ratingAverage" DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN     "ratingCount" INTEGER NOT NULL DEFAULT 0;
+
+-- CreateIndex
+CREATE INDEX "Product_ratingAverage_ratingCount_idx" ON "Product"("ratingAverage", "ratingCount");
+
+-- Agrégats des avis approuvés existants
+UPDATE "Product" p SET
+    "ratingCount" = s."count",
+    "ratingAverage" = s."average",
+    "rating1Count" = s."r1",
+    "rating2Count" = s."r2",
+    "rating3Count" = s."r3",
+    "rating4Count" = s."r4",
+    "rating5Count" = s."r5"
+FROM (
+    SELECT "productID",
+        COUNT(*)::int AS "count",
+        AVG("rating")::float8 AS "average",
+        COUNT(*) FILTER (WHERE "rating" = 1)::int AS "r1",
+        COUNT(*) FILTER (WHERE "rating" = 2)::int AS "r2",
+        COUNT(*) FILTER (WHERE "rating" = 3)::int AS "r3",
+        COUNT(*) FILTER (WHERE "rating" = 4)::int AS "r4",
+        COUNT(*) FILTER (WHERE "rating" = 5)::int AS "r5"
+    FROM "Review"
+    WHERE "status" = 'APPROVED'
+    GROUP BY "productID"
+) s
+WHERE p."id" = s."productID";
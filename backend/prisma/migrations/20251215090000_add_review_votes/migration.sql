@@ -0,0 +1,24 @@
+-- AlterTable
+ALTER TABLE "Review" ADD COLUMN     "helpfulCount" INTEGER NOT NULL DEFAULT 0;
+
+-- CreateTable
+CREATE TABLE "ReviewVote" (
+    "id" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "reviewID" TEXT NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "ReviewVote_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "ReviewVote_userID_idx" ON "ReviewVote"("userID");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "ReviewVote_reviewID_userID_key" ON "ReviewVote"("reviewID", "userID");
+
+-- AddForeignKey
+ALTER TABLE "ReviewVote" ADD CONSTRAINT "ReviewVote_reviewID_fkey" FOREIGN KEY ("reviewID") REFERENCES "Review"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "ReviewVote" ADD CONSTRAINT "ReviewVote_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
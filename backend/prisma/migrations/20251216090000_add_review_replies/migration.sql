@@ -0,0 +1,20 @@
+-- CreateTable
+CREATE TABLE "ReviewReply" (
+    "id" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "reviewID" TEXT NOT NULL,
+    "authorID" TEXT,
+
+    CONSTRAINT "ReviewReply_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "ReviewReply_reviewID_key" ON "ReviewReply"("reviewID");
+
+-- AddForeignKey
+ALTER TABLE "ReviewReply" ADD CONSTRAINT "ReviewReply_reviewID_fkey" FOREIGN KEY ("reviewID") REFERENCES "Review"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "ReviewReply" ADD CONSTRAINT "ReviewReply_authorID_fkey" FOREIGN KEY ("authorID") REFERENCES "User"("id") ON DELETE SET NULL ON UPDATE CASCADE;
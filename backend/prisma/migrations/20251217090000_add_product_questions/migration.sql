@@ -0,0 +1,110 @@
+-- CreateEnum
+CREATE TYPE "QuestionStatus" AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+-- CreateTable
+CREATE TABLE "Question" (
+    "id" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "status" "QuestionStatus" NOT NULL DEFAULT 'PENDING',
+    "moderationReason" TEXT,
+    "moderatedAt" TIMESTAMP(3),
+    "upvoteCount" INTEGER NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "userID" TEXT NOT NULL,
+    "productID" TEXT NOT NULL,
+
+    CONSTRAINT "Question_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "Answer" (
+    "id" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "status" "QuestionStatus" NOT NULL DEFAULT 'PENDING',
+    "moderationReason" TEXT,
+    "moderatedAt" TIMESTAMP(3),
+    "official" BOOLEAN NOT NULL DEFAULT false,
+    "verifiedPurchase" BOOLEAN NOT NULL DEFAULT false,
+    "upvoteCount" INTEGER NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+    "questionID" TEXT NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "Answer_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "QuestionVote" (
+    "id" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "questionID" TEXT NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "QuestionVote_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE "AnswerVote" (
+    "id" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "answerID" TEXT NOT NULL,
+    "userID" TEXT NOT NULL,
+
+    CONSTRAINT "AnswerVote_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "Question_productID_status_idx" ON "Question"("productID", "status");
+
+-- CreateIndex
+CREATE INDEX "Question_status_createdAt_idx" ON "Question"("status", "createdAt");
+
+-- CreateIndex
+CREATE INDEX "Question_userID_idx" ON "Question"("userID");
+
+-- CreateIndex
+CREATE INDEX "Answer_questionID_status_idx" ON "Answer"("questionID", "status");
+
+-- CreateIndex
+CREATE INDEX "Answer_status_createdAt_idx" ON "Answer"("status", "createdAt");
+
+-- CreateIndex
+CREATE INDEX "Answer_userID_idx" ON "Answer"("userID");
+
+-- CreateIndex
+CREATE INDEX "QuestionVote_userID_idx" ON "QuestionVote"("userID");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "QuestionVote_questionID_userID_key" ON "QuestionVote"("questionID", "userID");
+
+-- CreateIndex
+CREATE INDEX "AnswerVote_userID_idx" ON "AnswerVote"("userID");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "AnswerVote_answerID_userID_key" ON "AnswerVote"("answerID", "userID");
+
+-- AddForeignKey
+ALTER TABLE "Question" ADD CONSTRAINT "Question_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Question" ADD CONSTRAINT "Question_productID_fkey" FOREIGN KEY ("productID") REFERENCES "Product"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Answer" ADD CONSTRAINT "Answer_questionID_fkey" FOREIGN KEY ("questionID") REFERENCES "Question"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "Answer" ADD CONSTRAINT "Answer_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "QuestionVote" ADD CONSTRAINT "QuestionVote_questionID_fkey" FOREIGN KEY ("questionID") REFERENCES "Question"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "QuestionVote" ADD CONSTRAINT "QuestionVote_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "AnswerVote" ADD CONSTRAINT "AnswerVote_answerID_fkey" FOREIGN KEY ("answerID") REFERENCES "Answer"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "AnswerVote" ADD CONSTRAINT "AnswerVote_userID_fkey" FOREIGN KEY ("userID") REFERENCES "User"("id") ON DELETE CASCADE ON UPDATE CASCADE;
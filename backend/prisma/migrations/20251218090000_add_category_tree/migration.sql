@@ -0,0 +1,34 @@
+-- AlterTable
+ALTER TABLE "Category" ADD COLUMN     "description" TEXT,
+ADD COLUMN     "parentID" TEXT,
+ADD COLUMN     "slug" TEXT,
+ADD COLUMN     "sortOrder" INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill : slug dérivé du nom (minuscules, sans accents, mots séparés par des tirets)
+UPDATE "Category" SET "slug" = trim(both '-' from regexp_replace(
+    lower(translate("name",
+        'àâäáãåçéèêëíìîïñóòôöõúùûüýÿÀÂÄÁÃÅÇÉÈÊËÍÌÎÏÑÓÒÔÖÕÚÙÛÜÝ',
+        'aaaaaaceeeeiiiinooooouuuuyyAAAAAACEEEEIIIINOOOOOUUUUY')),
+    '[^a-z0-9]+', '-', 'g'));
+
+-- Slug vide ou déjà pris par une catégorie plus ancienne : suffixe tiré de l'identifiant
+UPDATE "Category" c SET "slug" = CASE
+        WHEN c."slug" = '' THEN 'categorie-' || substr(c."id", 1, 8)
+        ELSE c."slug" || '-' || substr(c."id", 1, 8)
+    END
+WHERE c."slug" = ''
+    OR EXISTS (
+        SELECT 1 FROM "Category" o
+        WHERE o."slug" = c."slug" AND (o."createdAt", o."id") < (c."createdAt", c."id")
+    );
+
+ALTER TABLE "Category" ALTER COLUMN "slug" SET NOT NULL;
+
+-- CreateIndex
+CREATE UNIQUE INDEX "Category_slug_key" ON "Category"("slug");
+
+-- CreateIndex
+CREATE INDEX "Category_parentID_sortOrder_idx" ON "Category"("parentID", "sortOrder");
+
+-- AddForeignKey
+ALTER TABLE "Category" ADD CONSTRAINT "Category_parentID_fkey" FOREIGN KEY ("parentID") REFERENCES "Category"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
@@ -0,0 +1,28 @@
+-- CreateFunction
+-- Revérifie les limites d'utilisation d'un code promo dans la transaction qui crée la commande.
+-- Le code promo est verrouillé jusqu'à la fin de la transaction : les commandes concurrentes
+-- utilisant le même code sont sérialisées et chacune compte les commandes validées avant elle.
+-- Les commandes annulées ne comptent pas.
+CREATE OR REPLACE FUNCTION "claim_coupon"(coupon_id TEXT, user_id TEXT) RETURNS void AS $$
+DECLARE
+    max_uses INTEGER;
+    max_uses_per_user INTEGER;
+BEGIN
+    SELECT "maxUses", "maxUsesPerUser" INTO max_uses, max_uses_per_user
+    FROM "Coupon" WHERE "id" = coupon_id FOR UPDATE;
+
+    IF max_uses IS NOT NULL AND (
+        SELECT COUNT(*) FROM "Order"
+        WHERE "couponID" = coupon_id AND "status" <> 'CANCELLED'
+    ) >= max_uses THEN
+        RAISE EXCEPTION 'code promo épuisé';
+    END IF;
+
+    IF max_uses_per_user IS NOT NULL AND (
+        SELECT COUNT(*) FROM "Order"
+        WHERE "couponID" = coupon_id AND "userID" = user_id AND "status" <> 'CANCELLED'
+    ) >= max_uses_per_user THEN
+        RAISE EXCEPTION 'code promo déjà utilisé le nombre maximal de fois';
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "OutboxEvent" ADD COLUMN     "deliveredTo" TEXT[] DEFAULT ARRAY[]::TEXT[];
@@ -0,0 +1,20 @@
+-- CreateTable
+CREATE TABLE "ModerationLog" (
+    "id" TEXT NOT NULL,
+    "entityType" TEXT NOT NULL,
+    "entityID" TEXT NOT NULL,
+    "productID" TEXT NOT NULL,
+    "authorID" TEXT NOT NULL,
+    "adminID" TEXT NOT NULL,
+    "reason" TEXT NOT NULL,
+    "content" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "ModerationLog_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "ModerationLog_entityType_entityID_idx" ON "ModerationLog"("entityType", "entityID");
+
+-- CreateIndex
+CREATE INDEX "ModerationLog_createdAt_idx" ON "ModerationLog"("createdAt");
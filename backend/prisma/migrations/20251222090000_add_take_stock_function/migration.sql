@@ -0,0 +1,17 @@
+-- CreateFunction
+-- Décrémente le stock d'une variante et de son produit dans la transaction qui crée la commande.
+-- La variante n'est décrémentée que si son stock suffit : la ligne est verrouillée par l'UPDATE,
+-- les commandes concurrentes sur la même variante sont sérialisées et ne peuvent pas la survendre.
+-- Un stock insuffisant annule toute la transaction.
+CREATE OR REPLACE FUNCTION "take_stock"(variant_id TEXT, product_id TEXT, quantity INTEGER) RETURNS void AS $$
+BEGIN
+    UPDATE "ProductVariant" SET "stock" = "stock" - quantity
+    WHERE "id" = variant_id AND "stock" >= quantity;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'stock insuffisant pour la variante %', variant_id;
+    END IF;
+
+    UPDATE "Product" SET "stock" = "stock" - quantity WHERE "id" = product_id;
+END;
+$$ LANGUAGE plpgsql;
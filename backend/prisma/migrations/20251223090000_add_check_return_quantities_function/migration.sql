@@ -0,0 +1,26 @@
+-- CreateFunction
+-- Revérifie les quantités retournées d'une commande dans la transaction qui crée une demande de retour,
+-- après l'insertion de ses articles. La commande est verrouillée jusqu'à la fin de la transaction :
+-- les demandes concurrentes sur la même commande sont sérialisées et chacune compte les retours validés avant elle.
+-- Les retours refusés ne comptent pas. Le verrou (FOR NO KEY UPDATE) ne bloque pas les clés étrangères posées
+-- sur la commande par les insertions, ce qui évite un interblocage entre deux demandes concurrentes.
+CREATE OR REPLACE FUNCTION "check_return_quantities"(order_id TEXT) RETURNS void AS $$
+DECLARE
+    order_item_id TEXT;
+BEGIN
+    PERFORM 1 FROM "Order" WHERE "id" = order_id FOR NO KEY UPDATE;
+
+    SELECT oi."id" INTO order_item_id
+    FROM "OrderItem" oi
+    JOIN "ReturnItem" ri ON ri."orderItemID" = oi."id"
+    JOIN "ReturnRequest" rr ON rr."id" = ri."returnRequestID"
+    WHERE oi."orderID" = order_id AND rr."status" <> 'REJECTED'
+    GROUP BY oi."id", oi."quantity"
+    HAVING SUM(ri."quantity") > oi."quantity"
+    LIMIT 1;
+
+    IF order_item_id IS NOT NULL THEN
+        RAISE EXCEPTION 'quantité retournée supérieure à la quantité retournable pour l''article %', order_item_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
@@ -2,6 +2,7 @@ package main
 
 import (
 	"api/internal/db"
+	"api/internal/utils"
 	"context"
 	"log"
 
@@ -86,6 +87,7 @@ func main() {
 		if err != nil || existingCat == nil {
 			category, err := client.Category.CreateOne(
 				db.Category.Name.Set(cat.name),
+				db.Category.Slug.Set(utils.Slugify(cat.name)),
 			).Exec(ctx)
 			if err != nil {
 				log.Printf("⚠️  Erreur lors de la création de la catégorie %s: %v", cat.name, err)